@@ -0,0 +1,255 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+var (
+	// ErrDependencyNotRunOnce is returned by AfterAll when a dependency is not a RunOnce task, it would never
+	// complete.
+	ErrDependencyNotRunOnce = errors.New("dependency is not a RunOnce task")
+	// ErrDependencyFailed is wrapped by the DependencyError delivered when a dependency of a task added with AfterAll
+	// failed for the last time.
+	ErrDependencyFailed = errors.New("dependency failed")
+	// ErrDependencyDeleted is wrapped by the DependencyError delivered when a dependency of a task added with AfterAll
+	// was removed before completing, e.g. with Del, by its CompleteBy deadline or by Stop.
+	ErrDependencyDeleted = errors.New("dependency deleted before completing")
+)
+
+// dependencyOutcome is how a dependency left the task list.
+type dependencyOutcome int
+
+const (
+	dependencySucceeded dependencyOutcome = iota
+	dependencyFailed
+	dependencyDeleted
+)
+
+// DependencyError is delivered to the error functions of a task added with AfterAll when one of its dependencies will
+// never complete as required. The task is then never scheduled.
+type DependencyError struct {
+	// TaskID is the ID returned by AfterAll.
+	TaskID string
+
+	// Dependency is the ID of the dependency that did not complete.
+	Dependency string
+
+	// Err is ErrDependencyFailed or ErrDependencyDeleted.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DependencyError) Error() string {
+	return fmt.Sprintf("task (id: %s) dependency %s: %s", e.TaskID, e.Dependency, e.Err.Error())
+}
+
+// Unwrap returns ErrDependencyFailed or ErrDependencyDeleted.
+func (e *DependencyError) Unwrap() error {
+	return e.Err
+}
+
+// AfterAllOptions configures AfterAllWithOptions.
+type AfterAllOptions struct {
+	// IncludeFailures counts dependencies that failed for the last time as complete. By default a failed dependency
+	// fails the task with ErrDependencyFailed.
+	IncludeFailures bool
+}
+
+// fanIn is a task waiting for its dependencies, see AfterAll.
+type fanIn struct {
+	id      string
+	task    *Task
+	pending map[string]struct{}
+	opts    AfterAllOptions
+}
+
+// fanIns tracks the tasks added with AfterAll until they are scheduled or failed.
+type fanIns struct {
+	sync.Mutex
+
+	// byID holds the waiting tasks by their ID, byDependency by the IDs of the dependencies they wait for.
+	byID         map[string]*fanIn
+	byDependency map[string][]*fanIn
+}
+
+// AfterAll will add the task once every one of the listed RunOnce tasks has completed successfully, e.g. to aggregate
+// the results of several jobs. The task is added when the last dependency completes, and is then scheduled as usual
+// from that moment. The returned ID is the ID it is added with; deleting it with Del before that cancels it.
+//
+// If a dependency fails for the last time or is removed without completing, the task is never added and a
+// DependencyError is delivered to its error functions. Use AfterAllWithOptions to count failed dependencies as
+// complete instead.
+//
+// It returns ErrTaskNotFound if a dependency is not in the task list, completed dependencies included, and
+// ErrDependencyNotRunOnce if a dependency is a recurring task.
+//
+//	// Aggregate once the three imports are done
+//	id, err := scheduler.AfterAll([]string{"import-a", "import-b", "import-c"}, &tasks.Task{
+//		RunOnce:  true,
+//		TaskFunc: aggregate,
+//		ErrFunc:  report,
+//	})
+//	if err != nil {
+//		// Do stuff
+//	}
+func (s *StdScheduler) AfterAll(ids []string, t *Task) (string, error) {
+	return s.AfterAllWithOptions(ids, t, AfterAllOptions{})
+}
+
+// AfterAllWithOptions will add the task once every one of the listed RunOnce tasks has completed, as configured by
+// opts. See AfterAll.
+func (s *StdScheduler) AfterAllWithOptions(ids []string, t *Task, opts AfterAllOptions) (string, error) {
+	if t.TaskFunc == nil && t.FuncWithID == nil && t.FuncWithTaskContext == nil {
+		return "", ErrTaskExecFunctionsNotSet
+	}
+
+	if t.ErrFunc == nil && t.ErrFuncWithID == nil && t.ErrFuncWithTaskContext == nil {
+		return "", ErrTaskErrFunctionsNotSet
+	}
+
+	f := &fanIn{
+		id:      newID(),
+		task:    t.CloneForReuse(),
+		pending: make(map[string]struct{}, len(ids)),
+		opts:    opts,
+	}
+
+	// The dependencies are registered before the task list is unlocked, so that none can complete unnoticed
+	s.RLock()
+	if s.stopped {
+		s.RUnlock()
+
+		return "", ErrSchedulerStopped
+	}
+
+	for _, id := range ids {
+		dep, ok := s.tasks[id]
+		if !ok {
+			s.RUnlock()
+
+			return "", fmt.Errorf("%w: dependency %s", ErrTaskNotFound, id)
+		}
+		if !dep.RunOnce {
+			s.RUnlock()
+
+			return "", fmt.Errorf("%w: dependency %s", ErrDependencyNotRunOnce, id)
+		}
+		f.pending[id] = struct{}{}
+	}
+
+	waits := len(f.pending) > 0
+	if waits {
+		s.fanIns.Lock()
+		if s.fanIns.byID == nil {
+			s.fanIns.byID = make(map[string]*fanIn)
+			s.fanIns.byDependency = make(map[string][]*fanIn)
+		}
+		s.fanIns.byID[f.id] = f
+		for id := range f.pending {
+			s.fanIns.byDependency[id] = append(s.fanIns.byDependency[id], f)
+		}
+		s.fanIns.Unlock()
+	}
+	s.RUnlock()
+
+	// Without dependencies to wait for, the task is added right away
+	if !waits {
+		if err := s.AddWithID(f.id, f.task); err != nil {
+			return "", err
+		}
+	}
+
+	return f.id, nil
+}
+
+// dependencyDone records how a task left the task list, adding the tasks waiting for it with AfterAll when it was
+// their last dependency, or failing them.
+func (s *StdScheduler) dependencyDone(id string, outcome dependencyOutcome) {
+	var ready, failed []*fanIn
+
+	s.fanIns.Lock()
+	waiting := s.fanIns.byDependency[id]
+	delete(s.fanIns.byDependency, id)
+	for _, f := range waiting {
+		if outcome == dependencyDeleted || (outcome == dependencyFailed && !f.opts.IncludeFailures) {
+			s.dropFanIn(f)
+			failed = append(failed, f)
+
+			continue
+		}
+
+		delete(f.pending, id)
+		if len(f.pending) == 0 {
+			delete(s.fanIns.byID, f.id)
+			ready = append(ready, f)
+		}
+	}
+	s.fanIns.Unlock()
+
+	for _, f := range ready {
+		logger.Debugf("task (id: %s) dependencies have completed, adding it", f.id)
+
+		if err := s.AddWithID(f.id, f.task); err != nil {
+			logger.Errorf("task (id: %s) could not be added once its dependencies completed: %s", f.id, err.Error())
+			s.failFanIn(f, err)
+		}
+	}
+
+	for _, f := range failed {
+		err := ErrDependencyDeleted
+		if outcome == dependencyFailed {
+			err = ErrDependencyFailed
+		}
+
+		depErr := &DependencyError{TaskID: f.id, Dependency: id, Err: err}
+		logger.Errorf("%s", depErr.Error())
+		s.failFanIn(f, depErr)
+	}
+}
+
+// dropFanIn stops tracking a task waiting for its dependencies. The fan-ins lock must be held.
+func (s *StdScheduler) dropFanIn(f *fanIn) {
+	delete(s.fanIns.byID, f.id)
+	for dep := range f.pending {
+		waiting := s.fanIns.byDependency[dep]
+		for i, w := range waiting {
+			if w == f {
+				waiting = append(waiting[:i], waiting[i+1:]...)
+				break
+			}
+		}
+
+		if len(waiting) == 0 {
+			delete(s.fanIns.byDependency, dep)
+		} else {
+			s.fanIns.byDependency[dep] = waiting
+		}
+	}
+}
+
+// cancelFanIn stops tracking the task with the given ID if it is waiting for its dependencies.
+func (s *StdScheduler) cancelFanIn(id string) {
+	s.fanIns.Lock()
+	defer s.fanIns.Unlock()
+
+	if f, ok := s.fanIns.byID[id]; ok {
+		s.dropFanIn(f)
+	}
+}
+
+// failFanIn delivers err to the error functions of the waiting task.
+func (s *StdScheduler) failFanIn(f *fanIn, err error) {
+	taskCtx := f.task.TaskContext
+	if taskCtx.Context == nil {
+		taskCtx.Context = context.Background()
+	}
+	taskCtx.id = f.id
+	f.task.id = f.id
+
+	go s.deliverError(f.task, taskCtx, err)
+}
@@ -0,0 +1,184 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// dependency returns a RunOnce task running after delay and returning err.
+func dependency(delay time.Duration, err error) *Task {
+	return &Task{
+		Interval: delay,
+		RunOnce:  true,
+		TaskFunc: func() error { return err },
+		ErrFunc:  func(error) {},
+	}
+}
+
+func TestAfterAll(t *testing.T) {
+	t.Run("Verify the task is added once every dependency succeeded", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("a", dependency(10*time.Millisecond, nil)))
+		assert.NoError(scheduler.AddWithID("b", dependency(30*time.Millisecond, nil)))
+		assert.NoError(scheduler.AddWithID("c", dependency(60*time.Millisecond, nil)))
+
+		ran := make(chan time.Time, 1)
+		id, err := scheduler.AfterAll([]string{"a", "b", "c", "a"}, &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			FuncWithID: func(id string) error {
+				ran <- time.Now()
+				return nil
+			},
+			ErrFunc: func(err error) { t.Errorf("unexpected error: %s", err) },
+		})
+		assert.NoError(err)
+		assert.NotEmpty(id)
+		assert.False(scheduler.Has(id))
+
+		select {
+		case at := <-ran:
+			assert.False(scheduler.Has("c"))
+			assert.WithinDuration(time.Now(), at, time.Second)
+		case <-time.After(time.Second):
+			t.Fatalf("task did not run within 1 second")
+		}
+	})
+
+	t.Run("Verify a failed dependency fails the task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errDep := errors.New("import failed")
+		assert.NoError(scheduler.AddWithID("a", dependency(10*time.Millisecond, nil)))
+		assert.NoError(scheduler.AddWithID("b", dependency(20*time.Millisecond, errDep)))
+
+		errCh := make(chan error, 1)
+		id, err := scheduler.AfterAll([]string{"a", "b"}, &Task{
+			RunOnce:  true,
+			TaskFunc: func() error { t.Errorf("task ran despite a failed dependency"); return nil },
+			ErrFuncWithID: func(id string, err error) {
+				assert.NotEmpty(id)
+				errCh <- err
+			},
+		})
+		assert.NoError(err)
+
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(err, ErrDependencyFailed)
+			var depErr *DependencyError
+			assert.ErrorAs(err, &depErr)
+			assert.Equal(id, depErr.TaskID)
+			assert.Equal("b", depErr.Dependency)
+		case <-time.After(time.Second):
+			t.Fatalf("dependency failure was not delivered within 1 second")
+		}
+		assert.False(scheduler.Has(id))
+	})
+
+	t.Run("Verify failed dependencies can count as complete", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("a", dependency(10*time.Millisecond, errors.New("failed"))))
+
+		ran := make(chan struct{})
+		_, err := scheduler.AfterAllWithOptions([]string{"a"}, &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				close(ran)
+				return nil
+			},
+			ErrFunc: func(err error) { t.Errorf("unexpected error: %s", err) },
+		}, AfterAllOptions{IncludeFailures: true})
+		assert.NoError(err)
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatalf("task did not run within 1 second")
+		}
+	})
+
+	t.Run("Verify a deleted dependency fails the task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("a", dependency(time.Minute, nil)))
+
+		errCh := make(chan error, 1)
+		_, err := scheduler.AfterAll([]string{"a"}, &Task{
+			RunOnce:  true,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(err error) { errCh <- err },
+		})
+		assert.NoError(err)
+
+		scheduler.Del("a")
+
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(err, ErrDependencyDeleted)
+		case <-time.After(time.Second):
+			t.Fatalf("dependency deletion was not delivered within 1 second")
+		}
+	})
+
+	t.Run("Verify deleting the task cancels it", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("a", dependency(10*time.Millisecond, nil)))
+
+		id, err := scheduler.AfterAll([]string{"a"}, &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error { t.Errorf("cancelled task ran"); return nil },
+			ErrFunc:  func(err error) { t.Errorf("unexpected error: %s", err) },
+		})
+		assert.NoError(err)
+		scheduler.Del(id)
+
+		assert.Eventually(func() bool { return !scheduler.Has("a") }, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		assert.False(scheduler.Has(id))
+	})
+
+	t.Run("Verify invalid dependencies are rejected", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("recurring", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		task := dependency(time.Millisecond, nil)
+		_, err := scheduler.AfterAll([]string{"missing"}, task)
+		assert.ErrorIs(err, ErrTaskNotFound)
+		_, err = scheduler.AfterAll([]string{"recurring"}, task)
+		assert.ErrorIs(err, ErrDependencyNotRunOnce)
+		_, err = scheduler.AfterAll([]string{"recurring"}, &Task{RunOnce: true})
+		assert.ErrorIs(err, ErrTaskExecFunctionsNotSet)
+	})
+}
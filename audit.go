@@ -0,0 +1,255 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// defaultAuditQueueSize is the number of records buffered for the AuditWriter when
+// StdSchedulerOptions.AuditQueueSize is not set.
+const defaultAuditQueueSize = 1024
+
+// Execution outcomes reported in AuditRecord.Outcome.
+const (
+	// AuditOutcomeSuccess is an execution that succeeded.
+	AuditOutcomeSuccess = "success"
+	// AuditOutcomeFailure is an execution that failed without being retried.
+	AuditOutcomeFailure = "failure"
+	// AuditOutcomeRetry is an execution that failed and armed a retry or a reschedule on error.
+	AuditOutcomeRetry = "retry"
+	// AuditOutcomeYielded is an execution that returned ErrYielded, to resume on the next execution.
+	AuditOutcomeYielded = "yielded"
+)
+
+// AuditRecord is the record of a single execution, written to the StdSchedulerOptions.AuditWriter.
+type AuditRecord struct {
+	// TaskID is the ID of the executed task.
+	TaskID string
+
+	// RunSequence is the execution cycle of the task, see TaskContext.RunSequence.
+	RunSequence uint64
+
+	// Scheduled is when the execution was meant to fire, Enqueued when it started waiting for a worker. See
+	// RunTimes.
+	Scheduled time.Time
+	Enqueued  time.Time
+
+	// Start and End are when the execution started and ended.
+	Start time.Time
+	End   time.Time
+
+	// Outcome is the outcome of the execution, e.g. AuditOutcomeSuccess.
+	Outcome string
+
+	// Err is the error returned by the execution, nil on success.
+	Err error
+
+	// Trigger is why the execution happened, see Trigger.
+	Trigger Trigger
+
+	// DryRun is set for executions of a task with Task.DryRun enabled.
+	DryRun bool
+}
+
+// AuditWriter writes execution records to an external sink, see StdSchedulerOptions.AuditWriter.
+type AuditWriter interface {
+	WriteRecord(ctx context.Context, r AuditRecord) error
+}
+
+// AuditStats counts the records handed to the StdSchedulerOptions.AuditWriter.
+type AuditStats struct {
+	// Written is the number of records written successfully.
+	Written uint64
+
+	// Failed is the number of records the writer returned an error for.
+	Failed uint64
+
+	// Dropped is the number of records dropped because the queue was full, or because the scheduler was stopped.
+	Dropped uint64
+}
+
+// auditor buffers records in a bounded queue, written by a background flusher so that slow writers do not stall
+// executions.
+type auditor struct {
+	w AuditWriter
+
+	// mu guards closed, records are only queued while the queue is open.
+	mu     sync.RWMutex
+	closed bool
+	queue  chan AuditRecord
+	done   chan struct{}
+
+	// report is called with the records that could not be written or were dropped, see reportInternalError.
+	report func(source string, err error)
+
+	written, failed, dropped atomic.Uint64
+}
+
+// newAuditor starts the flusher of w.
+func newAuditor(w AuditWriter, size int, report func(source string, err error)) *auditor {
+	if size <= 0 {
+		size = defaultAuditQueueSize
+	}
+
+	a := &auditor{
+		w:      w,
+		queue:  make(chan AuditRecord, size),
+		done:   make(chan struct{}),
+		report: report,
+	}
+	go a.flush()
+
+	return a
+}
+
+// record queues r, or drops it when the queue is full.
+func (a *auditor) record(r AuditRecord) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		a.drop(r, "audit queue is closed")
+		return
+	}
+
+	select {
+	case a.queue <- r:
+	default:
+		a.drop(r, "audit queue is full")
+	}
+}
+
+// drop counts a record that could not be queued.
+func (a *auditor) drop(r AuditRecord, reason string) {
+	a.dropped.Add(1)
+	a.report(InternalErrorAuditDrop, fmt.Errorf("task (id: %s) audit record dropped: %s", r.TaskID, reason))
+}
+
+// flush writes the queued records until the queue is closed and drained.
+func (a *auditor) flush() {
+	defer close(a.done)
+
+	for r := range a.queue {
+		if err := a.w.WriteRecord(context.Background(), r); err != nil {
+			a.failed.Add(1)
+			logger.Errorf("task (id: %s) audit record could not be written: %s", r.TaskID, err.Error())
+			a.report(InternalErrorAuditWrite, fmt.Errorf("task (id: %s) audit record could not be written: %w",
+				r.TaskID, err))
+
+			continue
+		}
+		a.written.Add(1)
+	}
+}
+
+// close stops queueing records and waits for the queued ones to be written.
+func (a *auditor) close() {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+}
+
+// AuditStats will return the number of records written, failed and dropped by the StdSchedulerOptions.AuditWriter,
+// or zero values without one.
+func (s *StdScheduler) AuditStats() AuditStats {
+	if s.auditor == nil {
+		return AuditStats{}
+	}
+
+	return AuditStats{
+		Written: s.auditor.written.Load(),
+		Failed:  s.auditor.failed.Load(),
+		Dropped: s.auditor.dropped.Load(),
+	}
+}
+
+// audit records an execution of the task, if an AuditWriter is set.
+func (s *StdScheduler) audit(t *Task, taskCtx TaskContext, err error, retried bool) {
+	if s.auditor == nil {
+		return
+	}
+
+	outcome := AuditOutcomeSuccess
+	switch {
+	case errors.Is(err, ErrYielded):
+		outcome, err = AuditOutcomeYielded, nil
+	case err != nil && retried:
+		outcome = AuditOutcomeRetry
+	case err != nil:
+		outcome = AuditOutcomeFailure
+	}
+
+	s.auditor.record(AuditRecord{
+		TaskID:      t.id,
+		RunSequence: taskCtx.runSequence,
+		Scheduled:   taskCtx.runTimes.Scheduled,
+		Enqueued:    taskCtx.runTimes.Enqueued,
+		Start:       taskCtx.runTimes.Started,
+		End:         time.Now(),
+		Outcome:     outcome,
+		Err:         err,
+		Trigger:     taskCtx.trigger,
+		DryRun:      taskCtx.dryRun,
+	})
+}
+
+// JSONLinesAuditWriter is the reference AuditWriter, writing every record as a line of JSON.
+type JSONLinesAuditWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+	c  io.Closer
+}
+
+// NewJSONLinesAuditWriter will return an AuditWriter writing records as lines of JSON to w.
+func NewJSONLinesAuditWriter(w io.Writer) *JSONLinesAuditWriter {
+	return &JSONLinesAuditWriter{w: w}
+}
+
+// OpenJSONLinesAuditFile will return an AuditWriter appending records as lines of JSON to the file at path, created
+// if needed. The file is closed with Close.
+func OpenJSONLinesAuditFile(path string) (*JSONLinesAuditWriter, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLinesAuditWriter{w: f, c: f}, nil
+}
+
+// WriteRecord writes r as a line of JSON, see AuditRecord.MarshalJSON.
+func (j *JSONLinesAuditWriter) WriteRecord(_ context.Context, r AuditRecord) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err = j.w.Write(append(line, '\n'))
+
+	return err
+}
+
+// Close closes the file opened by OpenJSONLinesAuditFile, it does nothing for other writers.
+func (j *JSONLinesAuditWriter) Close() error {
+	if j.c == nil {
+		return nil
+	}
+
+	return j.c.Close()
+}
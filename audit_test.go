@@ -0,0 +1,255 @@
+package tasks
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// memoryAuditWriter keeps the written records, optionally blocking every write until release is closed.
+type memoryAuditWriter struct {
+	mu      sync.Mutex
+	records []AuditRecord
+	release chan struct{}
+	err     error
+}
+
+func (m *memoryAuditWriter) WriteRecord(_ context.Context, r AuditRecord) error {
+	if m.release != nil {
+		<-m.release
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	m.records = append(m.records, r)
+
+	return nil
+}
+
+func (m *memoryAuditWriter) byTask(id string) []AuditRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rr []AuditRecord
+	for _, r := range m.records {
+		if r.TaskID == id {
+			rr = append(rr, r)
+		}
+	}
+
+	return rr
+}
+
+func TestAuditWriter(t *testing.T) {
+	t.Run("Verify records of successful, failed and retried executions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		w := &memoryAuditWriter{}
+		scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: w})
+
+		errFailed := errors.New("failed")
+		before := time.Now()
+
+		assert.NoError(scheduler.AddWithID("success", &Task{
+			Interval: 10 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.NoError(scheduler.AddWithID("failure", &Task{
+			Interval: 10 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error { return errFailed },
+			ErrFunc:  func(error) {},
+		}))
+		assert.NoError(scheduler.AddWithID("retry", &Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			TaskFunc:             func() error { return errFailed },
+			ErrFunc:              func(error) {},
+		}))
+
+		assert.Eventually(func() bool {
+			return !scheduler.Has("success") && !scheduler.Has("failure") && !scheduler.Has("retry")
+		}, time.Second, time.Millisecond)
+		scheduler.Stop()
+
+		rr := w.byTask("success")
+		if assert.Len(rr, 1) {
+			assert.Equal(AuditOutcomeSuccess, rr[0].Outcome)
+			assert.NoError(rr[0].Err)
+			assert.Equal(TriggerInterval, rr[0].Trigger)
+			assert.Equal(uint64(1), rr[0].RunSequence)
+			assert.False(rr[0].Start.Before(before))
+			assert.False(rr[0].End.Before(rr[0].Start))
+			assert.False(rr[0].Scheduled.IsZero())
+			assert.False(rr[0].Start.Before(rr[0].Enqueued))
+		}
+
+		rr = w.byTask("failure")
+		if assert.Len(rr, 1) {
+			assert.Equal(AuditOutcomeFailure, rr[0].Outcome)
+			assert.ErrorIs(rr[0].Err, errFailed)
+		}
+
+		rr = w.byTask("retry")
+		if assert.Len(rr, 2) {
+			assert.Equal(AuditOutcomeRetry, rr[0].Outcome)
+			assert.Equal(AuditOutcomeFailure, rr[1].Outcome)
+			assert.Equal(TriggerRetry, rr[1].Trigger)
+			assert.Equal(rr[0].RunSequence, rr[1].RunSequence)
+		}
+
+		assert.Equal(AuditStats{Written: 4}, scheduler.AuditStats())
+	})
+
+	t.Run("Verify a slow writer does not stall executions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		w := &memoryAuditWriter{release: make(chan struct{})}
+		scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: w, AuditQueueSize: 2})
+
+		var (
+			mu   sync.Mutex
+			runs int
+		)
+		assert.NoError(scheduler.AddWithID("slow", &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				runs++
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return runs >= 10
+		}, time.Second, time.Millisecond)
+		assert.NotZero(scheduler.AuditStats().Dropped)
+
+		close(w.release)
+		scheduler.Stop()
+
+		assert.NotZero(scheduler.AuditStats().Written)
+	})
+
+	t.Run("Verify Stop writes the queued records", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		w := &memoryAuditWriter{release: make(chan struct{})}
+		scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: w})
+
+		for _, id := range []string{"a", "b", "c"} {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: 5 * time.Millisecond,
+				RunOnce:  true,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+		}
+		assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+
+		stopped := make(chan struct{})
+		go func() {
+			scheduler.Stop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			assert.Fail("Stop returned before the records were written")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(w.release)
+		<-stopped
+
+		assert.Len(w.byTask("a"), 1)
+		assert.Len(w.byTask("b"), 1)
+		assert.Len(w.byTask("c"), 1)
+		assert.Equal(AuditStats{Written: 3}, scheduler.AuditStats())
+	})
+
+	t.Run("Verify writer failures are counted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		w := &memoryAuditWriter{err: errors.New("sink unavailable")}
+		scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: w})
+
+		assert.NoError(scheduler.AddWithID("failing-sink", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.Eventually(func() bool { return !scheduler.Has("failing-sink") }, time.Second, time.Millisecond)
+		scheduler.Stop()
+
+		assert.Equal(AuditStats{Failed: 1}, scheduler.AuditStats())
+	})
+}
+
+func TestJSONLinesAuditWriter(t *testing.T) {
+	assert := assertions.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	w, err := OpenJSONLinesAuditFile(path)
+	if !assert.NoError(err) {
+		return
+	}
+
+	start := time.Now().UTC()
+	assert.NoError(w.WriteRecord(context.Background(), AuditRecord{
+		TaskID:      "task",
+		RunSequence: 3,
+		Start:       start,
+		End:         start.Add(time.Second),
+		Outcome:     AuditOutcomeFailure,
+		Err:         errors.New("failed"),
+		Trigger:     TriggerRetry,
+	}))
+	assert.NoError(w.WriteRecord(context.Background(), AuditRecord{TaskID: "other", Outcome: AuditOutcomeSuccess}))
+	assert.NoError(w.Close())
+
+	f, err := os.Open(path)
+	if !assert.NoError(err) {
+		return
+	}
+	defer f.Close()
+
+	var lines []map[string]any
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var line map[string]any
+		assert.NoError(json.Unmarshal(sc.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	if assert.Len(lines, 2) {
+		assert.Equal("task", lines[0]["task_id"])
+		assert.Equal(float64(3), lines[0]["run_sequence"])
+		assert.Equal(start.Format(time.RFC3339Nano), lines[0]["start"])
+		assert.Equal("failure", lines[0]["outcome"])
+		assert.Equal("failed", lines[0]["error"])
+		assert.Equal(TriggerRetry.String(), lines[0]["trigger"])
+		assert.NotContains(lines[1], "error")
+	}
+}
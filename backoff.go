@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryBackoff computes the delay before each retry of a failed task, see Task.RetryBackoff.
+type RetryBackoff interface {
+	// NextDelay returns the delay before the retry, attempt being 1 for the first retry of the task.
+	NextDelay(attempt int) time.Duration
+}
+
+// FixedBackoff retries after the same delay every time, like Task.RetryOnErrorInterval.
+type FixedBackoff struct {
+	Delay time.Duration
+}
+
+// NextDelay implements RetryBackoff.
+func (b FixedBackoff) NextDelay(int) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff doubles the delay after every retry, starting from Base and capped at Max.
+type ExponentialBackoff struct {
+	// Base is the delay before the first retry.
+	Base time.Duration
+
+	// Max caps the delay, zero leaves it uncapped.
+	Max time.Duration
+
+	// Jitter, between 0 and 1, is the fraction of the delay randomly taken off each retry, so that tasks failing
+	// together do not retry together. Zero retries after the exact delay.
+	Jitter float64
+}
+
+// NextDelay implements RetryBackoff.
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	d := b.Base
+	for i := 1; i < attempt && d > 0; i++ {
+		// Stop doubling once capped, or before overflowing
+		if (b.Max > 0 && d >= b.Max) || d > time.Duration(1<<62) {
+			break
+		}
+		d *= 2
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+
+	if b.Jitter > 0 {
+		jitter := b.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+
+	return d
+}
+
+// resetRetryCycle ends the retries of the current cycle once it succeeded or used them all, the next failure gets
+// the full RetriesOnError budget again. The task lock must be held.
+func (t *Task) resetRetryCycle() {
+	t.retriesRemaining, t.retryAttempts = t.RetriesOnError, 0
+}
+
+// retryDelay is the delay before the next retry of the task, from its RetryBackoff if any. The task lock must be
+// held, and retryAttempts already count the retry.
+func (t *Task) retryDelay() time.Duration {
+	if t.RetryBackoff != nil {
+		return t.RetryBackoff.NextDelay(t.retryAttempts)
+	}
+
+	return t.RetryOnErrorInterval
+}
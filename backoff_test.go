@@ -0,0 +1,111 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	t.Run("Verify exponential delays double and are capped", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		b := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+		var delays []time.Duration
+		for attempt := 1; attempt <= 6; attempt++ {
+			delays = append(delays, b.NextDelay(attempt))
+		}
+		assert.Equal([]time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+			time.Second,
+		}, delays)
+
+		assert.Equal(100*time.Millisecond, b.NextDelay(0))
+		assert.Equal(time.Second, b.NextDelay(1000))
+		assert.Greater(ExponentialBackoff{Base: time.Second}.NextDelay(1000), time.Duration(0))
+		assert.Equal(time.Minute, FixedBackoff{Delay: time.Minute}.NextDelay(3))
+	})
+
+	t.Run("Verify jitter only shortens the delay", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		b := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second, Jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			d := b.NextDelay(3)
+			assert.GreaterOrEqual(d, 200*time.Millisecond)
+			assert.LessOrEqual(d, 400*time.Millisecond)
+		}
+	})
+
+	t.Run("Verify retries are delayed by the backoff", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			mu     sync.Mutex
+			starts []time.Time
+		)
+		done := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("backoff", &Task{
+			Interval:       time.Millisecond,
+			RunOnce:        true,
+			RetriesOnError: 4,
+			RetryBackoff:   ExponentialBackoff{Base: 20 * time.Millisecond, Max: 80 * time.Millisecond},
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				starts = append(starts, time.Now())
+				if len(starts) == 5 {
+					close(done)
+				}
+				return errors.New("rate limited")
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("the task was not retried 4 times")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// 20ms, 40ms, 80ms, then capped at 80ms
+		for i, want := range []time.Duration{20, 40, 80, 80} {
+			gap := starts[i+1].Sub(starts[i])
+			assert.GreaterOrEqual(gap, want*time.Millisecond, "retry %d", i+1)
+			assert.Less(gap, want*time.Millisecond+50*time.Millisecond, "retry %d", i+1)
+		}
+	})
+
+	t.Run("Verify the retry interval is optional with a backoff", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		task := &Task{
+			Interval:       time.Hour,
+			RunOnce:        true,
+			RetriesOnError: 1,
+			TaskFunc:       func() error { return nil },
+			ErrFunc:        func(error) {},
+		}
+		assert.ErrorIs(scheduler.AddWithID("no-interval", task), ErrRetryOnErrorIntervalEmpty)
+
+		task.RetryBackoff = FixedBackoff{Delay: time.Second}
+		assert.NoError(scheduler.AddWithID("no-interval", task))
+	})
+}
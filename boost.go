@@ -0,0 +1,177 @@
+package tasks
+
+import (
+	"time"
+)
+
+// Reasons reported to StdSchedulerOptions.OnScheduleChange when a boost re-arms a task.
+const (
+	scheduleReasonBoost      = "boost"
+	scheduleReasonBoostEnded = "boost ended"
+)
+
+// Boost will run the recurring task every interval instead of its configured Interval until the given time, e.g. to
+// poll more often during an incident. A pending execution is brought forward to at most interval from now. Once the
+// boost ends, the task reverts to its configured Interval counted from its latest execution. Retries and reschedules
+// on error keep their own intervals. Boosting a boosted task replaces its boost.
+//
+// It returns ErrTaskNotFound if the task does not exist, and ErrInvalidBoost if the interval is not positive, the end
+// is not in the future or the task runs only once.
+//
+//	// Poll every 5 seconds for the next 10 minutes
+//	err := scheduler.Boost(id, 5*time.Second, time.Now().Add(10*time.Minute))
+func (s *StdScheduler) Boost(id string, interval time.Duration, until time.Time) error {
+	t, err := s.scheduled(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if interval <= 0 || !until.After(now) {
+		return ErrInvalidBoost
+	}
+
+	var (
+		next  time.Time
+		armed bool
+	)
+	t.safeOps(func() {
+		if t.state == TaskStateRemoved {
+			err = ErrTaskNotFound
+			return
+		}
+		if t.RunOnce {
+			err = ErrInvalidBoost
+			return
+		}
+
+		if t.boostTimer != nil {
+			t.boostTimer.Stop()
+		}
+
+		t.boostSeq++
+		seq := t.boostSeq
+		t.boostTimer = time.AfterFunc(until.Sub(now), func() { s.endBoost(t, seq) })
+		t.boostInterval, t.boostUntil = interval, until
+
+		// Bring the pending execution forward
+		if t.waitsForInterval() && t.nextFire.After(now.Add(interval)) {
+			next, armed = s.resetTimer(t, interval, DecisionTimerArmed, t.trigger)
+		}
+	})
+	if armed {
+		s.notifyScheduleChange(id, next, scheduleReasonBoost)
+	}
+
+	return err
+}
+
+// Unboost will end the boost of the task early, reverting it to its configured Interval counted from its latest
+// execution. It returns ErrTaskNotFound if the task does not exist, unboosting a task that is not boosted does
+// nothing.
+func (s *StdScheduler) Unboost(id string) error {
+	t, err := s.scheduled(id)
+	if err != nil {
+		return err
+	}
+
+	var seq uint64
+	t.safeOps(func() {
+		seq = t.boostSeq
+	})
+	s.endBoost(t, seq)
+
+	return nil
+}
+
+// ActiveBoost will return the interval and end of the boost of the task, see StdScheduler.Boost, or zero values if
+// the task is not boosted.
+func (t *Task) ActiveBoost() (interval time.Duration, until time.Time) {
+	t.safeOps(func() {
+		if time.Now().Before(t.boostUntil) {
+			interval, until = t.boostInterval, t.boostUntil
+		}
+	})
+
+	return interval, until
+}
+
+// scheduled returns the scheduled task with the given ID.
+func (s *StdScheduler) scheduled(id string) (*Task, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	return t, nil
+}
+
+// endBoost reverts the task to its configured Interval, unless the boost numbered seq has already ended or been
+// replaced.
+func (s *StdScheduler) endBoost(t *Task, seq uint64) {
+	var (
+		next  time.Time
+		armed bool
+	)
+	t.safeOps(func() {
+		if t.boostSeq != seq || t.boostTimer == nil {
+			return
+		}
+
+		t.boostTimer.Stop()
+		t.boostTimer = nil
+		t.boostInterval, t.boostUntil = 0, time.Time{}
+
+		// Re-anchor the pending execution on the latest one, or on the next matching time of a cron task
+		now := time.Now()
+		if !t.waitsForInterval() || !t.nextFire.After(now) {
+			return
+		}
+
+		var wait time.Duration
+		if t.cron != nil {
+			wait = t.cron.delay(now)
+		} else {
+			anchor := t.lastStart
+			if anchor.IsZero() {
+				anchor = now
+			}
+			if wait = anchor.Add(t.Interval).Sub(now); wait < 0 {
+				wait = 0
+			}
+		}
+		next, armed = s.resetTimer(t, wait, DecisionTimerArmed, t.trigger)
+	})
+	if armed {
+		s.notifyScheduleChange(t.id, next, scheduleReasonBoostEnded)
+	}
+}
+
+// waitsForInterval tells whether the pending timer of the task was armed with its interval, rather than for a retry
+// or a reschedule. The task lock must be held.
+func (t *Task) waitsForInterval() bool {
+	return !t.retryPending && (t.trigger == TriggerInterval || t.trigger == TriggerStartAfter)
+}
+
+// boosted reports whether a boost is active at now. The task lock must be held.
+func (t *Task) boosted(now time.Time) bool {
+	return t.boostInterval > 0 && now.Before(t.boostUntil)
+}
+
+// interval returns the interval until the next execution of a recurring task, its boost while one is active. For a
+// cron task it is the time until the next matching time. The task lock must be held.
+func (t *Task) interval() time.Duration {
+	now := time.Now()
+	if t.boosted(now) {
+		return t.boostInterval
+	}
+
+	if t.cron != nil {
+		return t.cron.delay(now)
+	}
+
+	return t.Interval
+}
@@ -0,0 +1,129 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestBoost(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	addTask := func(t *testing.T, id string, runs *atomic.Int32) {
+		err := scheduler.AddWithID(id, &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error {
+				runs.Add(1)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assertions.NoError(t, err)
+		t.Cleanup(func() { scheduler.Del(id) })
+	}
+
+	t.Run("Verify the task runs at the boosted interval until the boost ends", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, "boosted", &runs)
+
+		until := time.Now().Add(100 * time.Millisecond)
+		assert.NoError(scheduler.Boost("boosted", 10*time.Millisecond, until))
+
+		task, err := scheduler.Lookup("boosted")
+		assert.NoError(err)
+		interval, boostedUntil := task.ActiveBoost()
+		assert.Equal(10*time.Millisecond, interval)
+		assert.Equal(until, boostedUntil)
+
+		time.Sleep(time.Until(until))
+		boosted := runs.Load()
+		assert.GreaterOrEqual(boosted, int32(3))
+
+		// Once reverted, the next execution is an hour after the latest one
+		time.Sleep(100 * time.Millisecond)
+		assert.LessOrEqual(runs.Load(), boosted+1)
+
+		task, err = scheduler.Lookup("boosted")
+		assert.NoError(err)
+		interval, _ = task.ActiveBoost()
+		assert.Zero(interval)
+		assert.WithinDuration(time.Now().Add(time.Hour), task.nextFire, 200*time.Millisecond)
+	})
+
+	t.Run("Verify Unboost ends the boost early", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, "unboosted", &runs)
+
+		assert.NoError(scheduler.Boost("unboosted", 10*time.Millisecond, time.Now().Add(time.Hour)))
+		assert.Eventually(func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+
+		assert.NoError(scheduler.Unboost("unboosted"))
+		unboosted := runs.Load()
+
+		time.Sleep(100 * time.Millisecond)
+		assert.LessOrEqual(runs.Load(), unboosted+1)
+
+		// Unboosting again does nothing
+		assert.NoError(scheduler.Unboost("unboosted"))
+	})
+
+	t.Run("Verify a cron task returns to its schedule once the boost ends", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		assert.NoError(scheduler.AddWithID("boosted-cron", &Task{
+			CronExpr: "0 * * * *",
+			Location: time.UTC,
+			TaskFunc: func() error {
+				runs.Add(1)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+		t.Cleanup(func() { scheduler.Del("boosted-cron") })
+
+		assert.NoError(scheduler.Boost("boosted-cron", 50*time.Millisecond, time.Now().Add(time.Hour)))
+		assert.Eventually(func() bool { return runs.Load() >= 1 }, time.Second, time.Millisecond)
+
+		// Once reverted between two boosted executions, the next one is on the next matching time rather than right
+		// away
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(scheduler.Unboost("boosted-cron"))
+		reverted := runs.Load()
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(reverted, runs.Load())
+
+		task, err := scheduler.Lookup("boosted-cron")
+		if assert.NoError(err) {
+			assert.Equal(time.Now().UTC().Truncate(time.Hour).Add(time.Hour), task.NextRun().UTC())
+		}
+	})
+
+	t.Run("Verify invalid boosts are rejected", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, "invalid", &runs)
+
+		assert.ErrorIs(scheduler.Boost("missing", time.Second, time.Now().Add(time.Minute)), ErrTaskNotFound)
+		assert.ErrorIs(scheduler.Unboost("missing"), ErrTaskNotFound)
+		assert.ErrorIs(scheduler.Boost("invalid", 0, time.Now().Add(time.Minute)), ErrInvalidBoost)
+		assert.ErrorIs(scheduler.Boost("invalid", time.Second, time.Now().Add(-time.Minute)), ErrInvalidBoost)
+
+		assert.NoError(scheduler.AddWithID("invalid-run-once", &Task{
+			RunOnce:    true,
+			StartAfter: time.Now().Add(time.Hour),
+			TaskFunc:   func() error { return nil },
+			ErrFunc:    func(e error) {},
+		}))
+		defer scheduler.Del("invalid-run-once")
+		assert.ErrorIs(scheduler.Boost("invalid-run-once", time.Second, time.Now().Add(time.Minute)), ErrInvalidBoost)
+	})
+}
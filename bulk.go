@@ -0,0 +1,86 @@
+package tasks
+
+import "time"
+
+// removalReasonBulk is reported when a task is removed with DelWhere.
+const removalReasonBulk = "bulk"
+
+// TaskInfo is a lightweight description of a task, passed to the predicate of DelWhere.
+type TaskInfo struct {
+	// ID is the ID of the task.
+	ID string
+
+	// Interval is Task.Interval.
+	Interval time.Duration
+
+	// CronExpr is Task.CronExpr.
+	CronExpr string
+
+	// RunOnce is Task.RunOnce.
+	RunOnce bool
+
+	// Lane is Task.Lane.
+	Lane string
+
+	// Group is Task.Group.
+	Group string
+
+	// State is the lifecycle state of the task.
+	State TaskState
+
+	// NextRun is when the task is due to execute next, zero if no execution is pending. See Task.NextRun.
+	NextRun time.Time
+}
+
+// DelWhere will delete every task the predicate returns true for, and return the IDs of the deleted tasks. The
+// predicate is called with no lock held, so it may use the scheduler, on infos taken when DelWhere is called: tasks
+// added during the sweep are not considered, and tasks deleted or replaced under the same ID in the meantime are left
+// alone. Removals are reported to StdSchedulerOptions.OnScheduleChange with the reason "bulk".
+//
+//	// Clean up after a tenant
+//	deleted := scheduler.DelWhere(func(info tasks.TaskInfo) bool {
+//		return strings.HasPrefix(info.ID, "tenant-42/")
+//	})
+func (s *StdScheduler) DelWhere(pred func(info TaskInfo) bool) (deleted []string) {
+	type candidate struct {
+		t    *Task
+		info TaskInfo
+	}
+
+	s.RLock()
+	candidates := make([]candidate, 0, len(s.tasks))
+	for id, t := range s.tasks {
+		c := candidate{t: t}
+		t.safeOps(func() {
+			c.info = TaskInfo{
+				ID:       id,
+				Interval: t.Interval,
+				CronExpr: t.CronExpr,
+				RunOnce:  t.RunOnce,
+				Lane:     t.Lane,
+				Group:    t.Group,
+				State:    t.state,
+				NextRun:  t.nextRun(),
+			}
+		})
+		candidates = append(candidates, c)
+	}
+	s.RUnlock()
+
+	for _, c := range candidates {
+		if !pred(c.info) {
+			continue
+		}
+
+		if !s.delTask(c.info.ID, c.t, removalReasonBulk) {
+			continue
+		}
+		s.deadLetters.Lock()
+		s.deadLetters.remove(c.info.ID)
+		s.deadLetters.Unlock()
+
+		deleted = append(deleted, c.info.ID)
+	}
+
+	return deleted
+}
@@ -0,0 +1,142 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestDelWhere(t *testing.T) {
+	t.Run("Verify matching tasks are deleted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu      sync.Mutex
+			reasons = make(map[string]string)
+		)
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			OnScheduleChange: func(id string, next time.Time, reason string) {
+				if next.IsZero() {
+					mu.Lock()
+					reasons[id] = reason
+					mu.Unlock()
+				}
+			},
+		})
+		defer scheduler.Stop()
+
+		add := func(id string, interval time.Duration, group string) {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: interval,
+				Group:    group,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+		}
+		add("tenant-a/report", time.Hour, "tenant-a")
+		add("tenant-a/sync", time.Minute, "tenant-a")
+		add("tenant-b/report", time.Hour, "tenant-b")
+		add("experiment/1", time.Hour, "")
+		add("experiment/2", 2*time.Hour, "")
+
+		deleted := scheduler.DelWhere(func(info TaskInfo) bool { return info.Group == "tenant-a" })
+		sort.Strings(deleted)
+		assert.Equal([]string{"tenant-a/report", "tenant-a/sync"}, deleted)
+
+		deleted = scheduler.DelWhere(func(info TaskInfo) bool {
+			return strings.HasPrefix(info.ID, "experiment/") && info.Interval > time.Hour
+		})
+		assert.Equal([]string{"experiment/2"}, deleted)
+
+		assert.Empty(scheduler.DelWhere(func(TaskInfo) bool { return false }))
+		assert.Len(scheduler.Tasks(), 2)
+		assert.True(scheduler.Has("tenant-b/report"))
+		assert.True(scheduler.Has("experiment/1"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(map[string]string{
+			"tenant-a/report": "bulk",
+			"tenant-a/sync":   "bulk",
+			"experiment/2":    "bulk",
+		}, reasons)
+	})
+
+	t.Run("Verify the predicate may use the scheduler", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		for _, id := range []string{"replaced", "removed", "kept"} {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: time.Hour,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+		}
+
+		// Tasks deleted or replaced once the sweep started are left alone
+		deleted := scheduler.DelWhere(func(info TaskInfo) bool {
+			switch info.ID {
+			case "replaced":
+				scheduler.Del("replaced")
+				assert.NoError(scheduler.AddWithID("replaced", &Task{
+					Interval: time.Minute,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(error) {},
+				}))
+			case "removed":
+				scheduler.Del("removed")
+			}
+			return info.ID != "kept"
+		})
+		assert.Empty(deleted)
+		assert.True(scheduler.Has("replaced"))
+		assert.True(scheduler.Has("kept"))
+		assert.False(scheduler.Has("removed"))
+	})
+
+	t.Run("Verify concurrent adds during the sweep", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		for i := 0; i < 200; i++ {
+			assert.NoError(scheduler.AddWithID(fmt.Sprintf("old-%d", i), &Task{
+				Interval: time.Hour,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				assert.NoError(scheduler.AddWithID(fmt.Sprintf("new-%d", i), &Task{
+					Interval: time.Hour,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(error) {},
+				}))
+			}
+		}()
+
+		deleted := scheduler.DelWhere(func(info TaskInfo) bool { return strings.HasPrefix(info.ID, "old-") })
+		wg.Wait()
+
+		assert.Len(deleted, 200)
+		assert.Len(scheduler.Tasks(), 200)
+		for id := range scheduler.Tasks() {
+			assert.True(strings.HasPrefix(id, "new-"), id)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package tasks
+
+// cancelledChan is returned by CancellationChan for tasks that do not exist.
+var cancelledChan = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+
+	return c
+}()
+
+// CancellationChan will return a channel closed once the specified task is cancelled: deleted, removed after its last
+// execution, or stopped with the scheduler. It lets TaskFunc and FuncWithID functions, which have no context, stop at
+// convenient points when Del interrupts them. For unknown IDs, including tasks already deleted, the returned channel
+// is closed.
+//
+// Task.Timeout and the user context of TaskContext only apply to FuncWithTaskContext, they do not close the channel.
+// Functions that can change their signature should use FuncWithTaskContext instead.
+//
+//	FuncWithID: func(id string) error {
+//		done := scheduler.CancellationChan(id)
+//		for _, item := range items {
+//			select {
+//			case <-done:
+//				return nil
+//			default:
+//			}
+//			process(item)
+//		}
+//		return nil
+//	},
+func (s *StdScheduler) CancellationChan(id string) <-chan struct{} {
+	s.RLock()
+	t, ok := s.tasks[id]
+	s.RUnlock()
+	if !ok {
+		return cancelledChan
+	}
+
+	return t.ctx.Done()
+}
+
+// IsCancelled will return whether the specified task has been cancelled, see CancellationChan. It returns true for
+// unknown IDs, including tasks already deleted. Loops polling it often should get the channel of CancellationChan once
+// instead, which is read without any lock.
+func (s *StdScheduler) IsCancelled(id string) bool {
+	select {
+	case <-s.CancellationChan(id):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestCancellation(t *testing.T) {
+	t.Run("Verify Del interrupts a plain function polling IsCancelled", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var iterations atomic.Int64
+		started := make(chan struct{})
+		returned := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("loop", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				defer close(returned)
+
+				close(started)
+				for !scheduler.IsCancelled("loop") {
+					iterations.Add(1)
+					time.Sleep(time.Millisecond)
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		<-started
+		assert.False(scheduler.IsCancelled("loop"))
+		scheduler.Del("loop")
+
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Fatal("the task function was not interrupted")
+		}
+		assert.Positive(iterations.Load())
+	})
+
+	t.Run("Verify Del closes the cancellation channel", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		started := make(chan struct{})
+		returned := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("chan", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			FuncWithID: func(id string) error {
+				defer close(returned)
+
+				close(started)
+				<-scheduler.CancellationChan(id)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		<-started
+		scheduler.Del("chan")
+
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Fatal("the task function was not interrupted")
+		}
+	})
+
+	t.Run("Verify unknown tasks are cancelled", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.True(scheduler.IsCancelled("unknown"))
+		select {
+		case <-scheduler.CancellationChan("unknown"):
+		default:
+			t.Fatal("the cancellation channel of an unknown task is open")
+		}
+
+		assert.NoError(scheduler.AddWithID("stopped", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		done := scheduler.CancellationChan("stopped")
+		assert.False(scheduler.IsCancelled("stopped"))
+
+		scheduler.Stop()
+		assert.True(scheduler.IsCancelled("stopped"))
+		<-done
+	})
+}
+
+func TestBindCancellationReason(t *testing.T) {
+	assert := assertions.New(t)
+
+	reasons := make(chan string, 10)
+	scheduler := NewStdScheduler(StdSchedulerOptions{
+		OnScheduleChange: func(id string, next time.Time, reason string) {
+			if next.IsZero() {
+				reasons <- reason
+			}
+		},
+	})
+	defer scheduler.Stop()
+
+	assert.NoError(scheduler.AddWithID("bound", &Task{
+		Interval: time.Minute,
+		TaskFunc: func() error { return nil },
+		ErrFunc:  func(error) {},
+	}))
+
+	done := make(chan struct{})
+	assert.NoError(scheduler.BindCancellation("bound", done))
+	close(done)
+
+	select {
+	case reason := <-reasons:
+		assert.Equal(removalReasonExternal, reason)
+	case <-time.After(time.Second):
+		t.Fatal("the bound task was not removed")
+	}
+	assert.False(scheduler.Has("bound"))
+}
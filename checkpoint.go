@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"sync"
+)
+
+// MaxCheckpointSize is the maximum size of a checkpoint set with TaskContext.SetCheckpoint.
+const MaxCheckpointSize = 64 << 10
+
+// runCheckpoint holds the checkpoint set during an execution until it succeeds.
+type runCheckpoint struct {
+	sync.Mutex
+
+	value []byte
+	set   bool
+}
+
+// Checkpoint will return the checkpoint stored by the latest successful execution of the task, or nil if none. It
+// lets tasks computing deltas resume from where the last successful execution stopped.
+func (ctx TaskContext) Checkpoint() []byte {
+	if ctx.checkpoint == nil {
+		return nil
+	}
+
+	return append([]byte(nil), ctx.checkpoint...)
+}
+
+// SetCheckpoint will store v as the checkpoint of the task if the current execution succeeds or returns ErrYielded,
+// for the next executions to read with Checkpoint. Failed executions do not overwrite the checkpoint. The checkpoint
+// is kept in memory and removed with the task. It returns ErrCheckpointTooLarge if v is larger than MaxCheckpointSize.
+//
+// SetCheckpoint is only meaningful within FuncWithTaskContext, checkpoints set outside of an execution are dropped.
+//
+//	FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+//		watermark := decode(taskCtx.Checkpoint())
+//		next, err := syncSince(taskCtx.Context, watermark)
+//		if err != nil {
+//			return err
+//		}
+//		return taskCtx.SetCheckpoint(encode(next))
+//	},
+func (ctx TaskContext) SetCheckpoint(v []byte) error {
+	if len(v) > MaxCheckpointSize {
+		return ErrCheckpointTooLarge
+	}
+
+	if ctx.pendingCheckpoint == nil {
+		return nil
+	}
+
+	ctx.pendingCheckpoint.Lock()
+	defer ctx.pendingCheckpoint.Unlock()
+
+	ctx.pendingCheckpoint.value = append([]byte(nil), v...)
+	ctx.pendingCheckpoint.set = true
+
+	return nil
+}
+
+// commitCheckpoint stores the checkpoint set during a successful execution.
+func (t *Task) commitCheckpoint(pending *runCheckpoint) {
+	if pending == nil {
+		return
+	}
+
+	pending.Lock()
+	value, set := pending.value, pending.set
+	pending.Unlock()
+
+	if !set {
+		return
+	}
+
+	t.safeOps(func() {
+		t.checkpoint = value
+	})
+}
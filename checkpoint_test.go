@@ -0,0 +1,107 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify only successful executions store their checkpoint", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		readCh := make(chan string, 10)
+
+		var runs atomic.Int32
+
+		assert.NoError(scheduler.AddWithID("checkpoint", &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				run := runs.Add(1)
+				readCh <- string(taskCtx.Checkpoint())
+
+				if err := taskCtx.SetCheckpoint([]byte(fmt.Sprintf("run-%d", run))); err != nil {
+					return err
+				}
+
+				// The second execution fails after setting its checkpoint
+				if run == 2 {
+					return errors.New("failed")
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+
+		var reads []string
+		for len(reads) < 4 {
+			select {
+			case read := <-readCh:
+				reads = append(reads, read)
+			case <-time.After(time.Second):
+				t.Fatalf("Task did not execute within 1 second")
+			}
+		}
+		scheduler.Del("checkpoint")
+
+		assert.Equal([]string{"", "run-1", "run-1", "run-3"}, reads)
+	})
+
+	t.Run("Verify the checkpoint survives re-adding a looked up task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		readCh := make(chan string, 10)
+		assert.NoError(scheduler.AddWithID("replaced", &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				readCh <- string(taskCtx.Checkpoint())
+				return taskCtx.SetCheckpoint([]byte("watermark"))
+			},
+			ErrFunc: func(e error) {},
+		}))
+
+		// Wait for a successful execution to complete
+		<-readCh
+		assert.Equal("watermark", <-readCh)
+
+		scheduled, err := scheduler.Lookup("replaced")
+		assert.NoError(err)
+		scheduler.Del("replaced")
+		assert.NoError(scheduler.AddWithID("replaced", scheduled))
+		defer scheduler.Del("replaced")
+
+		for len(readCh) > 0 {
+			<-readCh
+		}
+		assert.Equal("watermark", <-readCh)
+	})
+
+	t.Run("Verify checkpoints are size-capped", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errCh := make(chan error, 1)
+		_, err := scheduler.Add(&Task{
+			RunOnce: true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				errCh <- taskCtx.SetCheckpoint(make([]byte, MaxCheckpointSize+1))
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(err, ErrCheckpointTooLarge)
+		case <-time.After(time.Second):
+			t.Errorf("Task did not execute within 1 second")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// ID collision kinds reported to StdSchedulerOptions.OnIDCollision.
+const (
+	// IDCollisionGenerated is an ID generated by Add that was already in use. It should never happen, repeated
+	// occurrences point at a broken ID generator.
+	IDCollisionGenerated = "generated"
+
+	// IDCollisionDuplicate is an ID passed to AddWithID that was already in use. It is common with callers that add
+	// tasks idempotently.
+	IDCollisionDuplicate = "duplicate"
+)
+
+// newID generates task IDs for Add, it is replaced in tests.
+var newID = func() string { return xid.New().String() }
+
+// IDCollisions counts the task IDs that were rejected because they were already in use.
+type IDCollisions struct {
+	// Generated is the number of IDs generated by Add that were already in use.
+	Generated uint64
+
+	// Duplicates is the number of IDs passed to AddWithID that were already in use.
+	Duplicates uint64
+
+	// Last is the time of the latest collision of either kind, zero if none.
+	Last time.Time
+}
+
+// idCollisionCounter keeps the IDCollisions of a scheduler.
+type idCollisionCounter struct {
+	sync.Mutex
+
+	collisions IDCollisions
+}
+
+// record counts a collision of the given kind.
+func (c *idCollisionCounter) record(kind string) {
+	c.Lock()
+	defer c.Unlock()
+
+	switch kind {
+	case IDCollisionGenerated:
+		c.collisions.Generated++
+	case IDCollisionDuplicate:
+		c.collisions.Duplicates++
+	}
+	c.collisions.Last = time.Now()
+}
+
+// IDCollisions will return the number of task IDs rejected so far because they were already in use, by kind.
+func (s *StdScheduler) IDCollisions() IDCollisions {
+	s.idCollisions.Lock()
+	defer s.idCollisions.Unlock()
+
+	return s.idCollisions.collisions
+}
+
+// recordIDCollision counts a collision and reports it to StdSchedulerOptions.OnIDCollision.
+func (s *StdScheduler) recordIDCollision(id, kind string) {
+	s.idCollisions.record(kind)
+
+	if s.opts.OnIDCollision != nil {
+		s.opts.OnIDCollision(id, kind)
+	}
+}
@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// collisionCollector is a stub metrics collector for StdSchedulerOptions.OnIDCollision.
+type collisionCollector struct {
+	sync.Mutex
+
+	counts map[string]int
+	ids    []string
+}
+
+func (c *collisionCollector) collect(id string, kind string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[kind]++
+	c.ids = append(c.ids, id)
+}
+
+func TestIDCollisions(t *testing.T) {
+	newTask := func() *Task {
+		return &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		}
+	}
+
+	t.Run("Verify duplicate AddWithID calls are counted as duplicates", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		collector := &collisionCollector{}
+		scheduler := NewStdScheduler(StdSchedulerOptions{OnIDCollision: collector.collect})
+		defer scheduler.Stop()
+
+		assert.Zero(scheduler.IDCollisions())
+
+		assert.NoError(scheduler.AddWithID("dup", newTask()))
+		before := time.Now()
+		assert.ErrorIs(scheduler.AddWithID("dup", newTask()), ErrIDInUse)
+		assert.ErrorIs(scheduler.AddWithID("dup", newTask()), ErrIDInUse)
+
+		collisions := scheduler.IDCollisions()
+		assert.Equal(uint64(2), collisions.Duplicates)
+		assert.Zero(collisions.Generated)
+		assert.False(collisions.Last.Before(before))
+
+		assert.Equal(map[string]int{IDCollisionDuplicate: 2}, collector.counts)
+		assert.Equal([]string{"dup", "dup"}, collector.ids)
+	})
+
+	t.Run("Verify generator collisions in Add are counted as generated", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		ids := []string{"taken", "taken", "free"}
+		defer func(orig func() string) { newID = orig }(newID)
+		newID = func() string {
+			id := ids[0]
+			ids = ids[1:]
+			return id
+		}
+
+		collector := &collisionCollector{}
+		scheduler := NewStdScheduler(StdSchedulerOptions{OnIDCollision: collector.collect})
+		defer scheduler.Stop()
+
+		id, err := scheduler.Add(newTask())
+		assert.NoError(err)
+		assert.Equal("taken", id)
+
+		id, err = scheduler.Add(newTask())
+		assert.NoError(err)
+		assert.Equal("free", id)
+
+		collisions := scheduler.IDCollisions()
+		assert.Equal(uint64(1), collisions.Generated)
+		assert.Zero(collisions.Duplicates)
+		assert.False(collisions.Last.IsZero())
+
+		assert.Equal(map[string]int{IDCollisionGenerated: 1}, collector.counts)
+	})
+}
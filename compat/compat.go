@@ -0,0 +1,153 @@
+/*
+Package compat provides the scheduler API of the upstream tasks package this module was forked from, implemented on top
+of tasks.StdScheduler, so code written against it can switch import paths before moving to the current API.
+
+	// Start the Scheduler
+	scheduler := compat.New()
+	defer scheduler.Stop()
+
+	// Add a task
+	id, err := scheduler.Add(&compat.Task{
+		Interval: 30 * time.Second,
+		TaskFunc: func() error {
+			// Put your logic here
+		},
+	})
+	if err != nil {
+		// Do Stuff
+	}
+
+Deprecated: use tasks.NewStdScheduler with tasks.StdSchedulerOptions. Scheduler.Std gives access to the current API
+while migrating.
+*/
+package compat
+
+import (
+	"github.com/shaelmaar/tasks"
+)
+
+// Task is the task type of the upstream package, the fields it had keep their meaning.
+//
+// Deprecated: use tasks.Task.
+type Task = tasks.Task
+
+// TaskContext is the task context type of the upstream package.
+//
+// Deprecated: use tasks.TaskContext.
+type TaskContext = tasks.TaskContext
+
+var (
+	// ErrIDInUse is returned when a Task ID is specified but already used.
+	//
+	// Deprecated: use tasks.ErrIDInUse.
+	ErrIDInUse = tasks.ErrIDInUse
+	// ErrTaskNotFound is returned by Lookup when the task is not in the task list.
+	//
+	// Deprecated: use tasks.ErrTaskNotFound.
+	ErrTaskNotFound = tasks.ErrTaskNotFound
+)
+
+// Scheduler is the scheduler of the upstream package. It behaves like it, with the following intentional differences
+// inherited from tasks.StdScheduler:
+//
+//   - Retries: Task.RetriesOnError and Task.RetryOnErrorInterval are honoured, recurring tasks retry each failed
+//     tick. They are zero by default, so tasks written for the upstream package are not retried.
+//   - Logging: the scheduler logs warnings, such as a RunOnce task with both StartAfter and Interval set, and tasks
+//     that never executed when it stops, through the default logger of the logger package which writes to stdout.
+//     Use logger.SetDefault to redirect or silence it. Past 5 identical failures in a row, failures of a task are
+//     logged in a periodic summary, see tasks.StdSchedulerOptions.ErrorDampingThreshold. The error functions are
+//     still called for every failure.
+//   - Lookup and Tasks return read-only copies: changing their fields does not affect the scheduled task, as upstream,
+//     but their setters return tasks.ErrReadOnlyTask.
+//   - Stop is final: adding tasks afterwards returns tasks.ErrSchedulerStopped, where upstream scheduled them.
+//   - Panics: a panic in a task function is recovered and delivered to the error functions as a tasks.PanicError,
+//     where upstream it crashed the process.
+//   - Contexts: every execution gets a context derived from TaskContext.Context and cancelled once it returns, where
+//     upstream every execution got TaskContext.Context itself.
+//
+// Deprecated: use tasks.StdScheduler.
+type Scheduler struct {
+	s *tasks.StdScheduler
+}
+
+// New will create a new scheduler with the default options.
+//
+// Deprecated: use tasks.NewStdScheduler.
+func New() *Scheduler {
+	return &Scheduler{s: tasks.NewStdScheduler(tasks.StdSchedulerOptions{})}
+}
+
+// NewStdScheduler will create a new scheduler with the default options, it is the same as New.
+//
+// Deprecated: use tasks.NewStdScheduler.
+func NewStdScheduler() *Scheduler {
+	return New()
+}
+
+// Std will return the underlying scheduler, to use the current API on tasks added through the shim.
+func (s *Scheduler) Std() *tasks.StdScheduler {
+	return s.s
+}
+
+// Add will add a task to the task list and schedule it, returning the generated task ID.
+//
+// Unlike tasks.StdScheduler.Add, the error functions are optional as they were upstream: errors returned by a task
+// without one are dropped.
+//
+// Deprecated: use tasks.StdScheduler.Add.
+func (s *Scheduler) Add(t *Task) (string, error) {
+	return s.s.Add(withErrFunc(t))
+}
+
+// AddWithID will add a task with the given ID to the task list and schedule it. It returns ErrIDInUse when the ID is
+// already used. The error functions are optional, see Add.
+//
+// Deprecated: use tasks.StdScheduler.AddWithID.
+func (s *Scheduler) AddWithID(id string, t *Task) error {
+	return s.s.AddWithID(id, withErrFunc(t))
+}
+
+// Del will unschedule the specified task and remove it from the task list.
+//
+// Deprecated: use tasks.StdScheduler.Del.
+func (s *Scheduler) Del(name string) {
+	s.s.Del(name)
+}
+
+// Lookup will find the specified task from the internal task list using the task ID provided. It returns
+// ErrTaskNotFound when the task is not in the task list.
+//
+// Deprecated: use tasks.StdScheduler.Lookup.
+func (s *Scheduler) Lookup(name string) (*Task, error) {
+	return s.s.Lookup(name)
+}
+
+// Tasks is used to return a copy of the internal tasks map.
+//
+// Deprecated: use tasks.StdScheduler.Tasks.
+func (s *Scheduler) Tasks() map[string]*Task {
+	return s.s.Tasks()
+}
+
+// Stop is used to unschedule and delete all tasks owned by the scheduler instance.
+//
+// Deprecated: use tasks.StdScheduler.Stop.
+func (s *Scheduler) Stop() {
+	s.s.Stop()
+}
+
+// withErrFunc returns t, or a copy of it dropping errors when it has no error function. The copy leaves the task of
+// the caller untouched.
+func withErrFunc(t *Task) *Task {
+	if t == nil {
+		return t
+	}
+
+	c := t.CloneForReuse()
+	if c.ErrFunc != nil || c.ErrFuncWithID != nil || c.ErrFuncWithTaskContext != nil {
+		return t
+	}
+	c.ErrFunc = func(error) {}
+
+	return c
+}
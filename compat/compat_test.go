@@ -0,0 +1,241 @@
+package compat
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks"
+)
+
+// scheduler is the upstream API, implemented by both the shim and tasks.StdScheduler.
+type scheduler interface {
+	Add(t *Task) (string, error)
+	AddWithID(id string, t *Task) error
+	Del(name string)
+	Lookup(name string) (*Task, error)
+	Tasks() map[string]*Task
+	Stop()
+}
+
+// schedulers returns the implementations compared by the tests, the shim must behave like the std scheduler.
+func schedulers() map[string]func() scheduler {
+	return map[string]func() scheduler{
+		"compat": func() scheduler { return New() },
+		"std":    func() scheduler { return tasks.NewStdScheduler(tasks.StdSchedulerOptions{}) },
+	}
+}
+
+// counterTask returns a task counting its executions.
+func counterTask(interval time.Duration, runs *int64) *Task {
+	return &Task{
+		Interval: interval,
+		TaskFunc: func() error {
+			atomic.AddInt64(runs, 1)
+			return nil
+		},
+		ErrFunc: func(error) {},
+	}
+}
+
+func TestEquivalence(t *testing.T) {
+	for name, newScheduler := range schedulers() {
+		newScheduler := newScheduler
+
+		t.Run(name, func(t *testing.T) {
+			t.Run("Verify Add schedules the task", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				id, err := s.Add(counterTask(10*time.Millisecond, &runs))
+				assert.NoError(err)
+				assert.NotEmpty(id)
+
+				assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 2 }, time.Second, 5*time.Millisecond)
+			})
+
+			t.Run("Verify AddWithID rejects an ID in use", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				assert.NoError(s.AddWithID("task", counterTask(time.Minute, &runs)))
+				assert.ErrorIs(s.AddWithID("task", counterTask(time.Minute, &runs)), ErrIDInUse)
+			})
+
+			t.Run("Verify Del unschedules the task", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				id, err := s.Add(counterTask(20*time.Millisecond, &runs))
+				assert.NoError(err)
+
+				s.Del(id)
+				_, err = s.Lookup(id)
+				assert.ErrorIs(err, ErrTaskNotFound)
+
+				time.Sleep(50 * time.Millisecond)
+				assert.Zero(atomic.LoadInt64(&runs))
+			})
+
+			t.Run("Verify Lookup returns a copy", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				id, err := s.Add(counterTask(time.Minute, &runs))
+				assert.NoError(err)
+
+				task, err := s.Lookup(id)
+				assert.NoError(err)
+				assert.Equal(time.Minute, task.Interval)
+
+				task.Interval = time.Hour
+				task, err = s.Lookup(id)
+				assert.NoError(err)
+				assert.Equal(time.Minute, task.Interval)
+			})
+
+			t.Run("Verify Tasks lists every task", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				assert.NoError(s.AddWithID("a", counterTask(time.Minute, &runs)))
+				assert.NoError(s.AddWithID("b", counterTask(time.Minute, &runs)))
+
+				list := s.Tasks()
+				assert.Len(list, 2)
+				assert.Contains(list, "a")
+				assert.Contains(list, "b")
+			})
+
+			t.Run("Verify Stop removes every task", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+
+				var runs int64
+				assert.NoError(s.AddWithID("a", counterTask(20*time.Millisecond, &runs)))
+				s.Stop()
+
+				assert.Empty(s.Tasks())
+				time.Sleep(50 * time.Millisecond)
+				assert.Zero(atomic.LoadInt64(&runs))
+			})
+
+			t.Run("Verify RunOnce tasks run once", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				task := counterTask(10*time.Millisecond, &runs)
+				task.RunOnce = true
+				id, err := s.Add(task)
+				assert.NoError(err)
+
+				assert.Eventually(func() bool {
+					_, err := s.Lookup(id)
+					return errors.Is(err, ErrTaskNotFound)
+				}, time.Second, 5*time.Millisecond)
+				time.Sleep(50 * time.Millisecond)
+				assert.Equal(int64(1), atomic.LoadInt64(&runs))
+			})
+
+			t.Run("Verify tasks wait for StartAfter", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				var runs int64
+				task := counterTask(10*time.Millisecond, &runs)
+				task.StartAfter = time.Now().Add(100 * time.Millisecond)
+				_, err := s.Add(task)
+				assert.NoError(err)
+
+				time.Sleep(50 * time.Millisecond)
+				assert.Zero(atomic.LoadInt64(&runs))
+				assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 1 }, time.Second, 5*time.Millisecond)
+			})
+
+			t.Run("Verify RunOnce tasks run at StartAfter", func(t *testing.T) {
+				assert := assertions.New(t)
+
+				s := newScheduler()
+				defer s.Stop()
+
+				ran := make(chan time.Time, 1)
+				start := time.Now().Add(50 * time.Millisecond)
+				_, err := s.Add(&Task{
+					RunOnce:    true,
+					StartAfter: start,
+					TaskFunc: func() error {
+						ran <- time.Now()
+						return nil
+					},
+					ErrFunc: func(error) {},
+				})
+				assert.NoError(err)
+
+				select {
+				case at := <-ran:
+					assert.False(at.Before(start))
+				case <-time.After(time.Second):
+					t.Fatalf("task did not run within 1 second")
+				}
+			})
+		})
+	}
+}
+
+func TestOptionalErrFunc(t *testing.T) {
+	t.Run("Verify tasks without error functions are added", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		s := New()
+		defer s.Stop()
+
+		var runs int64
+		task := &Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				atomic.AddInt64(&runs, 1)
+				return errors.New("dropped")
+			},
+		}
+		_, err := s.Add(task)
+		assert.NoError(err)
+		assert.NoError(s.AddWithID("task", task))
+		assert.Nil(task.ErrFunc)
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 4 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Verify the std scheduler still requires them", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		s := New()
+		defer s.Stop()
+
+		_, err := s.Std().Add(&Task{Interval: time.Minute, TaskFunc: func() error { return nil }})
+		assert.ErrorIs(err, tasks.ErrTaskErrFunctionsNotSet)
+	})
+}
@@ -0,0 +1,90 @@
+package tasks
+
+import "sync"
+
+// completionRounds is the number of batches a completing execution removes before handing the remaining ones over to
+// a goroutine of their own, so that it releases its worker.
+const completionRounds = 4
+
+// completions batches the removals of tasks completing on their own, such as RunOnce tasks once executed. The first
+// completion removes the batch, completions arriving meanwhile are queued for it instead of contending for the
+// scheduler lock.
+type completions struct {
+	sync.Mutex
+
+	pending  []*Task
+	flushing bool
+}
+
+// push queues the task and reports whether the caller is to remove the batch.
+func (c *completions) push(t *Task) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pending = append(c.pending, t)
+	if c.flushing {
+		return false
+	}
+	c.flushing = true
+
+	return true
+}
+
+// next returns the queued tasks, or false once none is left.
+func (c *completions) next() ([]*Task, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.pending) == 0 {
+		c.flushing = false
+
+		return nil, false
+	}
+	batch := c.pending
+	c.pending = nil
+
+	return batch, true
+}
+
+// complete removes a task that completed on its own from the execution goroutine. Unlike Del, it reuses the task
+// reference the execution holds, and removes the tasks completing at the same time with a single scheduler lock.
+// Tasks deleted or replaced under the same ID meanwhile are left alone, removals are reported with the reason
+// "deleted" like with Del.
+func (s *StdScheduler) complete(t *Task) {
+	if s.completions.push(t) {
+		s.flushCompletions()
+	}
+}
+
+// flushCompletions removes the queued completions until none is left.
+func (s *StdScheduler) flushCompletions() {
+	for round := 0; ; round++ {
+		if round == completionRounds {
+			go s.flushCompletions()
+
+			return
+		}
+
+		batch, ok := s.completions.next()
+		if !ok {
+			return
+		}
+
+		removed := batch[:0]
+		s.Lock()
+		for _, t := range batch {
+			if s.tasks[t.id] == t {
+				delete(s.tasks, t.id)
+				removed = append(removed, t)
+			}
+		}
+		if len(removed) > 0 {
+			s.freeCapacity()
+		}
+		s.Unlock()
+
+		for _, t := range removed {
+			s.teardown(t.id, t, removalReasonDeleted)
+		}
+	}
+}
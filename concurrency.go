@@ -0,0 +1,162 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// ConcurrencyPolicy is what happens to a fire of a task with Task.MaxConcurrent executions already in flight.
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencySkip skips the fire, the task waits for its next interval. It is the default.
+	ConcurrencySkip ConcurrencyPolicy = iota
+	// ConcurrencyQueue runs the fire as soon as an execution returns, or skips it if none does within
+	// Task.MaxQueueDelay.
+	ConcurrencyQueue
+)
+
+// Skip reasons of the fires over Task.MaxConcurrent.
+const (
+	skipReasonConcurrency = "concurrency limit"
+	skipReasonQueueDelay  = "queue delay"
+	skipReasonRunning     = "previous execution running"
+)
+
+// concurrencySlots counts the executions of a task against Task.MaxConcurrent, it is guarded by the task lock.
+type concurrencySlots struct {
+	// used is the number of slots held by executions, including the ones waiting for a worker.
+	used int
+
+	// waiters are the fires queued with ConcurrencyQueue, oldest first. A freed slot is handed to the first one by
+	// closing its channel.
+	waiters []chan struct{}
+}
+
+// acquireSlot reserves one of the Task.MaxConcurrent slots for a fire, the only one with Task.SkipIfRunning, waiting
+// for one with ConcurrencyQueue. It returns whether a slot is held, to be released with releaseSlot, and false as
+// second value when the fire must not run: it has then been skipped, or the task deleted or the scheduler stopped
+// meanwhile.
+func (s *StdScheduler) acquireSlot(t *Task) (bool, bool) {
+	var (
+		limited, skipped bool
+		limit            int
+		waiter           chan struct{}
+		maxDelay         time.Duration
+	)
+	t.safeOps(func() {
+		limit = t.MaxConcurrent
+		if t.SkipIfRunning {
+			limit = 1
+		}
+		if limit <= 0 {
+			return
+		}
+		limited = true
+
+		if t.slots.used < limit {
+			t.slots.used++
+			return
+		}
+
+		if t.SkipIfRunning {
+			skipped = true
+			t.skippedRunning++
+			return
+		}
+
+		if t.ConcurrencyPolicy != ConcurrencyQueue {
+			skipped = true
+			return
+		}
+
+		waiter = make(chan struct{})
+		t.slots.waiters = append(t.slots.waiters, waiter)
+		maxDelay = t.MaxQueueDelay
+	})
+
+	switch {
+	case !limited:
+		return false, true
+	case skipped && t.SkipIfRunning:
+		logger.Debugf("task (id: %s) fire is skipped, the previous execution is still running", t.id)
+		s.skipTask(t, skipReasonRunning)
+
+		return false, false
+	case skipped:
+		logger.Debugf("task (id: %s) fire is skipped, %d executions are in flight", t.id, limit)
+		s.skipTask(t, skipReasonConcurrency)
+
+		return false, false
+	case waiter == nil:
+		return true, true
+	}
+
+	var timeout <-chan time.Time
+	if maxDelay > 0 {
+		timer := time.NewTimer(maxDelay)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-waiter:
+		return true, true
+	case <-timeout:
+		if t.leaveQueue(waiter) {
+			logger.Debugf("task (id: %s) fire is skipped, no execution returned within %s", t.id, maxDelay)
+			s.skipTask(t, skipReasonQueueDelay)
+
+			return false, false
+		}
+	case <-t.ctx.Done():
+		if t.leaveQueue(waiter) {
+			return false, false
+		}
+	case <-s.draining:
+		if t.leaveQueue(waiter) {
+			return false, false
+		}
+	}
+
+	// The slot was handed over while giving up, the fire runs unless the task is gone
+	if t.ctx.Err() != nil {
+		t.releaseSlot()
+
+		return false, false
+	}
+
+	return true, true
+}
+
+// leaveQueue removes a fire from the queue of the task. It returns false if a slot has been handed to it meanwhile.
+func (t *Task) leaveQueue(waiter chan struct{}) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	for i, w := range t.slots.waiters {
+		if w == waiter {
+			t.slots.waiters = append(t.slots.waiters[:i], t.slots.waiters[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// releaseSlot releases a slot reserved by acquireSlot, handing it to the oldest queued fire if any.
+func (t *Task) releaseSlot() {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.slots.waiters) > 0 {
+		close(t.slots.waiters[0])
+		t.slots.waiters = t.slots.waiters[1:]
+
+		return
+	}
+
+	t.slots.used--
+}
@@ -0,0 +1,226 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// concurrencyProbe measures how many executions run at once.
+type concurrencyProbe struct {
+	current int64
+	peak    int64
+	runs    int64
+}
+
+func (p *concurrencyProbe) run(d time.Duration) {
+	n := atomic.AddInt64(&p.current, 1)
+	for {
+		peak := atomic.LoadInt64(&p.peak)
+		if n <= peak || atomic.CompareAndSwapInt64(&p.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(d)
+	atomic.AddInt64(&p.current, -1)
+	atomic.AddInt64(&p.runs, 1)
+}
+
+func TestMaxConcurrent(t *testing.T) {
+	t.Run("Verify fires over the limit are skipped", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var probe concurrencyProbe
+		assert.NoError(scheduler.AddWithID("api", &Task{
+			Interval:      2 * time.Millisecond,
+			MaxConcurrent: 2,
+			Debug:         true,
+			TaskFunc: func() error {
+				probe.run(40 * time.Millisecond)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.runs) >= 6 }, 2*time.Second, time.Millisecond)
+		assert.Equal(int64(2), atomic.LoadInt64(&probe.peak))
+
+		status, err := scheduler.TaskStatus("api")
+		assert.NoError(err)
+		assert.Equal(2, status.PeakConcurrency)
+		assert.LessOrEqual(status.InFlight, 2)
+
+		var skipped int
+		for _, r := range scheduler.DebugTrace("api") {
+			if r.Decision == DecisionSkipped && r.Reason == skipReasonConcurrency {
+				skipped++
+			}
+		}
+		assert.Greater(skipped, 0)
+	})
+
+	t.Run("Verify queued fires run as soon as an execution returns", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			mu     sync.Mutex
+			starts []time.Time
+			ends   []time.Time
+			probe  concurrencyProbe
+		)
+		assert.NoError(scheduler.AddWithID("api", &Task{
+			Interval:          2 * time.Millisecond,
+			MaxConcurrent:     1,
+			ConcurrencyPolicy: ConcurrencyQueue,
+			TaskFunc: func() error {
+				mu.Lock()
+				starts = append(starts, time.Now())
+				mu.Unlock()
+
+				probe.run(20 * time.Millisecond)
+
+				mu.Lock()
+				ends = append(ends, time.Now())
+				mu.Unlock()
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.runs) >= 5 }, 2*time.Second, time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&probe.peak))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Every execution starts right after the previous one returned
+		for i := 1; i < 5; i++ {
+			assert.False(starts[i].Before(ends[i-1]))
+			assert.Less(starts[i].Sub(ends[i-1]), 10*time.Millisecond)
+		}
+	})
+
+	t.Run("Verify queued fires are skipped after MaxQueueDelay", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var probe concurrencyProbe
+		assert.NoError(scheduler.AddWithID("api", &Task{
+			Interval:          2 * time.Millisecond,
+			MaxConcurrent:     1,
+			ConcurrencyPolicy: ConcurrencyQueue,
+			MaxQueueDelay:     5 * time.Millisecond,
+			Debug:             true,
+			TaskFunc: func() error {
+				probe.run(50 * time.Millisecond)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool {
+			for _, r := range scheduler.DebugTrace("api") {
+				if r.Decision == DecisionSkipped && r.Reason == skipReasonQueueDelay {
+					return true
+				}
+			}
+			return false
+		}, 2*time.Second, time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&probe.peak))
+	})
+
+	t.Run("Verify a queued fire gives up when the task is deleted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		release := make(chan struct{})
+		var runs int64
+		assert.NoError(scheduler.AddWithID("api", &Task{
+			Interval:          2 * time.Millisecond,
+			MaxConcurrent:     1,
+			ConcurrencyPolicy: ConcurrencyQueue,
+			TaskFunc: func() error {
+				atomic.AddInt64(&runs, 1)
+				<-release
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		scheduler.Del("api")
+		close(release)
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&runs))
+	})
+}
+
+func TestSkipIfRunning(t *testing.T) {
+	t.Run("Verify fires are skipped while an execution runs", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var probe concurrencyProbe
+		assert.NoError(scheduler.AddWithID("slow", &Task{
+			Interval:      5 * time.Millisecond,
+			SkipIfRunning: true,
+			TaskFunc: func() error {
+				probe.run(30 * time.Millisecond)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.runs) >= 3 }, 2*time.Second, time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&probe.peak))
+
+		status, err := scheduler.TaskStatus("slow")
+		assert.NoError(err)
+		assert.Greater(status.SkippedWhileRunning, uint64(0))
+		assert.Equal(1, status.PeakConcurrency)
+	})
+
+	t.Run("Verify executions resume after a panic or an error", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs int64
+		assert.NoError(scheduler.AddWithID("failing", &Task{
+			Interval:      5 * time.Millisecond,
+			SkipIfRunning: true,
+			TaskFunc: func() error {
+				time.Sleep(10 * time.Millisecond)
+				switch atomic.AddInt64(&runs, 1) {
+				case 1:
+					panic("boom")
+				case 2:
+					return errors.New("failed")
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 4 }, 2*time.Second, time.Millisecond)
+	})
+}
@@ -0,0 +1,98 @@
+/*
+Package contrib provides ready-made tasks for common scheduled chores. Every constructor returns a plain *tasks.Task,
+fully wired with error logging through the scheduler logger, which can be further configured before being added to a
+scheduler.
+
+	// Send a heartbeat every 10 seconds
+	id, err := scheduler.Add(contrib.NewHeartbeatTask(10*time.Second, func(ctx context.Context) error {
+		return client.Beat(ctx)
+	}))
+	if err != nil {
+		// Do stuff
+	}
+*/
+package contrib
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/shaelmaar/tasks"
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// DefaultGCPressureInterval is how often the task returned by NewGCPressureTask samples the memory statistics.
+const DefaultGCPressureInterval = 10 * time.Second
+
+// DefaultHeartbeatRetries is how many times the task returned by NewHeartbeatTask retries a failed beat before
+// waiting for the next interval.
+const DefaultHeartbeatRetries = 3
+
+// readMemStats samples the memory statistics, it is replaced in tests.
+var readMemStats = runtime.ReadMemStats
+
+// NewHeartbeatTask returns a recurring task calling beat every interval with the task context. A failed beat is retried
+// DefaultHeartbeatRetries times, with an exponential backoff from a tenth of the interval up to half of it, so that
+// the retries are over before the next beat is due.
+func NewHeartbeatTask(interval time.Duration, beat func(ctx context.Context) error) *tasks.Task {
+	return &tasks.Task{
+		Interval:       interval,
+		RetriesOnError: DefaultHeartbeatRetries,
+		RetryBackoff: tasks.ExponentialBackoff{
+			Base:   interval / 10,
+			Max:    interval / 2,
+			Jitter: 0.2,
+		},
+		FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+			return beat(taskCtx.Context)
+		},
+		ErrFuncWithTaskContext: logError("heartbeat"),
+	}
+}
+
+// NewGCPressureTask returns a recurring task sampling the memory statistics every DefaultGCPressureInterval, and
+// calling action when the allocated heap reaches threshold bytes.
+//
+//	// Free memory once the heap reaches 1 GiB
+//	task := contrib.NewGCPressureTask(1<<30, func(ctx context.Context, stats *runtime.MemStats) error {
+//		debug.FreeOSMemory()
+//		return nil
+//	})
+func NewGCPressureTask(threshold uint64, action func(ctx context.Context, stats *runtime.MemStats) error) *tasks.Task {
+	return &tasks.Task{
+		Interval: DefaultGCPressureInterval,
+		FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+			var stats runtime.MemStats
+			readMemStats(&stats)
+
+			if stats.HeapAlloc < threshold {
+				return nil
+			}
+
+			logger.Debugf("task (id: %s) heap allocation %d reached the threshold %d", taskCtx.ID(), stats.HeapAlloc,
+				threshold)
+
+			return action(taskCtx.Context, &stats)
+		},
+		ErrFuncWithTaskContext: logError("gc pressure"),
+	}
+}
+
+// NewMetricsFlushTask returns a recurring task calling flush every interval with the task context.
+func NewMetricsFlushTask(flush func(ctx context.Context) error, interval time.Duration) *tasks.Task {
+	return &tasks.Task{
+		Interval: interval,
+		FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+			return flush(taskCtx.Context)
+		},
+		ErrFuncWithTaskContext: logError("metrics flush"),
+	}
+}
+
+// logError returns an error function logging failures of the named chore through the scheduler logger.
+func logError(chore string) func(tasks.TaskContext, error) {
+	return func(taskCtx tasks.TaskContext, err error) {
+		logger.Errorf("%s task (id: %s) failed: %s", chore, taskCtx.ID(), err.Error())
+	}
+}
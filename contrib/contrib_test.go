@@ -0,0 +1,187 @@
+package contrib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks"
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// syncBuffer is a log buffer safe to read while the scheduler writes to it.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.b.String()
+}
+
+func TestHeartbeatTask(t *testing.T) {
+	t.Run("Verify beats are sent with the task context", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := tasks.NewStdScheduler(tasks.StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		beatCh := make(chan context.Context, 10)
+
+		_, err := scheduler.Add(NewHeartbeatTask(10*time.Millisecond, func(ctx context.Context) error {
+			select {
+			case beatCh <- ctx:
+			default:
+			}
+			return nil
+		}))
+		assert.NoError(err)
+
+		for i := 0; i < 2; i++ {
+			select {
+			case ctx := <-beatCh:
+				assert.NotNil(ctx)
+			case <-time.After(time.Second):
+				t.Fatalf("heartbeat task did not beat within 1 second")
+			}
+		}
+	})
+
+	t.Run("Verify failed beats are retried before the next interval", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := tasks.NewStdScheduler(tasks.StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		task := NewHeartbeatTask(200*time.Millisecond, func(ctx context.Context) error { return nil })
+		assert.Equal(DefaultHeartbeatRetries, task.RetriesOnError)
+		assert.Equal(tasks.ExponentialBackoff{Base: 20 * time.Millisecond, Max: 100 * time.Millisecond, Jitter: 0.2},
+			task.RetryBackoff)
+
+		var (
+			mu    sync.Mutex
+			beats []time.Time
+		)
+		beatCh := make(chan struct{}, 10)
+		task.FuncWithTaskContext = func(taskCtx tasks.TaskContext) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			beats = append(beats, time.Now())
+			beatCh <- struct{}{}
+			if len(beats) == 1 {
+				return errors.New("connection refused")
+			}
+			return nil
+		}
+		_, err := scheduler.Add(task)
+		assert.NoError(err)
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-beatCh:
+			case <-time.After(time.Second):
+				t.Fatalf("heartbeat task did not beat within 1 second")
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Less(beats[1].Sub(beats[0]), 100*time.Millisecond)
+	})
+
+	t.Run("Verify failures are logged", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		b := &syncBuffer{}
+		defer logger.SetDefault(logger.Default())
+		logger.SetDefault(logger.NewSimpleLogger(log.New(b, "", 0), logger.LevelInfo))
+
+		task := NewHeartbeatTask(time.Second, func(ctx context.Context) error { return nil })
+		task.ErrFuncWithTaskContext(tasks.TaskContext{}, errors.New("connection refused"))
+
+		assert.Contains(b.String(), "heartbeat task (id: ) failed: connection refused")
+	})
+}
+
+func TestGCPressureTask(t *testing.T) {
+	t.Cleanup(func() { readMemStats = runtime.ReadMemStats })
+
+	var heapAlloc uint64
+	readMemStats = func(m *runtime.MemStats) {
+		m.HeapAlloc = heapAlloc
+	}
+
+	t.Run("Verify action is called over the threshold only", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var calls []uint64
+		task := NewGCPressureTask(100, func(ctx context.Context, stats *runtime.MemStats) error {
+			calls = append(calls, stats.HeapAlloc)
+			return nil
+		})
+		assert.Equal(DefaultGCPressureInterval, task.Interval)
+
+		for _, heap := range []uint64{50, 100, 99, 150} {
+			heapAlloc = heap
+			assert.NoError(task.FuncWithTaskContext(tasks.TaskContext{Context: context.Background()}))
+		}
+
+		assert.Equal([]uint64{100, 150}, calls)
+	})
+
+	t.Run("Verify action errors are returned", func(t *testing.T) {
+		someErr := errors.New("some error")
+		task := NewGCPressureTask(1, func(ctx context.Context, stats *runtime.MemStats) error {
+			return someErr
+		})
+
+		heapAlloc = 2
+		assertions.ErrorIs(t, task.FuncWithTaskContext(tasks.TaskContext{Context: context.Background()}), someErr)
+	})
+}
+
+func TestMetricsFlushTask(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := tasks.NewStdScheduler(tasks.StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	someErr := errors.New("some error")
+	flushCh := make(chan struct{}, 10)
+
+	task := NewMetricsFlushTask(func(ctx context.Context) error {
+		select {
+		case flushCh <- struct{}{}:
+		default:
+		}
+		return someErr
+	}, 10*time.Millisecond)
+	assert.Equal(10*time.Millisecond, task.Interval)
+
+	_, err := scheduler.Add(task)
+	assert.NoError(err)
+
+	select {
+	case <-flushCh:
+	case <-time.After(time.Second):
+		t.Fatalf("metrics flush task did not flush within 1 second")
+	}
+}
@@ -0,0 +1,219 @@
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next fire of a cron schedule, expressions that never match, such as the
+// 30th of February, are rejected when the task is added.
+const cronSearchYears = 5
+
+// cronMacros are the shorthands accepted in place of a cron expression.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the values accepted by a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Sunday is both 0 and 7
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronSchedule is a parsed cron expression, each field is a bit set of the matching values.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny are set when the day fields are unrestricted. When both are restricted, a day matches if
+	// either of them does.
+	domAny, dowAny bool
+
+	loc *time.Location
+}
+
+// parseCron parses a standard 5-field cron expression, minute hour day-of-month month day-of-week, or a 6-field one
+// starting with the second. Fields accept *, values, ranges, lists and steps, months and days of week accept their
+// three-letter English names. Macros such as @daily are accepted too. Times are matched in loc, time.Local if nil.
+func parseCron(expr string, loc *time.Location) (*cronSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w %q: expected 5 or 6 fields, got %d", ErrInvalidCronExpr, expr, len(fields))
+	}
+
+	c := &cronSchedule{loc: loc}
+	for i, f := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{cronSecond, &c.second},
+		{cronMinute, &c.minute},
+		{cronHour, &c.hour},
+		{cronDom, &c.dom},
+		{cronMonth, &c.month},
+		{cronDow, &c.dow},
+	} {
+		bits, err := f.field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidCronExpr, expr, err.Error())
+		}
+		*f.bits = bits
+	}
+
+	c.domAny = fields[3] == "*"
+	c.dowAny = fields[5] == "*"
+
+	// Sunday is matched as 0 by time.Weekday
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+// parse parses a field of a cron expression into the bit set of the values it matches.
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, step, hasStep := strings.Cut(item, "/")
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			var err error
+			first, last, isRange := strings.Cut(rng, "-")
+			if lo, err = f.value(first); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(last); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// A single value with a step runs up to the end of the field
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s range %q is reversed", f.name, rng)
+			}
+		}
+
+		every := 1
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s step %q is not a positive number", f.name, step)
+			}
+			every = n
+		}
+
+		for v := lo; v <= hi; v += every {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// value parses a single value of the field, a number or a name.
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is not a number", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d is out of range %d-%d", f.name, v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+// next returns the first time strictly after after matching the schedule, or the zero time if none is found within
+// cronSearchYears.
+func (c *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(c.loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+
+		switch {
+		case c.month&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, c.loc)
+		case !c.matchesDay(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, c.loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, c.loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case c.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of t matches the day of month and day of week fields.
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// delay returns the time from now until the next fire of the schedule.
+func (c *cronSchedule) delay(now time.Time) time.Duration {
+	next := c.next(now)
+	if next.IsZero() {
+		return 0
+	}
+
+	return next.Sub(now)
+}
@@ -0,0 +1,145 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestCronNext(t *testing.T) {
+	// 2024-01-10 is a Wednesday
+	from := time.Date(2024, 1, 10, 10, 30, 15, 0, time.UTC)
+
+	tc := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2024, 1, 10, 10, 31, 0, 0, time.UTC)},
+		{"every second", "* * * * * *", time.Date(2024, 1, 10, 10, 30, 16, 0, time.UTC)},
+		{"daily at 2am", "0 2 * * *", time.Date(2024, 1, 11, 2, 0, 0, 0, time.UTC)},
+		{"mondays at 9am", "0 9 * * MON", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 9 * * 7", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{"every 15 minutes", "*/15 * * * *", time.Date(2024, 1, 10, 10, 45, 0, 0, time.UTC)},
+		{"range with step", "0 8-18/4 * * *", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)},
+		{"list", "0 0 1,15 * *", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"month name", "0 0 1 mar *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 20 * FRI", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"macro", "@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"seconds field", "30 0 11 * * *", time.Date(2024, 1, 10, 11, 0, 30, 0, time.UTC)},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assertions.New(t)
+
+			s, err := parseCron(c.expr, time.UTC)
+			if assert.NoError(err) {
+				assert.Equal(c.want, s.next(from))
+			}
+		})
+	}
+
+	t.Run("Verify the location of the schedule is used", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		loc := time.FixedZone("UTC+3", 3*60*60)
+		s, err := parseCron("0 2 * * *", loc)
+		if assert.NoError(err) {
+			assert.Equal(time.Date(2024, 1, 11, 2, 0, 0, 0, loc), s.next(from))
+		}
+	})
+}
+
+func TestCronParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "* * * foo *"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseCron(expr, time.UTC)
+			assertions.ErrorIs(t, err, ErrInvalidCronExpr)
+		})
+	}
+}
+
+func TestCronTask(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify a cron task runs at the matching times", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		assert.NoError(scheduler.AddWithID("every-second", &Task{
+			CronExpr: "* * * * * *",
+			TaskFunc: func() error {
+				runs.Add(1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		t.Cleanup(func() { scheduler.Del("every-second") })
+
+		task, err := scheduler.Lookup("every-second")
+		if assert.NoError(err) {
+			next := task.NextRun()
+			assert.Zero(next.Nanosecond())
+			assert.WithinDuration(time.Now(), next, time.Second)
+		}
+
+		assert.Eventually(func() bool { return runs.Load() >= 2 }, 3*time.Second, 10*time.Millisecond)
+
+		task, err = scheduler.Lookup("every-second")
+		if assert.NoError(err) {
+			next := task.NextRun()
+			assert.Zero(next.Nanosecond())
+			assert.True(next.After(time.Now().Add(-10 * time.Millisecond)))
+		}
+	})
+
+	t.Run("Verify the next run follows the location", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		loc := time.FixedZone("UTC-5", -5*60*60)
+		assert.NoError(scheduler.AddWithID("daily", &Task{
+			CronExpr: "0 2 * * *",
+			Location: loc,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		t.Cleanup(func() { scheduler.Del("daily") })
+
+		assert.Eventually(func() bool {
+			task, err := scheduler.Lookup("daily")
+			return err == nil && !task.NextRun().IsZero()
+		}, time.Second, time.Millisecond)
+
+		task, err := scheduler.Lookup("daily")
+		if assert.NoError(err) {
+			next := task.NextRun().In(loc)
+			assert.Equal(2, next.Hour())
+			assert.Equal(0, next.Minute())
+			assert.WithinDuration(time.Now(), next, 24*time.Hour)
+		}
+	})
+
+	t.Run("Verify cron expressions are validated", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		task := func(expr string, interval time.Duration) *Task {
+			return &Task{
+				CronExpr: expr,
+				Interval: interval,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}
+		}
+
+		assert.ErrorIs(scheduler.AddWithID("invalid", task("* * * * *", time.Minute)), ErrCronWithInterval)
+		assert.ErrorIs(scheduler.AddWithID("invalid", task("* * * *", 0)), ErrInvalidCronExpr)
+		assert.ErrorIs(scheduler.AddWithID("invalid", task("0 0 30 2 *", 0)), ErrInvalidCronExpr)
+		assert.ErrorIs(scheduler.AddWithID("invalid", task("", 0)), ErrIntervalEmpty)
+	})
+}
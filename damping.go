@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// Defaults used when the error damping options of StdSchedulerOptions are not set.
+const (
+	defaultErrorDampingThreshold = 5
+	defaultErrorDampingInterval  = time.Minute
+)
+
+// RepeatedError is delivered to the error functions of a task with Task.DampenRepeatedErrors in place of the
+// identical failures collapsed since the previous notification. It unwraps to the latest failure.
+type RepeatedError struct {
+	// Err is the latest failure.
+	Err error
+
+	// Occurrences is the number of consecutive identical failures since Since.
+	Occurrences int
+
+	// Since is when the first of these failures happened.
+	Since time.Time
+}
+
+// Error implements the error interface.
+func (e *RepeatedError) Error() string {
+	return fmt.Sprintf("%s (%d occurrences since %s)", e.Err.Error(), e.Occurrences, e.Since.Format(time.TimeOnly))
+}
+
+// Unwrap returns the latest failure.
+func (e *RepeatedError) Unwrap() error {
+	return e.Err
+}
+
+// dampingDecision is how a failure is notified.
+type dampingDecision int
+
+const (
+	// failureNotified is a failure notified as usual.
+	failureNotified dampingDecision = iota
+	// failureDampened is the first failure collapsed into the periodic summary.
+	failureDampened
+	// failureSuppressed is a failure collapsed into the periodic summary.
+	failureSuppressed
+	// failureSummarized is a failure that triggers the periodic summary.
+	failureSummarized
+)
+
+// errorDamping counts the consecutive identical failures of a task.
+type errorDamping struct {
+	last        error
+	count       int
+	since       time.Time
+	lastSummary time.Time
+}
+
+// record counts a failure at now and decides how it is notified: the first threshold identical failures in a row are
+// notified, the following ones are collapsed into a summary every interval. A negative threshold disables damping.
+func (d *errorDamping) record(err error, now time.Time, threshold int, interval time.Duration) dampingDecision {
+	if d.last != nil && (errors.Is(err, d.last) || err.Error() == d.last.Error()) {
+		d.count++
+	} else {
+		*d = errorDamping{last: err, count: 1, since: now}
+	}
+
+	switch {
+	case threshold < 0 || d.count <= threshold:
+		return failureNotified
+	case d.count == threshold+1:
+		d.lastSummary = now
+		return failureDampened
+	case now.Sub(d.lastSummary) >= interval:
+		d.lastSummary = now
+		return failureSummarized
+	default:
+		return failureSuppressed
+	}
+}
+
+// notifyFailure logs the failure of an execution and delivers it to the error functions, collapsing the repeated
+// identical failures as configured by StdSchedulerOptions.ErrorDampingThreshold and Task.DampenRepeatedErrors.
+func (s *StdScheduler) notifyFailure(t *Task, taskCtx TaskContext, err error, retries int) {
+	threshold, interval := s.opts.ErrorDampingThreshold, s.opts.ErrorDampingInterval
+	if threshold == 0 {
+		threshold = defaultErrorDampingThreshold
+	}
+	if interval <= 0 {
+		interval = defaultErrorDampingInterval
+	}
+
+	var (
+		decision    dampingDecision
+		occurrences int
+		since       time.Time
+	)
+	t.safeOps(func() {
+		decision = t.damping.record(err, time.Now(), threshold, interval)
+		occurrences, since = t.damping.count, t.damping.since
+	})
+
+	switch decision {
+	case failureNotified:
+		logger.Errorf("task (id: %s, run: %s, retries left: %d) failed: %s", t.id, taskCtx.runID, retries, err.Error())
+	case failureDampened:
+		logger.Warnf("task (id: %s) keeps failing with the same error, further failures are summarized every %s: %s",
+			t.id, interval, err.Error())
+	case failureSummarized:
+		logger.Errorf("task (id: %s) still failing: %s, %d occurrences since %s", t.id, err.Error(), occurrences,
+			since.Format(time.TimeOnly))
+	default:
+		logger.Debugf("task (id: %s, run: %s, retries left: %d) failed: %s", t.id, taskCtx.runID, retries, err.Error())
+	}
+
+	if !t.DampenRepeatedErrors || decision == failureNotified {
+		go s.deliverError(t, taskCtx, err)
+
+		return
+	}
+
+	if decision == failureSummarized {
+		go s.deliverError(t, taskCtx, &RepeatedError{Err: err, Occurrences: occurrences, Since: since})
+	}
+}
+
+// resetDamping ends the failure streak of the task after a success.
+func (t *Task) resetDamping() {
+	t.Lock()
+	if t.damping.count > 0 {
+		t.damping = errorDamping{}
+	}
+	t.Unlock()
+}
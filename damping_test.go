@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestErrorDamping(t *testing.T) {
+	t.Run("Verify repeated failures are collapsed into periodic summaries", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errDown := errors.New("connection refused")
+		start := time.Date(2024, 6, 1, 10, 2, 0, 0, time.UTC)
+
+		var d errorDamping
+		decisions := map[dampingDecision]int{}
+		for i := 0; i < 100; i++ {
+			decisions[d.record(errDown, start.Add(time.Duration(i)*time.Second), 5, 30*time.Second)]++
+		}
+
+		assert.Equal(5, decisions[failureNotified])
+		assert.Equal(1, decisions[failureDampened])
+		assert.Equal(3, decisions[failureSummarized])
+		assert.Equal(91, decisions[failureSuppressed])
+		assert.Equal(100, d.count)
+		assert.Equal(start, d.since)
+	})
+
+	t.Run("Verify identical failures are matched by message or errors.Is", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errDown := errors.New("connection refused")
+		now := time.Now()
+
+		var d errorDamping
+		d.record(errDown, now, 1, time.Minute)
+		assert.Equal(failureDampened, d.record(fmt.Errorf("dial: %w", errDown), now, 1, time.Minute))
+		assert.Equal(failureSuppressed, d.record(errors.New("connection refused"), now, 1, time.Minute))
+
+		// A different failure starts a new streak
+		assert.Equal(failureNotified, d.record(errors.New("timeout"), now, 1, time.Minute))
+		assert.Equal(1, d.count)
+
+		// A negative threshold disables damping
+		for i := 0; i < 10; i++ {
+			assert.Equal(failureNotified, d.record(errDown, now, -1, time.Minute))
+		}
+	})
+
+	t.Run("Verify logs and error functions of 100 identical failures", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		w := &memoryAuditWriter{}
+		var (
+			mu        sync.Mutex
+			dampened  []error
+			undamped  int
+			errFailed = errors.New("dependency is down")
+		)
+
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{
+				AuditWriter:           w,
+				ErrorDampingThreshold: 3,
+				ErrorDampingInterval:  20 * time.Millisecond,
+			})
+
+			assert.NoError(scheduler.AddWithID("dampened", &Task{
+				Interval:             time.Millisecond,
+				MaxRuns:              100,
+				DampenRepeatedErrors: true,
+				TaskFunc:             func() error { return errFailed },
+				ErrFunc: func(err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					dampened = append(dampened, err)
+				},
+			}))
+			assert.NoError(scheduler.AddWithID("undamped", &Task{
+				Interval: time.Millisecond,
+				MaxRuns:  100,
+				TaskFunc: func() error { return errFailed },
+				ErrFunc: func(err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					undamped++
+				},
+			}))
+
+			assert.Eventually(func() bool {
+				return !scheduler.Has("dampened") && !scheduler.Has("undamped")
+			}, 5*time.Second, time.Millisecond)
+			scheduler.Stop()
+		})
+
+		// Every failure is still audited
+		assert.Len(w.byTask("dampened"), 100)
+
+		logs := b.String()
+		assert.Len(regexp.MustCompile(`task \(id: dampened, run: \w+, retries left: 0\) failed`).FindAllString(logs, -1), 3)
+		assert.Equal(1, strings.Count(logs, "task (id: dampened) keeps failing with the same error"))
+		summaries := strings.Count(logs, "task (id: dampened) still failing: dependency is down, ")
+		assert.GreaterOrEqual(summaries, 1)
+		assert.Contains(logs, "occurrences since ")
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return undamped == 100 && len(dampened) == 3+summaries
+		}, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Less(len(dampened), 20)
+
+		// Error functions run in their own goroutines, in no particular order
+		var plain int
+		for _, err := range dampened {
+			assert.ErrorIs(err, errFailed)
+
+			var repeated *RepeatedError
+			if !errors.As(err, &repeated) {
+				plain++
+				continue
+			}
+			assert.Greater(repeated.Occurrences, 4)
+			assert.False(repeated.Since.IsZero())
+		}
+		assert.Equal(3, plain)
+	})
+
+	t.Run("Verify a success ends the streak", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		task := &Task{}
+		now := time.Now()
+		errFailed := errors.New("failed")
+
+		task.damping.record(errFailed, now, 1, time.Minute)
+		assert.Equal(failureDampened, task.damping.record(errFailed, now, 1, time.Minute))
+
+		task.resetDamping()
+		assert.Equal(failureNotified, task.damping.record(errFailed, now, 1, time.Minute))
+	})
+}
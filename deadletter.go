@@ -0,0 +1,161 @@
+package tasks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// defaultDeadLetterLimit is the number of dead letters kept when StdSchedulerOptions.DeadLetterLimit is not set.
+const defaultDeadLetterLimit = 100
+
+// FailedAttempt is a failed execution of a RunOnce task, see DeadLetter.
+type FailedAttempt struct {
+	// At is when the execution failed.
+	At time.Time
+
+	// Err is the error returned by the execution.
+	Err error
+
+	// Trigger is why the execution happened, e.g. TriggerRetry.
+	Trigger Trigger
+}
+
+// DeadLetter is a RunOnce task that failed after exhausting its retries, kept when StdSchedulerOptions.DeadLetter is
+// enabled.
+type DeadLetter struct {
+	// ID is the ID the task was scheduled with.
+	ID string
+
+	// Err is the error of the last execution.
+	Err error
+
+	// Attempts lists the failed executions, oldest first.
+	Attempts []FailedAttempt
+
+	// At is when the task was dead-lettered.
+	At time.Time
+
+	// Task is a copy of the task as it was added, with its full retry budget.
+	Task *Task
+}
+
+// deadLetters holds the dead letters in the order they were added, evicting the oldest above limit.
+type deadLetters struct {
+	sync.Mutex
+
+	letters []DeadLetter
+}
+
+// add adds the dead letter, replacing one with the same ID, and evicts the oldest above limit.
+func (d *deadLetters) add(dl DeadLetter, limit int) {
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.remove(dl.ID)
+	d.letters = append(d.letters, dl)
+
+	if over := len(d.letters) - limit; over > 0 {
+		d.letters = append(d.letters[:0:0], d.letters[over:]...)
+	}
+}
+
+// take removes and returns the dead letter with the ID.
+func (d *deadLetters) take(id string) (DeadLetter, bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, dl := range d.letters {
+		if dl.ID == id {
+			d.remove(id)
+			return dl, true
+		}
+	}
+
+	return DeadLetter{}, false
+}
+
+// remove removes the dead letter with the ID, the lock must be held.
+func (d *deadLetters) remove(id string) {
+	for i, dl := range d.letters {
+		if dl.ID == id {
+			d.letters = append(d.letters[:i:i], d.letters[i+1:]...)
+			return
+		}
+	}
+}
+
+// DeadLetters will return the RunOnce tasks that failed after exhausting their retries, oldest first. Dead letters
+// are only kept when StdSchedulerOptions.DeadLetter is enabled.
+func (s *StdScheduler) DeadLetters() []DeadLetter {
+	s.deadLetters.Lock()
+	defer s.deadLetters.Unlock()
+
+	letters := make([]DeadLetter, len(s.deadLetters.letters))
+	for i, dl := range s.deadLetters.letters {
+		dl.Attempts = append([]FailedAttempt(nil), dl.Attempts...)
+		dl.Task = dl.Task.CloneForReuse()
+		letters[i] = dl
+	}
+
+	return letters
+}
+
+// Requeue will schedule the dead letter with the ID again, under the same ID, to run once after delay with a fresh
+// retry budget. It returns ErrTaskNotFound when there is no such dead letter, the dead letter is kept when the task
+// cannot be added.
+func (s *StdScheduler) Requeue(id string, delay time.Duration) error {
+	dl, ok := s.deadLetters.take(id)
+	if !ok {
+		return ErrTaskNotFound
+	}
+
+	t := dl.Task.CloneForReuse()
+	t.StartAfter, t.Interval = time.Time{}, delay
+
+	if err := s.AddWithID(id, t); err != nil {
+		s.deadLetters.add(dl, s.opts.DeadLetterLimit)
+		return err
+	}
+
+	return nil
+}
+
+// recordFailedAttempt adds the failed execution to the attempt history of a RunOnce task, when dead letters are kept.
+func (s *StdScheduler) recordFailedAttempt(t *Task, taskCtx TaskContext, err error) {
+	if !s.opts.DeadLetter || !t.RunOnce {
+		return
+	}
+
+	t.safeOps(func() {
+		t.failedAttempts = append(t.failedAttempts, FailedAttempt{At: time.Now(), Err: err, Trigger: taskCtx.trigger})
+	})
+}
+
+// deadLetter keeps a RunOnce task that failed for the last time, when dead letters are kept.
+func (s *StdScheduler) deadLetter(t *Task, err error) {
+	if !s.opts.DeadLetter || !t.RunOnce || t.definition == nil {
+		return
+	}
+
+	dl := DeadLetter{ID: t.id, Err: err, At: time.Now(), Task: t.definition}
+	removed := false
+	t.safeOps(func() {
+		dl.Attempts = append([]FailedAttempt(nil), t.failedAttempts...)
+		removed = t.state == TaskStateRemoved
+	})
+
+	// The error function already deleted or replaced the task
+	if removed {
+		return
+	}
+
+	s.deadLetters.add(dl, s.opts.DeadLetterLimit)
+
+	logger.Warnf("task (id: %s) has been dead-lettered: %s", t.id, err.Error())
+}
@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetters(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	failingTask := func(runs *atomic.Int32, fail *atomic.Bool) *Task {
+		return &Task{
+			Interval:             5 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				runs.Add(1)
+				if fail.Load() {
+					return errFailed
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}
+	}
+
+	t.Run("Verify terminally failed tasks are dead-lettered", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{DeadLetter: true})
+		defer scheduler.Stop()
+
+		var (
+			runs atomic.Int32
+			fail atomic.Bool
+		)
+		fail.Store(true)
+		assert.NoError(scheduler.AddWithID("failing", failingTask(&runs, &fail)))
+
+		assert.Eventually(func() bool { return len(scheduler.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+		assert.False(scheduler.Has("failing"))
+
+		dl := scheduler.DeadLetters()[0]
+		assert.Equal("failing", dl.ID)
+		assert.ErrorIs(dl.Err, errFailed)
+		assert.Equal(int32(3), runs.Load())
+		if assert.Len(dl.Attempts, 3) {
+			assert.Equal(TriggerInterval, dl.Attempts[0].Trigger)
+			assert.Equal(TriggerRetry, dl.Attempts[2].Trigger)
+			assert.ErrorIs(dl.Attempts[2].Err, errFailed)
+		}
+		assert.Equal(2, dl.Task.RetriesOnError)
+	})
+
+	t.Run("Verify requeued tasks run again with a fresh retry budget", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{DeadLetter: true})
+		defer scheduler.Stop()
+
+		var (
+			runs atomic.Int32
+			fail atomic.Bool
+		)
+		fail.Store(true)
+		assert.NoError(scheduler.AddWithID("requeued", failingTask(&runs, &fail)))
+		assert.Eventually(func() bool { return len(scheduler.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+
+		assert.NoError(scheduler.Requeue("requeued", 10*time.Millisecond))
+		assert.Empty(scheduler.DeadLetters())
+		assert.Eventually(func() bool { return len(scheduler.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(int32(6), runs.Load())
+
+		fail.Store(false)
+		assert.NoError(scheduler.Requeue("requeued", 0))
+		assert.Eventually(func() bool { return runs.Load() == 7 && !scheduler.Has("requeued") }, time.Second,
+			time.Millisecond)
+		assert.Empty(scheduler.DeadLetters())
+
+		assert.ErrorIs(scheduler.Requeue("requeued", 0), ErrTaskNotFound)
+	})
+
+	t.Run("Verify a failed requeue keeps the dead letter", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{DeadLetter: true})
+		defer scheduler.Stop()
+
+		var (
+			runs atomic.Int32
+			fail atomic.Bool
+		)
+		fail.Store(true)
+		assert.NoError(scheduler.AddWithID("taken", failingTask(&runs, &fail)))
+		assert.Eventually(func() bool { return len(scheduler.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+
+		assert.NoError(scheduler.AddWithID("taken", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.ErrorIs(scheduler.Requeue("taken", 0), ErrIDInUse)
+		assert.Len(scheduler.DeadLetters(), 1)
+
+		scheduler.Del("taken")
+		assert.Empty(scheduler.DeadLetters())
+	})
+
+	t.Run("Verify the oldest dead letters are evicted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{DeadLetter: true, DeadLetterLimit: 2})
+		defer scheduler.Stop()
+
+		var fail atomic.Bool
+		fail.Store(true)
+		for i, id := range []string{"first", "second", "third"} {
+			var runs atomic.Int32
+			assert.NoError(scheduler.AddWithID(id, failingTask(&runs, &fail)))
+			assert.Eventually(func() bool { return len(scheduler.DeadLetters()) == min(i+1, 2) && !scheduler.Has(id) },
+				time.Second, time.Millisecond)
+		}
+
+		dl := scheduler.DeadLetters()
+		if assert.Len(dl, 2) {
+			assert.Equal("second", dl[0].ID)
+			assert.Equal("third", dl[1].ID)
+		}
+	})
+
+	t.Run("Verify dead letters are not kept by default", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			runs atomic.Int32
+			fail atomic.Bool
+		)
+		fail.Store(true)
+		assert.NoError(scheduler.AddWithID("dropped", failingTask(&runs, &fail)))
+		assert.Eventually(func() bool { return runs.Load() == 3 && !scheduler.Has("dropped") }, time.Second,
+			time.Millisecond)
+		assert.Empty(scheduler.DeadLetters())
+	})
+}
@@ -0,0 +1,147 @@
+package tasks
+
+import (
+	"time"
+)
+
+// TaskEditor edits a scheduled task in place, it is returned by LookupForUpdate. Edits are applied under the task
+// lock and re-arm the pending timer when it depends on the edited setting. They are safe to call from the task
+// error functions.
+type TaskEditor struct {
+	s *StdScheduler
+	t *Task
+}
+
+// LookupForUpdate will find the specified task from the internal task list using the task ID provided, and return an
+// editor for it. Unlike the copy returned by Lookup, edits apply to the scheduled task.
+//
+//	// Give a failing task more attempts from its error function
+//	ErrFuncWithTaskContext: func(taskCtx tasks.TaskContext, err error) {
+//		if editor, err := scheduler.LookupForUpdate(taskCtx.ID()); err == nil {
+//			_ = editor.SetRetries(5, time.Second)
+//		}
+//	},
+func (s *StdScheduler) LookupForUpdate(id string) (*TaskEditor, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	return &TaskEditor{s: s, t: t}, nil
+}
+
+// SetInterval will set the interval of a recurring task. A pending interval timer is moved so that the next execution
+// happens the new interval after the previous one, or immediately if that is already past. It returns
+// ErrTaskNotFound if the task has been removed since the lookup.
+func (e *TaskEditor) SetInterval(interval time.Duration) error {
+	return e.edit(func(t *Task) (time.Time, bool, error) {
+		if !t.RunOnce && interval <= 0 {
+			return time.Time{}, false, ErrIntervalEmpty
+		}
+
+		if t.cron != nil && interval > 0 {
+			return time.Time{}, false, ErrCronWithInterval
+		}
+
+		prev := t.Interval
+		t.Interval = interval
+
+		// A boosted task keeps its boost, the new interval applies once it ends
+		if t.RunOnce || t.trigger != TriggerInterval || t.interval() != interval {
+			return time.Time{}, false, nil
+		}
+		next, armed := e.s.rearm(t, prev, interval)
+
+		return next, armed, nil
+	})
+}
+
+// SetRetries will set the number of retries on error, and the interval between them. The retries left to the current
+// failing cycle are reset to the new number. A pending retry is moved so that it happens the new interval after the
+// failure, or immediately if that is already past. It returns ErrTaskNotFound if the task has been removed since the
+// lookup.
+func (e *TaskEditor) SetRetries(retries int, interval time.Duration) error {
+	return e.edit(func(t *Task) (time.Time, bool, error) {
+		if retries > 0 && interval <= 0 && t.RetryBackoff == nil {
+			return time.Time{}, false, ErrRetryOnErrorIntervalEmpty
+		}
+
+		prev := t.RetryOnErrorInterval
+		t.RetriesOnError, t.retriesRemaining = retries, retries
+		t.RetryOnErrorInterval = interval
+
+		// A pending retry delayed by RetryBackoff is left as is
+		if !t.retryPending || t.trigger != TriggerRetry || t.RetryBackoff != nil {
+			return time.Time{}, false, nil
+		}
+		next, armed := e.s.rearm(t, prev, interval)
+
+		return next, armed, nil
+	})
+}
+
+// SetDryRun will turn the dry run mode of the task on or off, see Task.DryRun. It applies from the next execution, an
+// execution in flight completes in the mode it started in. It returns ErrTaskNotFound if the task has been removed
+// since the lookup.
+func (e *TaskEditor) SetDryRun(enabled bool) error {
+	return e.edit(func(t *Task) (time.Time, bool, error) {
+		t.DryRun = enabled
+
+		return time.Time{}, false, nil
+	})
+}
+
+// AddRescheduleRule will reschedule the task after interval when it fails with err, at most count times, like
+// Task.WithRescheduleOnError. It replaces the rule of the same error, and applies from the next failure. It returns
+// ErrTaskNotFound if the task has been removed since the lookup, and ErrTooManyRescheduleRules beyond
+// MaxRescheduleRules.
+func (e *TaskEditor) AddRescheduleRule(err error, interval time.Duration, count int) error {
+	return e.edit(func(t *Task) (time.Time, bool, error) {
+		return time.Time{}, false, t.addRescheduleRule(err, interval, count)
+	})
+}
+
+// edit applies f to the task under its lock and reports the timer re-armed by f, if any.
+func (e *TaskEditor) edit(f func(t *Task) (next time.Time, armed bool, err error)) error {
+	var (
+		next  time.Time
+		armed bool
+		err   error
+	)
+	e.t.safeOps(func() {
+		if e.t.state == TaskStateRemoved {
+			err = ErrTaskNotFound
+			return
+		}
+
+		next, armed, err = f(e.t)
+	})
+	if armed {
+		e.s.notifyScheduleChange(e.t.id, next, "edited")
+	}
+
+	return err
+}
+
+// rearm moves the pending fire of the task by the change of the delay it was armed with. Fires already due are left
+// to the timer. The task lock must be held.
+func (s *StdScheduler) rearm(t *Task, prev, d time.Duration) (time.Time, bool) {
+	if !t.armed() || prev == d || !t.nextFire.After(time.Now()) {
+		return time.Time{}, false
+	}
+
+	wait := time.Until(t.nextFire.Add(d - prev))
+	if wait < 0 {
+		wait = 0
+	}
+
+	decision := DecisionTimerArmed
+	if t.trigger == TriggerRetry {
+		decision = DecisionRetryArmed
+	}
+
+	return s.resetTimer(t, wait, decision, t.trigger)
+}
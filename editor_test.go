@@ -0,0 +1,185 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestLookupForUpdate(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify retries edited from the error function are honored", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs, errs atomic.Int32
+		task := &Task{
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Hour,
+			TaskFunc: func() error {
+				runs.Add(1)
+				return errors.New("failed")
+			},
+		}
+		task.ErrFuncWithTaskContext = func(taskCtx TaskContext, err error) {
+			if errs.Add(1) > 1 {
+				return
+			}
+
+			editor, err := scheduler.LookupForUpdate(taskCtx.ID())
+			if assert.NoError(err) {
+				assert.NoError(editor.SetRetries(3, 10*time.Millisecond))
+			}
+		}
+		assert.NoError(scheduler.AddWithID("edited-retries", task))
+
+		// Without the edit, the only retry would wait for an hour
+		assert.Eventually(func() bool { return !scheduler.Has("edited-retries") }, time.Second, 5*time.Millisecond)
+		assert.GreaterOrEqual(runs.Load(), int32(4))
+		assert.LessOrEqual(runs.Load(), int32(5))
+	})
+
+	t.Run("Verify an edited interval re-arms the pending timer", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		runCh := make(chan struct{}, 10)
+		assert.NoError(scheduler.AddWithID("edited-interval", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error {
+				runCh <- struct{}{}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+		defer scheduler.Del("edited-interval")
+
+		editor, err := scheduler.LookupForUpdate("edited-interval")
+		assert.NoError(err)
+		assert.NoError(editor.SetInterval(10 * time.Millisecond))
+		assert.ErrorIs(editor.SetInterval(0), ErrIntervalEmpty)
+
+		select {
+		case <-runCh:
+		case <-time.After(time.Second):
+			t.Errorf("Edited task did not execute within 1 second")
+		}
+
+		task, err := scheduler.Lookup("edited-interval")
+		assert.NoError(err)
+		assert.Equal(10*time.Millisecond, task.Interval)
+	})
+
+	t.Run("Verify reschedule rules can be added to a scheduled task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errTemporary := errors.New("temporary")
+		assert.NoError(scheduler.AddWithID("edited-rules", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return errTemporary },
+			ErrFunc:  func(e error) {},
+		}))
+		defer scheduler.Del("edited-rules")
+
+		editor, err := scheduler.LookupForUpdate("edited-rules")
+		assert.NoError(err)
+		assert.NoError(editor.AddRescheduleRule(errTemporary, time.Minute, 2))
+
+		task, err := scheduler.Lookup("edited-rules")
+		assert.NoError(err)
+		assert.Equal([]RescheduleRule{{Err: errTemporary, Interval: time.Minute, Remaining: 2}}, task.RescheduleRules())
+	})
+
+	t.Run("Verify dry run can be toggled on a scheduled task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var calls atomic.Int32
+		assert.NoError(scheduler.AddWithID("edited-dry-run", &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				calls.Add(1)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+		defer scheduler.Del("edited-dry-run")
+
+		assert.Eventually(func() bool { return calls.Load() > 0 }, time.Second, time.Millisecond)
+
+		editor, err := scheduler.LookupForUpdate("edited-dry-run")
+		assert.NoError(err)
+		assert.NoError(editor.SetDryRun(true))
+
+		// Executions go on without calling the task function, once the one in flight is over
+		time.Sleep(20 * time.Millisecond)
+		dryCalls := calls.Load()
+		status, err := scheduler.TaskStatus("edited-dry-run")
+		assert.NoError(err)
+		assert.Eventually(func() bool {
+			dry, err := scheduler.TaskStatus("edited-dry-run")
+			return err == nil && dry.RunCount >= status.RunCount+3
+		}, time.Second, time.Millisecond)
+		assert.Equal(dryCalls, calls.Load())
+
+		task, err := scheduler.Lookup("edited-dry-run")
+		assert.NoError(err)
+		assert.True(task.DryRun)
+
+		assert.NoError(editor.SetDryRun(false))
+		assert.Eventually(func() bool { return calls.Load() > dryCalls }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify removed tasks cannot be edited", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		_, err := scheduler.LookupForUpdate("missing")
+		assert.ErrorIs(err, ErrTaskNotFound)
+
+		assert.NoError(scheduler.AddWithID("edited-removed", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		}))
+
+		editor, err := scheduler.LookupForUpdate("edited-removed")
+		assert.NoError(err)
+
+		scheduler.Del("edited-removed")
+		assert.ErrorIs(editor.SetInterval(time.Minute), ErrTaskNotFound)
+	})
+}
+
+func TestReadOnlyLookup(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	assert.NoError(scheduler.AddWithID("read-only", &Task{
+		Interval: time.Hour,
+		TaskFunc: func() error { return nil },
+		ErrFunc:  func(e error) {},
+	}))
+
+	copied, err := scheduler.Lookup("read-only")
+	assert.NoError(err)
+
+	assert.ErrorIs(copied.SetInterval(time.Minute), ErrReadOnlyTask)
+	assert.ErrorIs(copied.SetRunOnce(true), ErrReadOnlyTask)
+	assert.ErrorIs(copied.SetRetries(1, time.Minute), ErrReadOnlyTask)
+	assert.ErrorIs(copied.SetFuncs(nil, nil), ErrReadOnlyTask)
+	assert.ErrorIs(copied.WithRescheduleOnError(errors.New("some"), time.Minute, 1), ErrReadOnlyTask)
+	assert.ErrorIs(scheduler.Tasks()["read-only"].SetInterval(time.Minute), ErrReadOnlyTask)
+
+	task, err := scheduler.Lookup("read-only")
+	assert.NoError(err)
+	assert.Equal(time.Hour, task.Interval)
+	assert.Empty(task.RescheduleRules())
+
+	// Clones of a read-only copy are regular tasks
+	assert.NoError(copied.Clone().SetInterval(time.Minute))
+}
@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExclusiveStats counts how exclusive executions, see Task.Exclusive, held the other executions back.
+type ExclusiveStats struct {
+	// Runs is the number of exclusive executions started.
+	Runs uint64
+
+	// Waits is the number of exclusive executions that had to wait for executions in flight to finish.
+	Waits uint64
+
+	// WaitTime is the total time exclusive executions waited for executions in flight to finish.
+	WaitTime time.Duration
+
+	// Blocked is the number of executions that had to wait for an exclusive execution to finish, or for one waiting
+	// to start.
+	Blocked uint64
+}
+
+// exclusiveGate keeps exclusive executions apart from all the others: executions take it for reading, exclusive
+// ones for writing. A writer waiting for the gate blocks new readers, so a steady stream of executions cannot starve
+// an exclusive one.
+type exclusiveGate struct {
+	sync.RWMutex
+
+	runs     atomic.Uint64
+	waits    atomic.Uint64
+	waitTime atomic.Int64
+	blocked  atomic.Uint64
+}
+
+// enter waits until an execution, exclusive or not, may start.
+func (g *exclusiveGate) enter(exclusive bool) {
+	if !exclusive {
+		if !g.TryRLock() {
+			g.blocked.Add(1)
+			g.RLock()
+		}
+
+		return
+	}
+
+	if !g.TryLock() {
+		g.waits.Add(1)
+		waited := time.Now()
+		g.Lock()
+		g.waitTime.Add(int64(time.Since(waited)))
+	}
+	g.runs.Add(1)
+}
+
+// leave lets the executions held back by an execution entered with the same exclusive flag start.
+func (g *exclusiveGate) leave(exclusive bool) {
+	if exclusive {
+		g.Unlock()
+
+		return
+	}
+
+	g.RUnlock()
+}
+
+// ExclusiveStats will return how exclusive executions held the other executions back so far, see Task.Exclusive.
+func (s *StdScheduler) ExclusiveStats() ExclusiveStats {
+	return ExclusiveStats{
+		Runs:     s.exclusive.runs.Load(),
+		Waits:    s.exclusive.waits.Load(),
+		WaitTime: time.Duration(s.exclusive.waitTime.Load()),
+		Blocked:  s.exclusive.blocked.Load(),
+	}
+}
@@ -0,0 +1,149 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestExclusive(t *testing.T) {
+	t.Run("Verify exclusive executions never overlap with others", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var active, exclusiveActive, violations, exclusiveRuns int64
+
+		for i := 0; i < 5; i++ {
+			_, err := scheduler.Add(&Task{
+				Interval: time.Millisecond,
+				TaskFunc: func() error {
+					atomic.AddInt64(&active, 1)
+					defer atomic.AddInt64(&active, -1)
+
+					if atomic.LoadInt64(&exclusiveActive) != 0 {
+						atomic.AddInt64(&violations, 1)
+					}
+					time.Sleep(2 * time.Millisecond)
+
+					return nil
+				},
+				ErrFunc: func(error) {},
+			})
+			assert.NoError(err)
+		}
+
+		_, err := scheduler.Add(&Task{
+			Interval:  5 * time.Millisecond,
+			Exclusive: true,
+			TaskFunc: func() error {
+				atomic.AddInt64(&exclusiveActive, 1)
+				defer atomic.AddInt64(&exclusiveActive, -1)
+
+				if atomic.LoadInt64(&active) != 0 {
+					atomic.AddInt64(&violations, 1)
+				}
+				time.Sleep(time.Millisecond)
+				if atomic.LoadInt64(&active) != 0 {
+					atomic.AddInt64(&violations, 1)
+				}
+				atomic.AddInt64(&exclusiveRuns, 1)
+
+				return nil
+			},
+			ErrFunc: func(error) {},
+		})
+		assert.NoError(err)
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&exclusiveRuns) >= 10 }, 5*time.Second,
+			5*time.Millisecond)
+		assert.Zero(atomic.LoadInt64(&violations))
+	})
+
+	t.Run("Verify exclusive executions are not starved under continuous load", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		// Executions of every task overlap, so there is always one in flight
+		for i := 0; i < 5; i++ {
+			_, err := scheduler.Add(&Task{
+				Interval: time.Millisecond,
+				TaskFunc: func() error {
+					time.Sleep(20 * time.Millisecond)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			})
+			assert.NoError(err)
+		}
+
+		// Let the load build up
+		time.Sleep(50 * time.Millisecond)
+
+		ran := make(chan struct{})
+		_, err := scheduler.Add(&Task{
+			Interval:  time.Millisecond,
+			RunOnce:   true,
+			Exclusive: true,
+			TaskFunc: func() error {
+				close(ran)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case <-ran:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("exclusive task did not run within 2 seconds")
+		}
+
+		stats := scheduler.ExclusiveStats()
+		assert.Equal(uint64(1), stats.Runs)
+		assert.Equal(uint64(1), stats.Waits)
+		assert.Greater(stats.WaitTime, time.Duration(0))
+		assert.Greater(stats.Blocked, uint64(0))
+	})
+
+	t.Run("Verify submitted functions wait for exclusive executions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		_, err := scheduler.Add(&Task{
+			Interval:  time.Millisecond,
+			RunOnce:   true,
+			Exclusive: true,
+			TaskFunc: func() error {
+				close(started)
+				<-release
+				return nil
+			},
+			ErrFunc: func(error) {},
+		})
+		assert.NoError(err)
+		<-started
+
+		var ran int64
+		assert.NoError(scheduler.Submit(func() error {
+			atomic.StoreInt64(&ran, 1)
+			return nil
+		}, func(error) {}))
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Zero(atomic.LoadInt64(&ran))
+
+		close(release)
+		assert.Eventually(func() bool { return atomic.LoadInt64(&ran) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(uint64(1), scheduler.ExclusiveStats().Blocked)
+	})
+}
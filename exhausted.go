@@ -0,0 +1,22 @@
+package tasks
+
+// deliverExhausted calls the exhausted function of a task that failed for the last time, if any, recovering a panic
+// like deliverError.
+func (s *StdScheduler) deliverExhausted(t *Task, taskCtx TaskContext, err error) {
+	if t.ExhaustedFunc == nil && t.ExhaustedFuncWithTaskContext == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.handlerPanicked(t.id, r)
+		}
+	}()
+
+	if t.ExhaustedFuncWithTaskContext != nil {
+		t.ExhaustedFuncWithTaskContext(taskCtx, err)
+
+		return
+	}
+	t.ExhaustedFunc(err)
+}
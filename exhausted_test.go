@@ -0,0 +1,127 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestExhaustedFunc(t *testing.T) {
+	t.Run("Verify it is called once the last retry failed", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			attempts  atomic.Int32
+			failures  atomic.Int32
+			mu        sync.Mutex
+			exhausted []error
+		)
+		assert.NoError(scheduler.AddWithID("exhausted", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: time.Millisecond,
+			TaskFunc: func() error {
+				return fmt.Errorf("attempt %d", attempts.Add(1))
+			},
+			ErrFunc: func(error) { failures.Add(1) },
+			ExhaustedFunc: func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				exhausted = append(exhausted, err)
+			},
+		}))
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(exhausted) > 0 && failures.Load() == 3
+		}, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal([]error{errors.New("attempt 3")}, exhausted)
+		assert.Equal(int32(3), attempts.Load())
+		assert.False(scheduler.Has("exhausted"))
+	})
+
+	t.Run("Verify it is not called when a retry succeeds", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			attempts  atomic.Int32
+			exhausted atomic.Int32
+		)
+		assert.NoError(scheduler.AddWithID("recovered", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: time.Millisecond,
+			TaskFunc: func() error {
+				if attempts.Add(1) < 3 {
+					return errors.New("transient")
+				}
+				return nil
+			},
+			ErrFunc:       func(error) {},
+			ExhaustedFunc: func(error) { exhausted.Add(1) },
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("recovered") }, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(int32(3), attempts.Load())
+		assert.Zero(exhausted.Load())
+	})
+
+	t.Run("Verify it is called once the reschedules are used", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errBusy := errors.New("busy")
+		var attempts atomic.Int32
+		exhausted := make(chan string, 2)
+		task := &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				attempts.Add(1)
+				return errBusy
+			},
+			ErrFunc: func(error) {},
+			ExhaustedFuncWithTaskContext: func(taskCtx TaskContext, err error) {
+				assert.ErrorIs(err, errBusy)
+				exhausted <- taskCtx.ID()
+			},
+		}
+		assert.NoError(task.WithRescheduleOnError(errBusy, time.Millisecond, 2))
+		assert.NoError(scheduler.AddWithID("rescheduled", task))
+
+		select {
+		case id := <-exhausted:
+			assert.Equal("rescheduled", id)
+		case <-time.After(time.Second):
+			t.Fatal("the exhausted function was not called")
+		}
+
+		assert.Eventually(func() bool { return !scheduler.Has("rescheduled") }, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(int32(3), attempts.Load())
+		assert.Empty(exhausted)
+	})
+}
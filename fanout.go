@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// runGroup tracks the goroutines started with TaskContext.Go during an execution, like an errgroup.
+type runGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	running int
+	done    bool
+	err     error
+}
+
+// newRunGroup returns a group whose goroutines are cancelled with parent.
+func newRunGroup(parent context.Context) *runGroup {
+	g := &runGroup{}
+	g.ctx, g.cancel = context.WithCancel(parent)
+	g.cond = sync.NewCond(&g.mu)
+
+	return g
+}
+
+// Go will run f in a goroutine bound to the execution. The context passed to f is cancelled when the task is deleted,
+// or as soon as one of the goroutines started with Go returns an error. The execution is only complete once all of
+// them have returned, and it fails with the first error returned by any of them, even when the task function
+// returns nil.
+//
+// Go is only meaningful within FuncWithTaskContext. Goroutines started from a context of an execution already
+// complete, or from the context passed to the error functions, are not waited for and their errors are only logged.
+//
+//	FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+//		for _, shard := range shards {
+//			shard := shard
+//			taskCtx.Go(func(ctx context.Context) error {
+//				return sync(ctx, shard)
+//			})
+//		}
+//		return nil
+//	},
+func (ctx TaskContext) Go(f func(ctx context.Context) error) {
+	if ctx.group == nil || !ctx.group.add() {
+		parent := ctx.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		go func() {
+			if err := f(parent); err != nil {
+				logger.Errorf("task (id: %s) goroutine started after its execution failed: %s", ctx.id, err.Error())
+			}
+		}()
+
+		return
+	}
+
+	go func() {
+		ctx.group.finish(f(ctx.group.ctx))
+	}()
+}
+
+// add registers a new goroutine, it returns false once the group has been waited for and has no goroutine left.
+func (g *runGroup) add() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.done && g.running == 0 {
+		return false
+	}
+	g.running++
+
+	return true
+}
+
+// finish records the result of a goroutine, the first error cancels the others.
+func (g *runGroup) finish(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil && g.err == nil {
+		g.err = err
+		g.cancel()
+	}
+
+	g.running--
+	if g.running == 0 {
+		g.cond.Broadcast()
+	}
+}
+
+// wait waits for all the goroutines of the group, including the ones they start, and returns the first error.
+func (g *runGroup) wait() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.done = true
+	for g.running > 0 {
+		g.cond.Wait()
+	}
+	g.cancel()
+
+	return g.err
+}
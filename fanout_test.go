@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTaskContextGo(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify the execution waits for all goroutines", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var finished atomic.Int32
+		doneCh := make(chan int32, 1)
+
+		id, err := scheduler.Add(&Task{
+			RunOnce: true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				for i := 0; i < 5; i++ {
+					taskCtx.Go(func(ctx context.Context) error {
+						time.Sleep(20 * time.Millisecond)
+						finished.Add(1)
+						return nil
+					})
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		// The run only finishes, deleting the RunOnce task, once its goroutines have returned
+		assert.Eventually(func() bool {
+			if !scheduler.Has(id) {
+				doneCh <- finished.Load()
+				return true
+			}
+			return false
+		}, time.Second, time.Millisecond)
+		assert.Equal(int32(5), <-doneCh)
+	})
+
+	t.Run("Verify a failed goroutine fails the execution and drives a retry", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errSubtask := errors.New("subtask failed")
+		errCh := make(chan error, 2)
+
+		var runs atomic.Int32
+		id, err := scheduler.Add(&Task{
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				first := runs.Add(1) == 1
+
+				taskCtx.Go(func(ctx context.Context) error {
+					if first {
+						return errSubtask
+					}
+					return nil
+				})
+
+				// The other goroutines are cancelled by the failure
+				taskCtx.Go(func(ctx context.Context) error {
+					if !first {
+						return nil
+					}
+
+					select {
+					case <-ctx.Done():
+						return nil
+					case <-time.After(time.Second):
+						return errors.New("not cancelled")
+					}
+				})
+				return nil
+			},
+			ErrFunc: func(e error) { errCh <- e },
+		})
+		assert.NoError(err)
+
+		select {
+		case e := <-errCh:
+			assert.ErrorIs(e, errSubtask)
+		case <-time.After(time.Second):
+			t.Errorf("Failed goroutine was not reported within 1 second")
+		}
+
+		assert.Eventually(func() bool { return runs.Load() == 2 }, time.Second, time.Millisecond)
+		assert.Eventually(func() bool { return !scheduler.Has(id) }, time.Second, time.Millisecond)
+		assert.Empty(errCh)
+	})
+
+	t.Run("Verify Del cancels all goroutines", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		startedCh := make(chan struct{}, 3)
+		cancelledCh := make(chan struct{}, 3)
+
+		id, err := scheduler.Add(&Task{
+			RunOnce: true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				for i := 0; i < 3; i++ {
+					taskCtx.Go(func(ctx context.Context) error {
+						startedCh <- struct{}{}
+						<-ctx.Done()
+						cancelledCh <- struct{}{}
+						return nil
+					})
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		for i := 0; i < 3; i++ {
+			<-startedCh
+		}
+		scheduler.Del(id)
+
+		for i := 0; i < 3; i++ {
+			select {
+			case <-cancelledCh:
+			case <-time.After(time.Second):
+				t.Fatalf("Goroutine was not cancelled within 1 second")
+			}
+		}
+	})
+
+	t.Run("Verify goroutines started outside of an execution still run", func(t *testing.T) {
+		ranCh := make(chan struct{})
+		TaskContext{Context: context.Background()}.Go(func(ctx context.Context) error {
+			close(ranCh)
+			return errors.New("logged")
+		})
+
+		select {
+		case <-ranCh:
+		case <-time.After(time.Second):
+			t.Errorf("Goroutine did not run within 1 second")
+		}
+	})
+}
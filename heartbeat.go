@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// defaultTimerStarvationThreshold is used when StdSchedulerOptions.TimerStarvationThreshold is not set.
+const defaultTimerStarvationThreshold = 5 * time.Second
+
+// heartbeatInterval is how often the heartbeat expects to fire, it is replaced in tests.
+var heartbeatInterval = time.Second
+
+// Health tells whether the scheduler can rely on its timers, whether its tasks meet their SLO and whether it keeps up
+// with them.
+type Health struct {
+	// TimerStarvation is true while the heartbeat of the scheduler fires later than
+	// StdSchedulerOptions.TimerStarvationThreshold, e.g. when a busy loop starves the runtime. Every task then fires
+	// late. It is cleared by the first heartbeat back on time.
+	TimerStarvation bool
+
+	// TimerDelay is how late the latest heartbeat fired.
+	TimerDelay time.Duration
+
+	// SLOBreaches are the IDs of the tasks currently below their Task.SLO, sorted. The breach episodes are also
+	// reported to StdSchedulerOptions.OnSLOBreach and OnSLORecover.
+	SLOBreaches []string
+
+	// Saturation tells whether the scheduler keeps up with its tasks, as reported by StdScheduler.Saturation.
+	Saturation Saturation
+}
+
+// Stats accumulates measurements over the lifetime of the scheduler, see Uptime.
+type Stats struct {
+	// Heartbeats is the number of times the heartbeat fired.
+	Heartbeats uint64
+
+	// WorstTimerDelay is the longest delay of the heartbeat seen so far.
+	WorstTimerDelay time.Duration
+
+	// GroupQueueWait is how long the latest executions waited for a worker of the shared pool, by Task.Group. It is
+	// empty without a StdSchedulerOptions.WorkerLimit.
+	GroupQueueWait map[string]GroupQueueWait
+}
+
+// heartbeat is an internal timer firing every second that measures how late the runtime fires timers. It is not a
+// task: it does not appear in Tasks and does not count against the TaskLimit.
+type heartbeat struct {
+	sync.Mutex
+
+	timer     *time.Timer
+	interval  time.Duration
+	threshold time.Duration
+	last      time.Time
+	beats     uint64
+	delay     time.Duration
+	worst     time.Duration
+	starved   bool
+	stopped   bool
+}
+
+// newHeartbeat starts a heartbeat flagging timer starvation beyond the threshold.
+func newHeartbeat(threshold time.Duration) *heartbeat {
+	if threshold <= 0 {
+		threshold = defaultTimerStarvationThreshold
+	}
+
+	h := &heartbeat{interval: heartbeatInterval, threshold: threshold, last: time.Now()}
+	h.Lock()
+	h.timer = time.AfterFunc(h.interval, h.fire)
+	h.Unlock()
+
+	return h
+}
+
+// fire measures the heartbeat and re-arms it.
+func (h *heartbeat) fire() {
+	h.beat(time.Now())
+
+	h.Lock()
+	if !h.stopped {
+		h.timer.Reset(h.interval)
+	}
+	h.Unlock()
+}
+
+// beat records a heartbeat fired at now, raising or clearing the timer starvation flag.
+func (h *heartbeat) beat(now time.Time) {
+	h.Lock()
+	delay := now.Sub(h.last) - h.interval
+	if delay < 0 {
+		delay = 0
+	}
+
+	h.last = now
+	h.beats++
+	h.delay = delay
+	if delay > h.worst {
+		h.worst = delay
+	}
+
+	starved := delay > h.threshold
+	changed := starved != h.starved
+	h.starved = starved
+	h.Unlock()
+
+	// A starvation episode is logged when it starts and when it ends, not on every late heartbeat
+	switch {
+	case !changed:
+	case starved:
+		logger.Errorf("scheduler timers are starved, the heartbeat fired %s late", delay)
+	default:
+		logger.Infof("scheduler timers have recovered, the heartbeat fired %s late", delay)
+	}
+}
+
+// stop stops the heartbeat.
+func (h *heartbeat) stop() {
+	h.Lock()
+	h.stopped = true
+	h.timer.Stop()
+	h.Unlock()
+}
+
+// Health will report whether the timers of the scheduler fire on time, from an internal heartbeat expected every
+// second, which tasks are below their SLO and whether the scheduler is saturated. See
+// StdSchedulerOptions.TimerStarvationThreshold.
+func (s *StdScheduler) Health() Health {
+	s.heartbeat.Lock()
+	health := Health{TimerStarvation: s.heartbeat.starved, TimerDelay: s.heartbeat.delay}
+	s.heartbeat.Unlock()
+
+	health.SLOBreaches = s.sloBreaches()
+	health.Saturation = s.Saturation()
+
+	return health
+}
+
+// Stats will return the measurements accumulated since the scheduler was created.
+func (s *StdScheduler) Stats() Stats {
+	s.heartbeat.Lock()
+	stats := Stats{Heartbeats: s.heartbeat.beats, WorstTimerDelay: s.heartbeat.worst}
+	s.heartbeat.Unlock()
+
+	if s.pool != nil {
+		stats.GroupQueueWait = s.pool.groupWaits()
+	}
+
+	return stats
+}
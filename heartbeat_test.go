@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestHeartbeat(t *testing.T) {
+	t.Run("Verify timer starvation is flagged and cleared", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{TimerStarvationThreshold: 2 * time.Second})
+			defer scheduler.Stop()
+
+			assert.False(scheduler.Health().TimerStarvation)
+
+			// Fake the heartbeats: on time, then 30 seconds late twice, then back on time
+			start := time.Now()
+			scheduler.heartbeat.Lock()
+			scheduler.heartbeat.last = start
+			scheduler.heartbeat.Unlock()
+
+			scheduler.heartbeat.beat(start.Add(heartbeatInterval))
+			assert.False(scheduler.Health().TimerStarvation)
+
+			scheduler.heartbeat.beat(start.Add(2*heartbeatInterval + 30*time.Second))
+			health := scheduler.Health()
+			assert.True(health.TimerStarvation)
+			assert.Equal(30*time.Second, health.TimerDelay)
+
+			scheduler.heartbeat.beat(start.Add(3*heartbeatInterval + 60*time.Second))
+			assert.True(scheduler.Health().TimerStarvation)
+
+			scheduler.heartbeat.beat(start.Add(4*heartbeatInterval + 60*time.Second))
+			health = scheduler.Health()
+			assert.False(health.TimerStarvation)
+			assert.Zero(health.TimerDelay)
+
+			stats := scheduler.Stats()
+			assert.Equal(30*time.Second, stats.WorstTimerDelay)
+			assert.GreaterOrEqual(stats.Heartbeats, uint64(4))
+		})
+
+		// The episode is logged once when it starts and once when it ends
+		assert.Equal(1, strings.Count(b.String(), "scheduler timers are starved"))
+		assert.Contains(b.String(), "scheduler timers are starved, the heartbeat fired 30s late")
+		assert.Equal(1, strings.Count(b.String(), "scheduler timers have recovered"))
+	})
+
+	t.Run("Verify the heartbeat is not a task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{TaskLimit: 1})
+		defer scheduler.Stop()
+
+		assert.Empty(scheduler.Tasks())
+		assert.NoError(scheduler.AddWithID("only", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.Len(scheduler.Tasks(), 1)
+	})
+
+	t.Run("Verify the heartbeat fires", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		interval := heartbeatInterval
+		heartbeatInterval = 5 * time.Millisecond
+		defer func() { heartbeatInterval = interval }()
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		assert.Eventually(func() bool { return scheduler.Stats().Heartbeats >= 3 }, time.Second, time.Millisecond)
+		assert.False(scheduler.Health().TimerStarvation)
+
+		scheduler.Stop()
+		beats := scheduler.Stats().Heartbeats
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(beats, scheduler.Stats().Heartbeats)
+	})
+}
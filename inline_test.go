@@ -0,0 +1,226 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// retryGaps runs a RunOnce task failing every attempt and returns the gaps between its attempts.
+func retryGaps(t *testing.T, inline bool, retries int, interval time.Duration) []time.Duration {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	var (
+		mu       sync.Mutex
+		attempts []time.Time
+		done     = make(chan struct{})
+	)
+	err := scheduler.AddWithID("gaps", &Task{
+		Interval:             time.Millisecond,
+		RunOnce:              true,
+		RetriesOnError:       retries,
+		RetryOnErrorInterval: interval,
+		InlineRetries:        inline,
+		TaskFunc: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			attempts = append(attempts, time.Now())
+			if len(attempts) == retries+1 {
+				close(done)
+			}
+
+			return errors.New("flaky")
+		},
+		ErrFunc: func(error) {},
+	})
+	if err != nil {
+		t.Fatalf("adding the task: %s", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the retries did not complete")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	gaps := make([]time.Duration, 0, len(attempts)-1)
+	for i := 1; i < len(attempts); i++ {
+		gaps = append(gaps, attempts[i].Sub(attempts[i-1]))
+	}
+
+	return gaps
+}
+
+// meanDeviation is the mean absolute difference between the gaps and the interval.
+func meanDeviation(gaps []time.Duration, interval time.Duration) time.Duration {
+	var total time.Duration
+	for _, gap := range gaps {
+		d := gap - interval
+		if d < 0 {
+			d = -d
+		}
+		total += d
+	}
+
+	return total / time.Duration(len(gaps))
+}
+
+func TestInlineRetries(t *testing.T) {
+	t.Run("Verify the precision of inline retries against timer retries", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		const interval = 2 * time.Millisecond
+
+		inline := retryGaps(t, true, 20, interval)
+		timer := retryGaps(t, false, 20, interval)
+		t.Logf("mean deviation from %s: inline %s, timer %s", interval, meanDeviation(inline, interval),
+			meanDeviation(timer, interval))
+
+		assert.Len(inline, 20)
+		for _, gap := range inline {
+			assert.GreaterOrEqual(gap, interval)
+		}
+		assert.Less(meanDeviation(inline, interval), 5*time.Millisecond)
+	})
+
+	t.Run("Verify each attempt is reported as one execution in flight", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			attempts  atomic.Int32
+			failures  atomic.Int32
+			exhausted = make(chan error, 1)
+		)
+		assert.NoError(scheduler.AddWithID("inline", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       3,
+			RetryOnErrorInterval: time.Millisecond,
+			InlineRetries:        true,
+			TaskFunc: func() error {
+				attempts.Add(1)
+
+				return errors.New("flaky")
+			},
+			ErrFunc:       func(error) { failures.Add(1) },
+			ExhaustedFunc: func(err error) { exhausted <- err },
+		}))
+
+		var status TaskStatus
+		assert.Eventually(func() bool {
+			var err error
+			status, err = scheduler.TaskStatus("inline")
+
+			return err == nil && status.RunCount == 3
+		}, time.Second, 100*time.Microsecond)
+		assert.Equal(1, status.PeakConcurrency)
+
+		select {
+		case err := <-exhausted:
+			assert.EqualError(err, "flaky")
+		case <-time.After(time.Second):
+			t.Fatal("the task did not exhaust its retries")
+		}
+		assert.Equal(int32(4), attempts.Load())
+		assert.Eventually(func() bool { return failures.Load() == 4 }, time.Second, time.Millisecond)
+		assert.Eventually(func() bool { return !scheduler.Has("inline") }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify the timeout applies to each attempt", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			attempts atomic.Int32
+			done     = make(chan struct{})
+		)
+		assert.NoError(scheduler.AddWithID("timeout", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: time.Millisecond,
+			InlineRetries:        true,
+			Timeout:              10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				if attempts.Add(1) < 3 {
+					<-taskCtx.Context.Done()
+
+					return taskCtx.Context.Err()
+				}
+				close(done)
+
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the last attempt did not run")
+		}
+		assert.Equal(int32(3), attempts.Load())
+	})
+
+	t.Run("Verify deleting the task stops the retry loop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			attempts atomic.Int32
+			failures atomic.Int32
+			ctxErr   atomic.Value
+			started  = make(chan struct{}, 100)
+		)
+		assert.NoError(scheduler.AddWithID("cancel", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       100,
+			RetryOnErrorInterval: 50 * time.Millisecond,
+			InlineRetries:        true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				attempts.Add(1)
+				started <- struct{}{}
+
+				return errors.New("flaky")
+			},
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, _ error) {
+				if failures.Add(1) != 2 {
+					return
+				}
+				<-taskCtx.Context.Done()
+				ctxErr.Store(taskCtx.Context.Err())
+			},
+		}))
+
+		<-started
+		<-started
+		deleted := time.Now()
+		scheduler.Del("cancel")
+
+		assert.Eventually(func() bool { return ctxErr.Load() != nil }, time.Second, time.Millisecond)
+		assert.Less(time.Since(deleted), 50*time.Millisecond)
+		assert.Equal(context.Canceled, ctxErr.Load())
+
+		time.Sleep(120 * time.Millisecond)
+		assert.Equal(int32(2), attempts.Load())
+		assert.False(scheduler.Has("cancel"))
+	})
+}
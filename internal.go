@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// internalErrorLimit is the number of internal errors kept, the oldest ones are evicted first.
+const internalErrorLimit = 100
+
+// Sources of the internal errors reported in InternalError.Source.
+const (
+	// InternalErrorAuditWrite is an audit record the StdSchedulerOptions.AuditWriter failed to write.
+	InternalErrorAuditWrite = "audit write"
+
+	// InternalErrorAuditDrop is an audit record dropped because the audit queue was full or closed.
+	InternalErrorAuditDrop = "audit drop"
+
+	// InternalErrorCallbackPanic is a panic recovered from StdSchedulerOptions.OnScheduleChange, OnSLOBreach or
+	// OnSLORecover.
+	InternalErrorCallbackPanic = "callback panic"
+
+	// InternalErrorHandlerPanic is a panic recovered from the error function of a task or of a submitted function.
+	InternalErrorHandlerPanic = "handler panic"
+
+	// InternalErrorWorkerRelease is a worker released while none was acquired.
+	InternalErrorWorkerRelease = "worker release"
+)
+
+// loggerConfigured reports whether a logger has been set, it is replaced in tests.
+var loggerConfigured = logger.Configured
+
+// InternalError is a problem of the scheduler itself rather than of a task, kept when no logger is configured.
+type InternalError struct {
+	// At is when the problem occurred.
+	At time.Time
+
+	// Source is what failed, e.g. InternalErrorAuditWrite.
+	Source string
+
+	// Err describes the problem.
+	Err error
+}
+
+// internalErrors keeps the latest internal errors of a scheduler.
+type internalErrors struct {
+	sync.Mutex
+
+	errs     []InternalError
+	warnOnce sync.Once
+}
+
+// add keeps e, evicting the oldest internal error when the limit is reached.
+func (l *internalErrors) add(e InternalError) {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(l.errs) >= internalErrorLimit {
+		l.errs = append(l.errs[:0], l.errs[1:]...)
+	}
+	l.errs = append(l.errs, e)
+}
+
+// InternalErrors will return the internal errors of the scheduler, oldest first, kept since the last
+// ClearInternalErrors. Internal errors, such as audit records that could not be written, are otherwise only visible
+// through the logger: they are kept only while neither StdSchedulerOptions.Logger nor a default logger is configured,
+// and only the latest 100 of them.
+func (s *StdScheduler) InternalErrors() []InternalError {
+	s.internalErrors.Lock()
+	defer s.internalErrors.Unlock()
+
+	return append([]InternalError(nil), s.internalErrors.errs...)
+}
+
+// ClearInternalErrors will drop the internal errors kept so far, see InternalErrors.
+func (s *StdScheduler) ClearInternalErrors() {
+	s.internalErrors.Lock()
+	defer s.internalErrors.Unlock()
+
+	s.internalErrors.errs = nil
+}
+
+// reportInternalError keeps an internal error when no logger is configured, warning once that they are accumulated.
+func (s *StdScheduler) reportInternalError(source string, err error) {
+	if s.opts.Logger != nil || loggerConfigured() {
+		return
+	}
+
+	s.internalErrors.add(InternalError{At: time.Now(), Source: source, Err: err})
+	s.internalErrors.warnOnce.Do(func() {
+		logger.Warn("no logger is configured, internal errors of the scheduler are accumulated and can be read " +
+			"with InternalErrors")
+	})
+}
@@ -0,0 +1,93 @@
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestInternalErrors(t *testing.T) {
+	t.Run("Verify store failures are kept when no logger is configured", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		defer func(orig func() bool) { loggerConfigured = orig }(loggerConfigured)
+		loggerConfigured = func() bool { return false }
+
+		var b bytes.Buffer
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			errStore := errors.New("disk full")
+			scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: &memoryAuditWriter{err: errStore}})
+
+			for _, id := range []string{"a", "b"} {
+				assert.NoError(scheduler.AddWithID(id, &Task{
+					Interval: time.Millisecond,
+					RunOnce:  true,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(error) {},
+				}))
+			}
+
+			assert.Eventually(func() bool { return len(scheduler.InternalErrors()) == 2 }, time.Second,
+				time.Millisecond)
+			scheduler.Stop()
+
+			errs := scheduler.InternalErrors()
+			assert.Len(errs, 2)
+			for _, e := range errs {
+				assert.Equal(InternalErrorAuditWrite, e.Source)
+				assert.ErrorIs(e.Err, errStore)
+				assert.False(e.At.IsZero())
+			}
+
+			// Kept until cleared
+			assert.Len(scheduler.InternalErrors(), 2)
+			scheduler.ClearInternalErrors()
+			assert.Empty(scheduler.InternalErrors())
+		})
+
+		assert.Equal(1, strings.Count(b.String(), "internal errors of the scheduler are accumulated"))
+	})
+
+	t.Run("Verify internal errors are not kept with a logger", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{Logger: logger.NewSimpleLogger(log.New(&bytes.Buffer{}, "", 0),
+			logger.LevelInfo)})
+		defer scheduler.Stop()
+
+		scheduler.reportInternalError(InternalErrorAuditWrite, errors.New("disk full"))
+		assert.Empty(scheduler.InternalErrors())
+	})
+
+	t.Run("Verify only the latest internal errors are kept", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		defer func(orig func() bool) { loggerConfigured = orig }(loggerConfigured)
+		loggerConfigured = func() bool { return false }
+
+		logger.With(logger.NewSimpleLogger(log.New(&bytes.Buffer{}, "", 0), logger.LevelInfo), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+			defer scheduler.Stop()
+
+			for i := 0; i < internalErrorLimit+5; i++ {
+				scheduler.reportInternalError(InternalErrorAuditDrop, fmt.Errorf("drop %d", i))
+			}
+
+			// Releasing a worker that was not acquired is reported too
+			scheduler.unlockSem()
+
+			errs := scheduler.InternalErrors()
+			assert.Len(errs, internalErrorLimit)
+			assert.EqualError(errs[0].Err, "drop 6")
+			assert.Equal(InternalErrorWorkerRelease, errs[len(errs)-1].Source)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package tasks
+
+import "sync/atomic"
+
+// lane is a pool of workers dedicated to the tasks with its name as Task.Lane.
+type lane struct {
+	sem chan struct{}
+
+	// queued is the number of executions waiting for a worker of the lane.
+	queued atomic.Int64
+}
+
+// LaneStats describes the current load of a lane, see StdSchedulerOptions.Lanes.
+type LaneStats struct {
+	// Workers is the number of workers dedicated to the lane.
+	Workers int
+
+	// Busy is the number of workers currently running an execution.
+	Busy int
+
+	// Queued is the number of executions waiting for a worker of the lane.
+	Queued int
+
+	// Utilization is the ratio of busy workers, between 0 and 1.
+	Utilization float64
+}
+
+// newLanes creates the lanes of StdSchedulerOptions.Lanes. Lanes with a non-positive worker count get one worker.
+func newLanes(workers map[string]int) map[string]*lane {
+	if len(workers) == 0 {
+		return nil
+	}
+
+	lanes := make(map[string]*lane, len(workers))
+	for name, n := range workers {
+		if n < 1 {
+			n = 1
+		}
+		lanes[name] = &lane{sem: make(chan struct{}, n)}
+	}
+
+	return lanes
+}
+
+// Lanes will return the current load of every lane configured with StdSchedulerOptions.Lanes, by lane name.
+func (s *StdScheduler) Lanes() map[string]LaneStats {
+	stats := make(map[string]LaneStats, len(s.lanes))
+	for name, l := range s.lanes {
+		st := LaneStats{
+			Workers: cap(l.sem),
+			Busy:    len(l.sem),
+			Queued:  int(l.queued.Load()),
+		}
+		st.Utilization = float64(st.Busy) / float64(st.Workers)
+		stats[name] = st
+	}
+
+	return stats
+}
+
+// hasLane reports whether name is empty, for the shared pool, or a configured lane.
+func (s *StdScheduler) hasLane(name string) bool {
+	if name == "" {
+		return true
+	}
+
+	_, ok := s.lanes[name]
+
+	return ok
+}
+
+// bypassesWorkers reports whether executions of the task start without waiting for a worker.
+func (s *StdScheduler) bypassesWorkers(t *Task) bool {
+	return t.Lane == "" && t.BypassWorkerLimit
+}
+
+// acquireWorker waits for a worker of the task lane, or of the shared pool when the task has no lane. It returns
+// false when the scheduler stops first.
+func (s *StdScheduler) acquireWorker(t *Task) bool {
+	if t.Lane != "" {
+		l := s.lanes[t.Lane]
+		l.queued.Add(1)
+		defer l.queued.Add(-1)
+
+		select {
+		case l.sem <- struct{}{}:
+			return true
+		case <-s.draining:
+			return false
+		}
+	}
+
+	if t.BypassWorkerLimit {
+		return true
+	}
+
+	return s.lockSem(t.Group)
+}
+
+// releaseWorker releases the worker acquired by acquireWorker.
+func (s *StdScheduler) releaseWorker(t *Task) {
+	if t.Lane != "" {
+		<-s.lanes[t.Lane].sem
+
+		return
+	}
+
+	if !t.BypassWorkerLimit {
+		s.unlockSem()
+	}
+}
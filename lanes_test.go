@@ -0,0 +1,116 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestLanes(t *testing.T) {
+	t.Run("Verify a lane keeps executing while the shared pool is saturated", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			WorkerLimit: 1,
+			Lanes:       map[string]int{"legacy": 1},
+		})
+
+		release := make(chan struct{})
+		var blocked, laneRuns atomic.Int32
+
+		for _, id := range []string{"bulk-1", "bulk-2"} {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: 5 * time.Millisecond,
+				TaskFunc: func() error {
+					blocked.Add(1)
+					<-release
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}))
+		}
+		assert.NoError(scheduler.AddWithID("legacy", &Task{
+			Interval: 10 * time.Millisecond,
+			Lane:     "legacy",
+			TaskFunc: func() error {
+				laneRuns.Add(1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		t.Cleanup(func() {
+			scheduler.Del("bulk-1")
+			scheduler.Del("bulk-2")
+			scheduler.Del("legacy")
+			close(release)
+		})
+
+		assert.Eventually(func() bool { return blocked.Load() == 1 }, time.Second, time.Millisecond)
+
+		start := laneRuns.Load()
+		time.Sleep(100 * time.Millisecond)
+		assert.GreaterOrEqual(laneRuns.Load()-start, int32(5))
+		assert.Equal(int32(1), blocked.Load())
+
+		stats := scheduler.Lanes()
+		assert.Len(stats, 1)
+		assert.Equal(1, stats["legacy"].Workers)
+	})
+
+	t.Run("Verify lane capacity is not shared", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{Lanes: map[string]int{"serial": 1}})
+		defer scheduler.Stop()
+
+		release := make(chan struct{})
+		var running, maxRunning atomic.Int32
+
+		for _, id := range []string{"serial-1", "serial-2", "serial-3"} {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: 5 * time.Millisecond,
+				RunOnce:  true,
+				Lane:     "serial",
+				TaskFunc: func() error {
+					n := running.Add(1)
+					defer running.Add(-1)
+					if n > maxRunning.Load() {
+						maxRunning.Store(n)
+					}
+					<-release
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}))
+		}
+
+		assert.Eventually(func() bool {
+			st := scheduler.Lanes()["serial"]
+			return st.Busy == 1 && st.Queued == 2
+		}, time.Second, time.Millisecond)
+		assert.Equal(LaneStats{Workers: 1, Busy: 1, Queued: 2, Utilization: 1}, scheduler.Lanes()["serial"])
+
+		close(release)
+		assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+		assert.Equal(int32(1), maxRunning.Load())
+		assert.Equal(LaneStats{Workers: 1}, scheduler.Lanes()["serial"])
+	})
+
+	t.Run("Verify unknown lanes are rejected", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{Lanes: map[string]int{"serial": 1}})
+		defer scheduler.Stop()
+
+		err := scheduler.AddWithID("unknown", &Task{
+			Interval: time.Minute,
+			Lane:     "missing",
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		})
+		assert.ErrorIs(err, ErrUnknownLane)
+		assert.False(scheduler.Has("unknown"))
+	})
+}
@@ -4,40 +4,102 @@ import (
 	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 )
 
+// loggerState is the default Logger, owned is set when it was set by a component configured with its own Logger and
+// builtin when it is the Logger the package starts with.
+type loggerState struct {
+	logger  Logger
+	owned   bool
+	builtin bool
+}
+
+// loggerValue holds the default Logger, it is swapped atomically.
 type loggerValue struct {
-	sync.RWMutex
-	logger Logger
+	state atomic.Pointer[loggerState]
 }
 
 func (l *loggerValue) getLogger() Logger {
-	l.RLock()
-	defer l.RUnlock()
-	return l.logger
+	return l.state.Load().logger
 }
 
-func (l *loggerValue) setLogger(new Logger) {
-	l.Lock()
-	defer l.Unlock()
-	l.logger = new
+// swap replaces the default Logger and returns the previous state.
+func (l *loggerValue) swap(new *loggerState) *loggerState {
+	return l.state.Swap(new)
 }
 
-var defaultLogger = loggerValue{
-	logger: NewSimpleLogger(
-		log.New(os.Stdout, "", log.LstdFlags|log.Lshortfile),
-		LevelInfo,
-	),
-}
+var defaultLogger = func() *loggerValue {
+	l := &loggerValue{}
+	l.state.Store(&loggerState{
+		logger: NewSimpleLogger(
+			log.New(os.Stdout, "", log.LstdFlags|log.Lshortfile),
+			LevelInfo,
+		),
+		builtin: true,
+	})
+
+	return l
+}()
 
-// Default returns the default Logger.
+// scopeMu serializes With calls.
+var scopeMu sync.Mutex
+
+// Default returns the default Logger. It is safe to call concurrently with SetDefault, which swaps the default Logger
+// atomically: every call returns either the previous or the new Logger, never a mix of both.
 func Default() Logger {
 	return defaultLogger.getLogger()
 }
 
-// SetDefault makes l the default Logger.
+// SetDefault makes l the default Logger. When the default Logger was set by a StdScheduler configured with its own
+// Logger, SetDefault still replaces it but logs a warning through l, since the scheduler logs then silently go to l.
 func SetDefault(l Logger) {
-	defaultLogger.setLogger(l)
+	prev := defaultLogger.swap(&loggerState{logger: l})
+	if prev.owned && prev.logger != l {
+		l.Warn("default logger set by a scheduler configured with its own logger has been replaced")
+	}
+}
+
+// SetDefaultOwned makes l the default Logger on behalf of a component configured with its own Logger, such as a
+// StdScheduler with StdSchedulerOptions.Logger. Replacing it later with SetDefault or SetDefaultOwned logs a warning.
+func SetDefaultOwned(l Logger) {
+	prev := defaultLogger.swap(&loggerState{logger: l, owned: true})
+	if prev.owned && prev.logger != l {
+		l.Warn("default logger set by a scheduler configured with its own logger has been replaced by another one")
+	}
+}
+
+// With makes l the default Logger for the duration of fn, then restores the previous one, without any warning. It is
+// meant for tests capturing logs: With calls are serialized, so that parallel tests using it do not capture each
+// other's logs. Calling With from fn deadlocks.
+//
+//	logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelDebug), func() {
+//		// Run the code under test
+//	})
+func With(l Logger, fn func()) {
+	scopeMu.Lock()
+	defer scopeMu.Unlock()
+
+	prev := defaultLogger.swap(&loggerState{logger: l})
+	defer defaultLogger.swap(prev)
+
+	fn()
+}
+
+// Configured reports whether the default Logger has been replaced, with SetDefault, SetDefaultOwned or With, since the
+// package started with its builtin Logger writing to stdout.
+func Configured() bool {
+	return !defaultLogger.state.Load().builtin
+}
+
+// Enabled reports whether the default Logger handles records at the given level. Loggers that do not implement
+// Enabled(Level) bool are assumed to handle every level.
+func Enabled(level Level) bool {
+	if l, ok := Default().(interface{ Enabled(Level) bool }); ok {
+		return l.Enabled(level)
+	}
+
+	return true
 }
 
 // Debug logs at LevelDebug.
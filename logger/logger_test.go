@@ -3,8 +3,10 @@ package logger_test
 import (
 	"bytes"
 	"log"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	assertions "github.com/stretchr/testify/assert"
 
@@ -105,6 +107,63 @@ func TestLogFormat(t *testing.T) {
 	assert.Contains(b.String(), "a, 1, true, {}")
 }
 
+func TestTimestamps(t *testing.T) {
+	t.Run("Verify microsecond timestamps in UTC", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		l := logger.NewSimpleLoggerWithOptions(log.New(&b, "", 0), logger.LevelDebug, logger.SimpleLoggerOptions{
+			Timestamp:    true,
+			Microseconds: true,
+			UTC:          true,
+		})
+
+		before := time.Now().Truncate(time.Microsecond)
+		l.Infof("Infof: %d", 1)
+		l.Warn("Warn", 2)
+		after := time.Now()
+
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		assert.Len(lines, 2)
+		assert.Regexp(`^INFO \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}Z Infof: 1$`, lines[0])
+		assert.Regexp(`^WARN \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}Z Warn 2$`, lines[1])
+
+		ts, err := time.Parse(logger.TimeFormatMicro, strings.Fields(lines[0])[1])
+		assert.NoError(err)
+		assert.Equal(time.UTC, ts.Location())
+		assert.False(ts.Before(before))
+		assert.False(ts.After(after))
+	})
+
+	t.Run("Verify second timestamps in local time", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		l := logger.NewSimpleLoggerWithOptions(log.New(&b, "", 0), logger.LevelDebug, logger.SimpleLoggerOptions{
+			Timestamp: true,
+		})
+
+		l.Debug("Debug")
+		fields := strings.Fields(b.String())
+		assert.Equal([]string{"DEBUG", fields[1], "Debug"}, fields)
+
+		ts, err := time.Parse(logger.TimeFormat, fields[1])
+		assert.NoError(err)
+		_, offset := time.Now().Zone()
+		_, tsOffset := ts.Zone()
+		assert.Equal(offset, tsOffset)
+		assert.NotContains(fields[1], ".")
+	})
+
+	t.Run("Verify no timestamp by default", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelDebug).Errorf("Errorf: %s", "a")
+		assert.Equal("ERROR Errorf: a\n", b.String())
+	})
+}
+
 func setLogger(wg *sync.WaitGroup, l *logger.SimpleLogger) {
 	defer wg.Done()
 	logger.SetDefault(l)
@@ -147,3 +206,89 @@ func (l *countingLogger) Error(...any) {
 func (l *countingLogger) Errorf(string, ...any) {
 	l.Count++
 }
+
+func TestEnabled(t *testing.T) {
+	assert := assertions.New(t)
+
+	var b bytes.Buffer
+	logger.SetDefault(logger.NewSimpleLogger(log.New(&b, "", log.LstdFlags), logger.LevelWarn))
+
+	assert.False(logger.Enabled(logger.LevelDebug))
+	assert.False(logger.Enabled(logger.LevelInfo))
+	assert.True(logger.Enabled(logger.LevelWarn))
+	assert.True(logger.Enabled(logger.LevelError))
+
+	// Loggers without Enabled handle every level
+	logger.SetDefault(&countingLogger{})
+	assert.True(logger.Enabled(logger.LevelDebug))
+}
+
+func TestWith(t *testing.T) {
+	assert := assertions.New(t)
+
+	previous := &countingLogger{}
+	logger.SetDefault(previous)
+
+	scoped := &countingLogger{}
+	logger.With(scoped, func() {
+		assert.Same(scoped, logger.Default())
+		logger.Info("info")
+	})
+
+	assert.Same(previous, logger.Default())
+	assert.Equal(1, scoped.Count)
+	assert.Zero(previous.Count)
+
+	// Scopes are serialized, each one only captures its own logs
+	var wg sync.WaitGroup
+	counts := make([]*countingLogger, 8)
+	for i := range counts {
+		counts[i] = &countingLogger{}
+
+		wg.Add(1)
+		go func(l *countingLogger) {
+			defer wg.Done()
+
+			logger.With(l, func() {
+				for j := 0; j < 10; j++ {
+					logger.Info("info")
+				}
+			})
+		}(counts[i])
+	}
+	wg.Wait()
+
+	for _, l := range counts {
+		assert.Equal(10, l.Count)
+	}
+}
+
+func TestSetDefaultOwned(t *testing.T) {
+	assert := assertions.New(t)
+
+	owned := &countingLogger{}
+	logger.SetDefaultOwned(owned)
+
+	// Scopes do not warn
+	logger.With(&countingLogger{}, func() {})
+	assert.Zero(owned.Count)
+	assert.Same(owned, logger.Default())
+
+	// Replacing an owned logger warns through the new one
+	replacing := &countingLogger{}
+	logger.SetDefault(replacing)
+	assert.Same(replacing, logger.Default())
+	assert.Equal(1, replacing.Count)
+
+	// Replacing a logger that is not owned does not
+	other := &countingLogger{}
+	logger.SetDefault(other)
+	assert.Zero(other.Count)
+}
+
+func TestConfigured(t *testing.T) {
+	assert := assertions.New(t)
+
+	logger.SetDefault(&countingLogger{})
+	assert.True(logger.Configured())
+}
@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"fmt"
 	"log"
+	"time"
 )
 
 // SimpleLogger prefixes.
@@ -12,95 +14,157 @@ const (
 	ErrorPrefix = "ERROR "
 )
 
+// Timestamp layouts of SimpleLogger.
+const (
+	// TimeFormat has a second precision, like time.RFC3339.
+	TimeFormat = time.RFC3339
+	// TimeFormatMicro has a microsecond precision, it is used by the scheduler for the times it measured.
+	TimeFormatMicro = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// SimpleLoggerOptions controls the timestamps SimpleLogger writes in front of every message. The timestamp is part of
+// the message, so that it does not depend on the flags of the log.Logger, which should then be created without
+// log.LstdFlags to avoid logging two timestamps.
+type SimpleLoggerOptions struct {
+	// Timestamp writes the wall-clock time in front of every message.
+	Timestamp bool
+
+	// Microseconds writes the timestamp with a microsecond precision instead of a second one.
+	Microseconds bool
+
+	// UTC writes the timestamp in UTC instead of the local time.
+	UTC bool
+}
+
 // SimpleLogger implements the logger.Logger interface.
 type SimpleLogger struct {
 	logger *log.Logger
 	level  Level
+	opts   SimpleLoggerOptions
 }
 
 var _ Logger = (*SimpleLogger)(nil)
 
 // NewSimpleLogger returns a new SimpleLogger.
 func NewSimpleLogger(logger *log.Logger, level Level) *SimpleLogger {
+	return NewSimpleLoggerWithOptions(logger, level, SimpleLoggerOptions{})
+}
+
+// NewSimpleLoggerWithOptions returns a new SimpleLogger writing timestamps as configured by opts.
+//
+//	logger.NewSimpleLoggerWithOptions(log.New(os.Stderr, "", 0), logger.LevelDebug, logger.SimpleLoggerOptions{
+//		Timestamp:    true,
+//		Microseconds: true,
+//		UTC:          true,
+//	})
+func NewSimpleLoggerWithOptions(logger *log.Logger, level Level, opts SimpleLoggerOptions) *SimpleLogger {
 	return &SimpleLogger{
 		logger: logger,
 		level:  level,
+		opts:   opts,
+	}
+}
+
+// println writes args in the manner of fmt.Println, after the timestamp if any.
+func (l *SimpleLogger) println(prefix string, args ...any) {
+	l.logger.SetPrefix(prefix)
+	if !l.opts.Timestamp {
+		l.logger.Println(args...)
+
+		return
+	}
+	l.logger.Print(l.timestamp() + " " + fmt.Sprintln(args...))
+}
+
+// printf writes args in the manner of fmt.Printf, after the timestamp if any.
+func (l *SimpleLogger) printf(prefix, format string, args ...any) {
+	l.logger.SetPrefix(prefix)
+	if !l.opts.Timestamp {
+		l.logger.Printf(format, args...)
+
+		return
+	}
+	l.logger.Print(l.timestamp() + " " + fmt.Sprintf(format, args...))
+}
+
+// timestamp formats the current time as configured.
+func (l *SimpleLogger) timestamp() string {
+	now := time.Now()
+	if l.opts.UTC {
+		now = now.UTC()
 	}
+	if l.opts.Microseconds {
+		return now.Format(TimeFormatMicro)
+	}
+
+	return now.Format(TimeFormat)
 }
 
 // Debug logs at LevelDebug.
 // Arguments are handled in the manner of fmt.Println.
 func (l *SimpleLogger) Debug(args ...any) {
-	if l.enabled(LevelDebug) {
-		l.logger.SetPrefix(DebugPrefix)
-		l.logger.Println(args...)
+	if l.Enabled(LevelDebug) {
+		l.println(DebugPrefix, args...)
 	}
 }
 
 // Debugf logs at LevelDebug.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *SimpleLogger) Debugf(format string, args ...any) {
-	if l.enabled(LevelDebug) {
-		l.logger.SetPrefix(DebugPrefix)
-		l.logger.Printf(format, args...)
+	if l.Enabled(LevelDebug) {
+		l.printf(DebugPrefix, format, args...)
 	}
 }
 
 // Info logs at LevelInfo.
 // Arguments are handled in the manner of fmt.Println.
 func (l *SimpleLogger) Info(args ...any) {
-	if l.enabled(LevelInfo) {
-		l.logger.SetPrefix(InfoPrefix)
-		l.logger.Println(args...)
+	if l.Enabled(LevelInfo) {
+		l.println(InfoPrefix, args...)
 	}
 }
 
 // Infof logs at LevelInfo.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *SimpleLogger) Infof(format string, args ...any) {
-	if l.enabled(LevelInfo) {
-		l.logger.SetPrefix(InfoPrefix)
-		l.logger.Printf(format, args...)
+	if l.Enabled(LevelInfo) {
+		l.printf(InfoPrefix, format, args...)
 	}
 }
 
 // Warn logs at LevelWarn.
 // Arguments are handled in the manner of fmt.Println.
 func (l *SimpleLogger) Warn(args ...any) {
-	if l.enabled(LevelWarn) {
-		l.logger.SetPrefix(WarnPrefix)
-		l.logger.Println(args...)
+	if l.Enabled(LevelWarn) {
+		l.println(WarnPrefix, args...)
 	}
 }
 
 // Warnf logs at LevelWarn.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *SimpleLogger) Warnf(format string, args ...any) {
-	if l.enabled(LevelWarn) {
-		l.logger.SetPrefix(WarnPrefix)
-		l.logger.Printf(format, args...)
+	if l.Enabled(LevelWarn) {
+		l.printf(WarnPrefix, format, args...)
 	}
 }
 
 // Error logs at LevelError.
 // Arguments are handled in the manner of fmt.Println.
 func (l *SimpleLogger) Error(args ...any) {
-	if l.enabled(LevelError) {
-		l.logger.SetPrefix(ErrorPrefix)
-		l.logger.Println(args...)
+	if l.Enabled(LevelError) {
+		l.println(ErrorPrefix, args...)
 	}
 }
 
 // Errorf logs at LevelError.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *SimpleLogger) Errorf(format string, args ...any) {
-	if l.enabled(LevelError) {
-		l.logger.SetPrefix(ErrorPrefix)
-		l.logger.Printf(format, args...)
+	if l.Enabled(LevelError) {
+		l.printf(ErrorPrefix, format, args...)
 	}
 }
 
-// enabled reports whether the logger handles records at the given level.
-func (l *SimpleLogger) enabled(level Level) bool {
+// Enabled reports whether the logger handles records at the given level.
+func (l *SimpleLogger) Enabled(level Level) bool {
 	return level >= l.level
 }
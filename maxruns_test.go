@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestMaxRuns(t *testing.T) {
+	t.Run("Verify the task is removed after its last run", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs int64
+		assert.NoError(scheduler.AddWithID("reminder", &Task{
+			Interval: 10 * time.Millisecond,
+			MaxRuns:  3,
+			TaskFunc: func() error {
+				atomic.AddInt64(&runs, 1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		task, err := scheduler.Lookup("reminder")
+		assert.NoError(err)
+		assert.Equal(3, task.RunsRemaining())
+
+		assert.Eventually(func() bool { return !scheduler.Has("reminder") }, time.Second, time.Millisecond)
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(int64(3), atomic.LoadInt64(&runs))
+	})
+
+	t.Run("Verify failed runs count", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs int64
+		assert.NoError(scheduler.AddWithID("failing", &Task{
+			Interval: 10 * time.Millisecond,
+			MaxRuns:  2,
+			TaskFunc: func() error {
+				atomic.AddInt64(&runs, 1)
+				return errors.New("failed")
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("failing") }, time.Second, time.Millisecond)
+		assert.Equal(int64(2), atomic.LoadInt64(&runs))
+	})
+
+	t.Run("Verify the remaining runs are exposed", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("counted", &Task{
+			Interval: 10 * time.Millisecond,
+			MaxRuns:  100,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		assert.Eventually(func() bool {
+			task, err := scheduler.Lookup("counted")
+			return err == nil && task.RunsRemaining() <= 98
+		}, time.Second, time.Millisecond)
+
+		assert.Equal(-1, (&Task{}).RunsRemaining())
+	})
+
+	t.Run("Verify MaxRuns cannot be combined with RunOnce", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		err := scheduler.AddWithID("invalid", &Task{
+			Interval: time.Second,
+			RunOnce:  true,
+			MaxRuns:  2,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		})
+		assert.ErrorIs(err, ErrMaxRunsWithRunOnce)
+	})
+}
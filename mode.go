@@ -0,0 +1,49 @@
+package tasks
+
+import "time"
+
+// ScheduleMode is when the interval of a recurring task starts counting, see Task.Mode.
+type ScheduleMode int
+
+const (
+	// FixedRate starts the interval when an execution starts, executions overlap when they last longer than the
+	// interval. It is the default.
+	FixedRate ScheduleMode = iota
+	// FixedDelay starts the interval when an execution returns, leaving a quiet gap of Interval between executions.
+	FixedDelay
+)
+
+// String returns the name of the mode.
+func (m ScheduleMode) String() string {
+	switch m {
+	case FixedRate:
+		return "fixed rate"
+	case FixedDelay:
+		return "fixed delay"
+	default:
+		return "unknown"
+	}
+}
+
+// armAfterRun arms the next fire of a FixedDelay task once an execution returned. Nothing is armed when a retry or a
+// reschedule on error already is, both count from the end of the execution as well.
+func (s *StdScheduler) armAfterRun(t *Task, taskCtx TaskContext) {
+	if t.Mode != FixedDelay || t.RunOnce || taskCtx.finalRun {
+		return
+	}
+
+	var (
+		next  time.Time
+		armed bool
+	)
+	t.safeOps(func() {
+		if t.retryPending {
+			return
+		}
+
+		next, armed = s.resetTimer(t, t.interval(), DecisionTimerArmed, TriggerInterval)
+	})
+	if armed {
+		s.notifyScheduleChange(t.id, next, TriggerInterval.String())
+	}
+}
@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestScheduleMode(t *testing.T) {
+	t.Run("Verify FixedRate executions overlap", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var probe concurrencyProbe
+		assert.NoError(scheduler.AddWithID("slow", &Task{
+			Interval: 200 * time.Millisecond,
+			TaskFunc: func() error {
+				probe.run(500 * time.Millisecond)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.peak) >= 2 }, 2*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Verify FixedDelay executions do not overlap", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			mu     sync.Mutex
+			starts []time.Time
+			ends   []time.Time
+			probe  concurrencyProbe
+		)
+		assert.NoError(scheduler.AddWithID("slow", &Task{
+			Interval: 200 * time.Millisecond,
+			Mode:     FixedDelay,
+			TaskFunc: func() error {
+				mu.Lock()
+				starts = append(starts, time.Now())
+				mu.Unlock()
+
+				probe.run(500 * time.Millisecond)
+
+				mu.Lock()
+				ends = append(ends, time.Now())
+				mu.Unlock()
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.runs) >= 3 }, 3*time.Second, 10*time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&probe.peak))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Every execution starts an interval after the previous one returned
+		for i := 1; i < 3; i++ {
+			assert.GreaterOrEqual(starts[i].Sub(ends[i-1]), 200*time.Millisecond)
+		}
+	})
+
+	t.Run("Verify FixedDelay reschedules on error do not overlap", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errFailed := errors.New("failed")
+
+		var probe concurrencyProbe
+		task := &Task{
+			Interval: 200 * time.Millisecond,
+			Mode:     FixedDelay,
+			TaskFunc: func() error {
+				probe.run(100 * time.Millisecond)
+				if atomic.LoadInt64(&probe.runs)%2 == 1 {
+					return errFailed
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}
+		assert.NoError(task.WithRescheduleOnError(errFailed, 10*time.Millisecond, 10))
+		assert.NoError(scheduler.AddWithID("failing", task))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&probe.runs) >= 4 }, 3*time.Second, 10*time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&probe.peak))
+	})
+}
+
+func TestScheduleModeString(t *testing.T) {
+	assert := assertions.New(t)
+
+	assert.Equal("fixed rate", FixedRate.String())
+	assert.Equal("fixed delay", FixedDelay.String())
+	assert.Equal("unknown", ScheduleMode(42).String())
+}
@@ -0,0 +1,75 @@
+package tasks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// NeverExecutedTask is a scheduled task that has not executed yet, see NeverExecuted.
+type NeverExecutedTask struct {
+	// ID is the ID of the task.
+	ID string
+
+	// RunOnce is set for RunOnce tasks, whose work is lost if the scheduler stops now.
+	RunOnce bool
+
+	// AddedAt is when the task was added.
+	AddedAt time.Time
+
+	// FirstFire is when the task was scheduled to execute for the first time.
+	FirstFire time.Time
+}
+
+// NeverExecuted will return the scheduled tasks that have not executed even once, sorted by ID. Stop reports them,
+// tasks added shortly before the scheduler stops usually mean their intervals are longer than the lifetime of the
+// scheduler.
+func (s *StdScheduler) NeverExecuted() []NeverExecutedTask {
+	s.RLock()
+	defer s.RUnlock()
+
+	var tt []NeverExecutedTask
+	for id, t := range s.tasks {
+		t.safeOps(func() {
+			if t.runSequence > 0 {
+				return
+			}
+
+			tt = append(tt, NeverExecutedTask{ID: id, RunOnce: t.RunOnce, AddedAt: t.addedAt, FirstFire: t.firstFire})
+		})
+	}
+
+	sort.Slice(tt, func(i, j int) bool { return tt[i].ID < tt[j].ID })
+
+	return tt
+}
+
+// reportNeverExecuted logs the tasks that never executed when the scheduler stops, at Warn level for RunOnce tasks
+// whose work is lost and at Debug level for recurring ones.
+func (s *StdScheduler) reportNeverExecuted() {
+	tt := s.NeverExecuted()
+	if len(tt) == 0 {
+		return
+	}
+
+	uptime := s.Uptime().Round(time.Millisecond)
+	for _, t := range tt {
+		if t.RunOnce {
+			logger.Warnf("task (id: %s) never executed before the scheduler stopped: first fire was due %s after "+
+				"the scheduler started, it ran for %s", t.ID, s.sinceStart(t.FirstFire), uptime)
+
+			continue
+		}
+
+		if logger.Enabled(logger.LevelDebug) {
+			logger.Debugf("task (id: %s) never executed before the scheduler stopped: first fire was due %s after "+
+				"the scheduler started, it ran for %s", t.ID, s.sinceStart(t.FirstFire), uptime)
+		}
+	}
+}
+
+// sinceStart returns how long after the scheduler was created t is, rounded to the millisecond.
+func (s *StdScheduler) sinceStart(t time.Time) time.Duration {
+	return t.Sub(s.startedAt).Round(time.Millisecond)
+}
@@ -0,0 +1,74 @@
+package tasks
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestNeverExecuted(t *testing.T) {
+	t.Run("Verify tasks that never executed are reported on Stop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelDebug), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{})
+
+			for id, runOnce := range map[string]bool{"recurring": false, "once": true} {
+				assert.NoError(scheduler.AddWithID(id, &Task{
+					Interval: 30 * time.Second,
+					RunOnce:  runOnce,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(error) {},
+				}))
+			}
+			assert.NoError(scheduler.AddWithID("executed", &Task{
+				Interval: 5 * time.Millisecond,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+			assert.Eventually(func() bool {
+				task, err := scheduler.Lookup("executed")
+				return err == nil && task.RunSequence() > 0
+			}, time.Second, time.Millisecond)
+
+			tt := scheduler.NeverExecuted()
+			if assert.Len(tt, 2) {
+				assert.Equal("once", tt[0].ID)
+				assert.True(tt[0].RunOnce)
+				assert.Equal("recurring", tt[1].ID)
+				assert.False(tt[1].RunOnce)
+				assert.WithinDuration(tt[1].AddedAt.Add(30*time.Second), tt[1].FirstFire, 10*time.Millisecond)
+			}
+
+			scheduler.Stop()
+		})
+
+		assert.Contains(b.String(), "WARN")
+		assert.Contains(b.String(), "task (id: once) never executed before the scheduler stopped: first fire was due 30")
+		assert.Contains(b.String(), "task (id: recurring) never executed before the scheduler stopped")
+		assert.NotContains(b.String(), "task (id: executed) never executed")
+	})
+
+	t.Run("Verify recurring tasks are only reported at Debug level", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{})
+			assert.NoError(scheduler.AddWithID("recurring", &Task{
+				Interval: 30 * time.Second,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+			scheduler.Stop()
+		})
+
+		assert.NotContains(b.String(), "never executed")
+	})
+}
@@ -0,0 +1,171 @@
+package tasks
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// dispatchEntry is a RunOnce task waiting in the ordered dispatch queue.
+type dispatchEntry struct {
+	t  *Task
+	at time.Time
+
+	// order is the position of the entry in the push order, it breaks ties between equal fire times.
+	order uint64
+
+	// seq is the dispatch sequence of the task when the entry was pushed, entries of disarmed tasks are stale.
+	seq uint64
+}
+
+// dispatchQueue is a heap of entries ordered by fire time, then push order.
+type dispatchQueue []dispatchEntry
+
+func (q dispatchQueue) Len() int { return len(q) }
+
+func (q dispatchQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+
+	return q[i].order < q[j].order
+}
+
+func (q dispatchQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *dispatchQueue) Push(x any) { *q = append(*q, x.(dispatchEntry)) }
+
+func (q *dispatchQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return e
+}
+
+// dispatcher fires the RunOnce tasks of a scheduler with StdSchedulerOptions.OrderedRunOnce from a single goroutine,
+// in fire time then push order, instead of from independent timers.
+type dispatcher struct {
+	mu      sync.Mutex
+	queue   dispatchQueue
+	order   uint64
+	started bool
+
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+}
+
+// push queues the task to fire at at, starting the dispatch goroutine on first use.
+func (d *dispatcher) push(s *StdScheduler, t *Task, at time.Time, seq uint64) {
+	d.mu.Lock()
+	d.order++
+	heap.Push(&d.queue, dispatchEntry{t: t, at: at, order: d.order, seq: seq})
+	if !d.started {
+		d.started = true
+		go d.run(s)
+	}
+	d.mu.Unlock()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run fires the queued tasks as they become due. Executions are started one at a time, so a task waits for a worker
+// before the tasks queued after it.
+func (d *dispatcher) run(s *StdScheduler) {
+	for {
+		d.mu.Lock()
+		if len(d.queue) == 0 {
+			d.mu.Unlock()
+
+			select {
+			case <-d.wake:
+				continue
+			case <-d.stop:
+				return
+			}
+		}
+
+		if wait := time.Until(d.queue[0].at); wait > 0 {
+			d.mu.Unlock()
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-d.wake:
+			case <-d.stop:
+				timer.Stop()
+				return
+			}
+			timer.Stop()
+
+			continue
+		}
+
+		e := heap.Pop(&d.queue).(dispatchEntry)
+		d.mu.Unlock()
+
+		var stale bool
+		e.t.safeOps(func() {
+			stale = e.t.dispatchSeq != e.seq
+		})
+		if !stale {
+			s.execTask(e.t)
+		}
+	}
+}
+
+// close stops the dispatch goroutine, queued tasks are dropped.
+func (d *dispatcher) close() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+// dispatchesInOrder reports whether the fire of the task for the trigger goes through the ordered dispatch queue.
+// Retries and reschedules on error keep their own timers.
+func (s *StdScheduler) dispatchesInOrder(t *Task, trigger Trigger) bool {
+	return s.dispatcher != nil && t.RunOnce && (trigger == TriggerInterval || trigger == TriggerStartAfter)
+}
+
+// dispatchAt queues the task to fire at at in the ordered dispatch queue, replacing any earlier entry of the task.
+// The task lock must be held.
+func (s *StdScheduler) dispatchAt(t *Task, at time.Time, decision Decision, trigger Trigger) (time.Time, bool) {
+	if t.state == TaskStateRemoved {
+		return time.Time{}, false
+	}
+
+	t.dispatchSeq++
+	s.dispatcher.push(s, t, at, t.dispatchSeq)
+
+	t.nextFire = at
+	t.trigger = trigger
+	t.trace.record(decision, time.Until(at), trigger.String())
+
+	return t.nextFire, true
+}
+
+// armed reports whether the task has been armed, with a timer or in the ordered dispatch queue. The task lock must
+// be held.
+func (t *Task) armed() bool {
+	return t.timer != nil || t.dispatchSeq > 0
+}
+
+// disarm stops the pending fire of the task, whether it waits on its timer or in the ordered dispatch queue. The task
+// lock must be held.
+func (t *Task) disarm() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if t.dispatchSeq > 0 {
+		t.dispatchSeq++
+	}
+}
@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestOrderedRunOnce(t *testing.T) {
+	const n = 1000
+
+	addTasks := func(t *testing.T, scheduler *StdScheduler, started *[]string, mu *sync.Mutex) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("job-%04d", i)
+			assertions.NoError(t, scheduler.AddWithID(ids[i], &Task{
+				RunOnce:    true,
+				StartAfter: time.Now(),
+				FuncWithID: func(id string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					*started = append(*started, id)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}))
+		}
+
+		return ids
+	}
+
+	t.Run("Verify RunOnce tasks start in the order they were added", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1, OrderedRunOnce: true})
+		defer scheduler.Stop()
+
+		var (
+			mu      sync.Mutex
+			started []string
+		)
+		ids := addTasks(t, scheduler, &started, &mu)
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(started) == n
+		}, 5*time.Second, time.Millisecond)
+		assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(ids, started)
+	})
+
+	t.Run("Verify the default mode runs every task without an order guarantee", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		// Tasks are fired by independent timers, they may start in any order
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+		defer scheduler.Stop()
+
+		var (
+			mu      sync.Mutex
+			started []string
+		)
+		ids := addTasks(t, scheduler, &started, &mu)
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(started) == n
+		}, 5*time.Second, time.Millisecond)
+		assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.ElementsMatch(ids, started)
+	})
+
+	t.Run("Verify deleted and snoozed tasks leave the queue", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1, OrderedRunOnce: true})
+		defer scheduler.Stop()
+
+		var (
+			mu      sync.Mutex
+			started []string
+		)
+		for _, id := range []string{"first", "deleted", "snoozed", "last"} {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				RunOnce:    true,
+				StartAfter: time.Now().Add(20 * time.Millisecond),
+				FuncWithID: func(id string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					started = append(started, id)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}))
+		}
+
+		scheduler.Del("deleted")
+		assert.NoError(scheduler.Snooze("snoozed", time.Now().Add(60*time.Millisecond)))
+
+		assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal([]string{"first", "last", "snoozed"}, started)
+	})
+}
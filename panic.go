@@ -0,0 +1,115 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// ErrTaskPanicked is wrapped by the PanicError returned for an execution whose task function panicked.
+var ErrTaskPanicked = errors.New("task panicked")
+
+// PanicError is the error of an execution whose task function panicked. It goes through the error functions,
+// retries and reschedules on error like any other failure, see StdSchedulerOptions.DisablePanicRecovery.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrTaskPanicked.Error(), e.Value)
+}
+
+// Unwrap returns ErrTaskPanicked, and the value passed to panic when it is an error.
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrTaskPanicked, err}
+	}
+
+	return []error{ErrTaskPanicked}
+}
+
+// callFunc calls the task function with the highest precedence. A panic is recovered and returned as a PanicError,
+// unless StdSchedulerOptions.DisablePanicRecovery is set.
+func (s *StdScheduler) callFunc(t *Task, taskCtx TaskContext) (err error) {
+	if !s.opts.DisablePanicRecovery {
+		defer func() {
+			if r := recover(); r != nil {
+				panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+				logger.Errorf("task (id: %s, run: %s) panicked: %v\n%s", t.id, taskCtx.runID, r, panicErr.Stack)
+				err = panicErr
+			}
+		}()
+	}
+
+	switch {
+	case t.FuncWithTaskContext != nil:
+		return t.FuncWithTaskContext(taskCtx)
+	case t.FuncWithID != nil:
+		return t.FuncWithID(t.id)
+	default:
+		return t.TaskFunc()
+	}
+}
+
+// callSubmitted calls a function passed to Submit, recovering a panic like callFunc.
+func (s *StdScheduler) callSubmitted(f func() error) (err error) {
+	if !s.opts.DisablePanicRecovery {
+		defer func() {
+			if r := recover(); r != nil {
+				panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+				logger.Errorf("submitted task panicked: %v\n%s", r, panicErr.Stack)
+				err = panicErr
+			}
+		}()
+	}
+
+	return f()
+}
+
+// deliverError calls the error function of the task with err. A panic in the error function is recovered, logged and
+// reported to StdSchedulerOptions.OnHandlerPanic, so that a faulty handler does not crash the process.
+func (s *StdScheduler) deliverError(t *Task, taskCtx TaskContext, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.handlerPanicked(t.id, r)
+		}
+	}()
+
+	t.callErrFunc(taskCtx, err)
+}
+
+// handlerPanicked reports a panic recovered from an error function.
+func (s *StdScheduler) handlerPanicked(id string, r any) {
+	logger.Errorf("task (id: %s) error function panicked: %v\n%s", id, r, debug.Stack())
+	s.reportInternalError(InternalErrorHandlerPanic, fmt.Errorf("task (id: %s) error function panicked: %v", id, r))
+
+	if s.opts.OnHandlerPanic == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("task (id: %s) handler panic callback panicked: %v", id, r)
+		}
+	}()
+
+	s.opts.OnHandlerPanic(id, r)
+}
+
+// deliverSubmittedError calls the error function of a submitted function, recovering a panic like deliverError.
+func (s *StdScheduler) deliverSubmittedError(errF func(error), err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.handlerPanicked("", r)
+		}
+	}()
+
+	errF(err)
+}
@@ -0,0 +1,185 @@
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	t.Run("Verify a panic is delivered to the error functions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var (
+			runs int64
+			mu   sync.Mutex
+			errs []error
+		)
+		assert.NoError(scheduler.AddWithID("panicking", &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				if atomic.AddInt64(&runs, 1) == 1 {
+					panic("boom")
+				}
+				return nil
+			},
+			ErrFunc: func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, err)
+			},
+		}))
+
+		// The following intervals still fire
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 3 }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if assert.Len(errs, 1) {
+			assert.ErrorIs(errs[0], ErrTaskPanicked)
+			assert.Equal("task panicked: boom", errs[0].Error())
+
+			var panicErr *PanicError
+			assert.True(errors.As(errs[0], &panicErr))
+			assert.Equal("boom", panicErr.Value)
+			assert.Contains(string(panicErr.Stack), "panic_test.go")
+		}
+	})
+
+	t.Run("Verify a panic is retried", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs int64
+		done := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("retried", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Millisecond,
+			FuncWithTaskContext: func(TaskContext) error {
+				if atomic.AddInt64(&runs, 1) == 1 {
+					panic(io.ErrUnexpectedEOF)
+				}
+				close(done)
+				return nil
+			},
+			ErrFunc: func(err error) {
+				// The error passed to panic is wrapped too
+				assert.ErrorIs(err, io.ErrUnexpectedEOF)
+			},
+		}))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the panicking execution was not retried")
+		}
+	})
+
+	t.Run("Verify submitted functions are recovered", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errCh := make(chan error, 1)
+		assert.NoError(scheduler.Submit(func() error { panic("boom") }, func(err error) { errCh <- err }))
+
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(err, ErrTaskPanicked)
+		case <-time.After(time.Second):
+			t.Fatal("the panic was not delivered")
+		}
+	})
+
+	t.Run("Verify recovery can be disabled", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{DisablePanicRecovery: true})
+		defer scheduler.Stop()
+
+		task := &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { panic("boom") },
+			ErrFunc:  func(error) {},
+		}
+		assert.PanicsWithValue("boom", func() { _ = scheduler.callFunc(task, TaskContext{}) })
+	})
+
+	t.Run("Verify a panicking error function does not stop the scheduler", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		type handlerPanic struct {
+			id        string
+			recovered any
+		}
+		panics := make(chan handlerPanic, 10)
+
+		var (
+			b    bytes.Buffer
+			runs int64
+		)
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			scheduler := NewStdScheduler(StdSchedulerOptions{
+				OnHandlerPanic: func(id string, recovered any) { panics <- handlerPanic{id, recovered} },
+			})
+			defer scheduler.Stop()
+
+			assert.NoError(scheduler.AddWithID("failing", &Task{
+				Interval: 5 * time.Millisecond,
+				TaskFunc: func() error {
+					atomic.AddInt64(&runs, 1)
+					return errors.New("failed")
+				},
+				ErrFuncWithTaskContext: func(TaskContext, error) { panic("handler boom") },
+			}))
+
+			select {
+			case p := <-panics:
+				assert.Equal("failing", p.id)
+				assert.Equal("handler boom", p.recovered)
+			case <-time.After(time.Second):
+				t.Fatal("the handler panic was not reported")
+			}
+
+			// The task keeps firing after its error function panicked
+			n := atomic.LoadInt64(&runs)
+			assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= n+2 }, time.Second, time.Millisecond)
+
+			// So do submitted functions, reported without an ID
+			assert.NoError(scheduler.Submit(func() error { return errors.New("failed") }, func(error) { panic("submit boom") }))
+			assert.Eventually(func() bool {
+				for {
+					select {
+					case p := <-panics:
+						if p.id == "" && p.recovered == "submit boom" {
+							return true
+						}
+					default:
+						return false
+					}
+				}
+			}, time.Second, time.Millisecond)
+
+			scheduler.Del("failing")
+		})
+
+		assert.Contains(b.String(), "task (id: failing) error function panicked: handler boom")
+	})
+}
@@ -0,0 +1,118 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// Reasons reported to StdSchedulerOptions.OnScheduleChange when the scheduler is paused and resumed.
+const (
+	scheduleReasonPaused  = "paused"
+	scheduleReasonResumed = "resumed"
+)
+
+// PauseAll will suspend the fires of every task, e.g. during a deploy, until ResumeAll. Timers are stopped, and any
+// fire due meanwhile is held instead of starting an execution, whatever requested it: intervals, retries, the end of
+// a snooze. Tasks added while the scheduler is paused are registered but not scheduled. Executions in flight are not
+// interrupted, and submitted functions still run. Pausing a paused scheduler has no effect.
+func (s *StdScheduler) PauseAll() {
+	var held []string
+
+	s.Lock()
+	if s.paused.Swap(true) {
+		s.Unlock()
+
+		return
+	}
+
+	for id, t := range s.tasks {
+		t.safeOps(func() {
+			// A task waiting for its StartAfter time is held once its timer would be armed
+			if t.state == TaskStatePending {
+				t.held = true
+				return
+			}
+
+			if !t.armed() || (t.state != TaskStateScheduled && t.state != TaskStateRetrying &&
+				t.state != TaskStateRunning) {
+				return
+			}
+
+			t.disarm()
+			t.held = true
+			t.trace.record(DecisionSkipped, 0, scheduleReasonPaused)
+			held = append(held, id)
+		})
+	}
+	s.Unlock()
+
+	logger.Infof("scheduler has been paused, %d tasks are held", len(held))
+
+	for _, id := range held {
+		s.notifyScheduleChange(id, time.Time{}, scheduleReasonPaused)
+	}
+}
+
+// ResumeAll will re-arm the tasks held since PauseAll, preserving their RunOnce and retry state: a recurring task runs
+// at the first of its regular fire times after now, a RunOnce task or a pending retry runs at its planned time, or
+// right away when that time passed meanwhile. Resuming a scheduler that is not paused has no effect.
+func (s *StdScheduler) ResumeAll() {
+	type resumed struct {
+		id   string
+		next time.Time
+	}
+	var rr []resumed
+
+	s.Lock()
+	if !s.paused.Swap(false) {
+		s.Unlock()
+
+		return
+	}
+
+	now := time.Now()
+	for id, t := range s.tasks {
+		t.safeOps(func() {
+			if !t.held {
+				return
+			}
+			t.held = false
+
+			// The timer of a task still waiting for its StartAfter time is armed as usual
+			if t.state == TaskStatePending {
+				return
+			}
+
+			next, armed := s.resetTimer(t, t.resumeAt(t.nextFire, now).Sub(now), DecisionTimerArmed, t.trigger)
+			if armed {
+				rr = append(rr, resumed{id: id, next: next})
+			}
+		})
+	}
+	s.Unlock()
+
+	logger.Infof("scheduler has been resumed, %d tasks are re-armed", len(rr))
+
+	for _, r := range rr {
+		s.notifyScheduleChange(r.id, r.next, scheduleReasonResumed)
+	}
+}
+
+// Paused will return whether the scheduler is paused, see PauseAll.
+func (s *StdScheduler) Paused() bool {
+	return s.paused.Load()
+}
+
+// hold suppresses the fire of the task when the scheduler is paused, ResumeAll re-arms it. It returns false when the
+// scheduler is not paused. The task lock must be held.
+func (s *StdScheduler) hold(t *Task) bool {
+	if !s.paused.Load() {
+		return false
+	}
+
+	t.held = true
+	t.trace.record(DecisionSkipped, 0, scheduleReasonPaused)
+
+	return true
+}
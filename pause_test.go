@@ -0,0 +1,149 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestPauseAll(t *testing.T) {
+	t.Run("Verify no task fires while the scheduler is paused", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var recurring, once int64
+		assert.NoError(scheduler.AddWithID("recurring", &Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				atomic.AddInt64(&recurring, 1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.NoError(scheduler.AddWithID("once", &Task{
+			Interval: 50 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				atomic.AddInt64(&once, 1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.Eventually(func() bool { return atomic.LoadInt64(&recurring) > 0 }, time.Second, time.Millisecond)
+
+		scheduler.PauseAll()
+		scheduler.PauseAll()
+		assert.True(scheduler.Paused())
+
+		// Across several intervals of both tasks
+		before := atomic.LoadInt64(&recurring)
+		time.Sleep(150 * time.Millisecond)
+		assert.Equal(before, atomic.LoadInt64(&recurring))
+		assert.Zero(atomic.LoadInt64(&once))
+		assert.True(scheduler.Has("once"))
+
+		scheduler.ResumeAll()
+		assert.False(scheduler.Paused())
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&recurring) > before+2 }, time.Second,
+			time.Millisecond)
+		assert.Eventually(func() bool { return !scheduler.Has("once") }, time.Second, time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&once))
+	})
+
+	t.Run("Verify pending retries are kept", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var attempts int64
+		failed := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("retried", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 50 * time.Millisecond,
+			TaskFunc: func() error {
+				if atomic.AddInt64(&attempts, 1) == 1 {
+					close(failed)
+					return errors.New("failed")
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		<-failed
+		scheduler.PauseAll()
+
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(int64(1), atomic.LoadInt64(&attempts))
+
+		task, err := scheduler.Lookup("retried")
+		assert.NoError(err)
+		assert.Equal(TaskStateRetrying, task.State())
+
+		// The retry was due during the pause, it runs right away
+		scheduler.ResumeAll()
+		assert.Eventually(func() bool { return !scheduler.Has("retried") }, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(int64(2), atomic.LoadInt64(&attempts))
+	})
+
+	t.Run("Verify tasks added while paused wait for ResumeAll", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu      sync.Mutex
+			changes []string
+		)
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			OnScheduleChange: func(id string, next time.Time, reason string) {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, reason)
+			},
+		})
+		defer scheduler.Stop()
+
+		scheduler.PauseAll()
+
+		ran := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("added", &Task{
+			Interval: 10 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				close(ran)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.True(scheduler.Has("added"))
+
+		select {
+		case <-ran:
+			t.Fatalf("task ran while the scheduler was paused")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		scheduler.ResumeAll()
+		scheduler.ResumeAll()
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatalf("task did not run within 1 second of ResumeAll")
+		}
+
+		// Nothing was scheduled before ResumeAll
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(scheduleReasonResumed, changes[0])
+	})
+}
@@ -0,0 +1,216 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// GroupQueueWait is the distribution of the time executions of a dispatch group waited for a worker of the shared
+// pool, over its latest executions. See Task.Group.
+type GroupQueueWait struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// workerPool is the shared pool of StdSchedulerOptions.WorkerLimit workers, it hands them to the executions waiting
+// for one. Waiting executions are queued by Task.Group, and a freed worker goes to each group in turn, so that a group
+// with many fires due at once cannot monopolize the pool.
+type workerPool struct {
+	sync.Mutex
+
+	// sem holds a value for every busy worker, a worker handed to a waiting execution stays busy.
+	sem chan struct{}
+
+	// queues are the executions waiting for a worker, by group, oldest first. A worker is handed to one by closing
+	// its channel.
+	queues map[string][]chan struct{}
+
+	// turns are the groups with waiting executions, the first one gets the next freed worker.
+	turns []string
+
+	// waits sample the queue wait of the latest executions, by group.
+	waits map[string]*latencySamples
+}
+
+// newWorkerPool creates a shared pool of n workers. It returns nil when the pool is unlimited.
+func newWorkerPool(n int) *workerPool {
+	if n <= 0 {
+		return nil
+	}
+
+	return &workerPool{
+		sem:    make(chan struct{}, n),
+		queues: make(map[string][]chan struct{}),
+		waits:  make(map[string]*latencySamples),
+	}
+}
+
+// acquire takes a free worker, or queues for one within the group. It returns a nil channel when a worker has been
+// taken, and otherwise the channel closed once a worker is handed over.
+func (p *workerPool) acquire(group string) chan struct{} {
+	p.Lock()
+	defer p.Unlock()
+
+	// Queued executions get the workers first
+	if len(p.turns) == 0 {
+		select {
+		case p.sem <- struct{}{}:
+			return nil
+		default:
+		}
+	}
+
+	waiter := make(chan struct{})
+	if len(p.queues[group]) == 0 {
+		p.turns = append(p.turns, group)
+	}
+	p.queues[group] = append(p.queues[group], waiter)
+
+	return waiter
+}
+
+// leave removes a waiting execution from its group. It returns false if a worker has been handed to it meanwhile.
+func (p *workerPool) leave(group string, waiter chan struct{}) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	queue := p.queues[group]
+	for i, w := range queue {
+		if w != waiter {
+			continue
+		}
+
+		p.queues[group] = append(queue[:i], queue[i+1:]...)
+		if len(p.queues[group]) == 0 {
+			p.dropTurn(group)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// release frees a worker, handing it to the oldest execution of the group whose turn it is. It returns false if no
+// worker was busy.
+func (p *workerPool) release() bool {
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.turns) > 0 {
+		group := p.turns[0]
+		p.turns = p.turns[1:]
+
+		queue := p.queues[group]
+		close(queue[0])
+		p.queues[group] = queue[1:]
+
+		// The group waits for its next turn behind the other ones
+		if len(p.queues[group]) > 0 {
+			p.turns = append(p.turns, group)
+		} else {
+			delete(p.queues, group)
+		}
+
+		return true
+	}
+
+	select {
+	case <-p.sem:
+		return true
+	default:
+		return false
+	}
+}
+
+// dropTurn removes a group without waiting executions from the turns. The pool lock must be held.
+func (p *workerPool) dropTurn(group string) {
+	delete(p.queues, group)
+	for i, g := range p.turns {
+		if g == group {
+			p.turns = append(p.turns[:i], p.turns[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// recordWait adds a queue wait sample to the group.
+func (p *workerPool) recordWait(group string, wait time.Duration) {
+	p.Lock()
+	samples, ok := p.waits[group]
+	if !ok {
+		samples = &latencySamples{}
+		p.waits[group] = samples
+	}
+	p.Unlock()
+
+	samples.record(wait)
+}
+
+// groupWaits returns the queue wait percentiles of every group that waited for a worker so far.
+func (p *workerPool) groupWaits() map[string]GroupQueueWait {
+	p.Lock()
+	groups := make(map[string]*latencySamples, len(p.waits))
+	for group, samples := range p.waits {
+		groups[group] = samples
+	}
+	p.Unlock()
+
+	waits := make(map[string]GroupQueueWait, len(groups))
+	for group, samples := range groups {
+		waits[group] = GroupQueueWait{
+			P50: samples.percentile(50),
+			P90: samples.percentile(90),
+			P99: samples.percentile(99),
+		}
+	}
+
+	return waits
+}
+
+// lockSem waits for a worker of the shared pool, its turn coming round-robin across groups. It returns false when the
+// scheduler stops first.
+func (s *StdScheduler) lockSem(group string) bool {
+	if s.pool == nil {
+		return true
+	}
+
+	queued := time.Now()
+	if waiter := s.pool.acquire(group); waiter != nil {
+		select {
+		case <-waiter:
+		case <-s.draining:
+			if s.pool.leave(group, waiter) {
+				return false
+			}
+
+			// The worker was handed over while giving up
+			s.unlockSem()
+
+			return false
+		}
+	}
+
+	wait := time.Since(queued)
+	s.queueWait.record(wait)
+	s.pool.recordWait(group, wait)
+
+	return true
+}
+
+// unlockSem releases a worker of the shared pool acquired by lockSem.
+func (s *StdScheduler) unlockSem() {
+	if s.pool == nil {
+		return
+	}
+
+	if !s.pool.release() {
+		logger.Error("a worker has been released while none was acquired")
+		s.reportInternalError(InternalErrorWorkerRelease, errors.New("worker released while none was acquired"))
+	}
+}
@@ -0,0 +1,112 @@
+package tasks
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("Verify a small group is not starved by a big one", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 2})
+		defer scheduler.Stop()
+
+		var bigRuns, smallRuns atomic.Int32
+		add := func(id, group string, interval time.Duration, runs *atomic.Int32) {
+			assert.NoError(scheduler.AddWithID(id, &Task{
+				Interval: interval,
+				Group:    group,
+				TaskFunc: func() error {
+					runs.Add(1)
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}))
+		}
+
+		// The big group asks for 5 times the capacity of the pool, the small one for a quarter of it. Taking turns,
+		// the small group gets all it asks for and the big one the rest.
+		for i := 0; i < 20; i++ {
+			add(fmt.Sprintf("big-%d", i), "big", 20*time.Millisecond, &bigRuns)
+		}
+		add("small-1", "small", 40*time.Millisecond, &smallRuns)
+		add("small-2", "small", 40*time.Millisecond, &smallRuns)
+
+		// Measure over a fixed window once both groups are running
+		assert.Eventually(func() bool { return smallRuns.Load() >= 2 && bigRuns.Load() >= 2 }, 5*time.Second,
+			time.Millisecond)
+		big, small := bigRuns.Load(), smallRuns.Load()
+		time.Sleep(500 * time.Millisecond)
+		big, small = bigRuns.Load()-big, smallRuns.Load()-small
+		t.Logf("executions over the window: big %d, small %d", big, small)
+
+		waits := scheduler.Stats().GroupQueueWait
+		if assert.Contains(waits, "big") && assert.Contains(waits, "small") {
+			// A small execution waits for at most one execution of each group, not for the backlog of the big group
+			assert.Less(waits["small"].P90, 50*time.Millisecond)
+			assert.Greater(waits["big"].P50, waits["small"].P50)
+		}
+		assert.Positive(small)
+		assert.Greater(big, small)
+	})
+
+	t.Run("Verify freed workers go to each group in turn", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		pool := newWorkerPool(1)
+		assert.Nil(pool.acquire("a"))
+
+		a1, a2 := pool.acquire("a"), pool.acquire("a")
+		b1 := pool.acquire("b")
+
+		closed := func(w chan struct{}) bool {
+			select {
+			case <-w:
+				return true
+			default:
+				return false
+			}
+		}
+
+		assert.True(pool.release())
+		assert.True(closed(a1))
+		assert.False(closed(b1))
+
+		assert.True(pool.release())
+		assert.True(closed(b1))
+		assert.False(closed(a2))
+
+		assert.True(pool.release())
+		assert.True(closed(a2))
+
+		// The last worker is released to the pool
+		assert.True(pool.release())
+		assert.False(pool.release())
+	})
+
+	t.Run("Verify a waiting execution gives up when the scheduler stops", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+
+		assert.True(scheduler.lockSem("a"))
+		acquired := make(chan bool)
+		go func() { acquired <- scheduler.lockSem("b") }()
+
+		assert.Eventually(func() bool {
+			scheduler.pool.Lock()
+			defer scheduler.pool.Unlock()
+			return len(scheduler.pool.turns) == 1
+		}, time.Second, time.Millisecond)
+
+		go scheduler.Stop()
+		assert.False(<-acquired)
+		scheduler.unlockSem()
+	})
+}
@@ -0,0 +1,678 @@
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// The tests in this file pin the concurrency guarantees of the scheduler. They are only meaningful with the race
+// detector enabled, and use short intervals and tight loops so the interleavings they look for happen often.
+
+// raceIterations is the number of iterations of every tight loop.
+const raceIterations = 200
+
+func TestRaceAddDelSameID(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < raceIterations; i++ {
+				_ = scheduler.AddWithID("same", &Task{
+					Interval: time.Millisecond,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(error) {},
+				})
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < raceIterations; i++ {
+				scheduler.Del("same")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRaceDelFire(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	var mu sync.Mutex
+	runs := make(map[string]int)
+
+	for i := 0; i < raceIterations; i++ {
+		id := fmt.Sprintf("fire-%d", i)
+
+		err := scheduler.AddWithID(id, &Task{
+			Interval: time.Millisecond,
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				runs[id]++
+				return nil
+			},
+			ErrFunc: func(error) {},
+		})
+		assert.NoError(err)
+
+		time.Sleep(time.Duration(i%3) * time.Millisecond)
+		scheduler.Del(id)
+	}
+
+	// A deleted task must not be re-armed by a firing that raced with its deletion
+	mu.Lock()
+	before := 0
+	for _, n := range runs {
+		before += n
+	}
+	mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	after := 0
+	for _, n := range runs {
+		after += n
+	}
+	assert.LessOrEqual(after-before, 1)
+}
+
+func TestRaceStopFire(t *testing.T) {
+	for i := 0; i < raceIterations/10; i++ {
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+
+		for k := 0; k < 10; k++ {
+			_, err := scheduler.Add(&Task{
+				Interval: time.Millisecond,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+			}
+		}
+
+		time.Sleep(time.Duration(i%3) * time.Millisecond)
+		scheduler.Stop()
+
+		assertions.Empty(t, scheduler.Tasks())
+	}
+}
+
+func TestRaceLookupAddDel(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < raceIterations; i++ {
+			id := fmt.Sprintf("lookup-%d", i%10)
+			_ = scheduler.AddWithID(id, &Task{
+				Interval:             time.Millisecond,
+				RetriesOnError:       1,
+				RetryOnErrorInterval: time.Millisecond,
+				TaskFunc:             func() error { return errors.New("some error") },
+				ErrFunc:              func(error) {},
+			})
+			scheduler.Del(fmt.Sprintf("lookup-%d", (i+5)%10))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < raceIterations; i++ {
+			if task, err := scheduler.Lookup(fmt.Sprintf("lookup-%d", i%10)); err == nil {
+				_ = task.Clone()
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < raceIterations; i++ {
+			for _, task := range scheduler.Tasks() {
+				_ = task.RunSequence()
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestRaceHasBatchAddDel(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("batch-%d", i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < raceIterations; i++ {
+			_ = scheduler.AddWithID(ids[i%10], &Task{
+				Interval: time.Millisecond,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			})
+			scheduler.Del(ids[(i+5)%10])
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < raceIterations; i++ {
+			_ = scheduler.HasAny(ids)
+
+			if missing := scheduler.HasAll(ids); len(missing) > len(ids) {
+				t.Errorf("HasAll returned %d missing IDs out of %d", len(missing), len(ids))
+			}
+			if exists := scheduler.Exists(ids...); len(exists) != len(ids) {
+				t.Errorf("Exists returned %d entries, expected %d", len(exists), len(ids))
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestRaceConfigureBeforeAdd(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	errSome := errors.New("some error")
+	doneCh := make(chan struct{})
+	var once sync.Once
+
+	task := &Task{}
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		task.SetInterval(time.Millisecond)
+	}()
+	go func() {
+		defer wg.Done()
+		task.SetRunOnce(false)
+		task.SetRetries(1, time.Millisecond)
+	}()
+	go func() {
+		defer wg.Done()
+		task.SetFuncs(func() error {
+			once.Do(func() { close(doneCh) })
+			return nil
+		}, func(error) {})
+	}()
+	go func() {
+		defer wg.Done()
+		task.WithRescheduleOnError(errSome, time.Millisecond, 1)
+	}()
+
+	// Adding while another goroutine still configures the task schedules a consistent snapshot
+	go func() {
+		_ = scheduler.AddWithID("early", task)
+	}()
+
+	wg.Wait()
+
+	assert.NoError(scheduler.AddWithID("configured", task))
+
+	select {
+	case <-doneCh:
+	case <-time.After(time.Second):
+		t.Fatalf("StdScheduler failed to execute the configured task within 1 second")
+	}
+}
+
+func TestRaceRescheduleOnErrorExecution(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	errSome := errors.New("some error")
+
+	// Executions last longer than the interval, so they overlap and hit the error path concurrently
+	task := &Task{
+		Interval: time.Millisecond,
+		TaskFunc: func() error {
+			time.Sleep(3 * time.Millisecond)
+			return errSome
+		},
+		ErrFunc: func(error) {},
+	}
+	task.WithRescheduleOnError(errSome, time.Millisecond, raceIterations)
+
+	err := scheduler.AddWithID("reschedule", task)
+	if err != nil {
+		t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+	}
+
+	// Both the added task and copies of the scheduled one are mutated while the scheduled one executes
+	for i := 0; i < raceIterations; i++ {
+		task.WithRescheduleOnError(errSome, time.Millisecond, i)
+
+		if scheduled, err := scheduler.Lookup("reschedule"); err == nil {
+			scheduled.WithRescheduleOnError(errSome, time.Millisecond, i)
+		}
+	}
+}
+
+func TestRaceRescheduleRulesLookup(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	errFirst := errors.New("first error")
+	errSecond := errors.New("second error")
+
+	var calls atomic.Int32
+	task := &Task{
+		Interval: time.Millisecond,
+		TaskFunc: func() error {
+			if calls.Add(1)%2 == 0 {
+				return errSecond
+			}
+			return errFirst
+		},
+		ErrFunc: func(error) {},
+	}
+	task.WithRescheduleOnError(errFirst, time.Millisecond, 1000)
+	task.WithRescheduleOnError(errSecond, time.Millisecond, 1000)
+	assert.NoError(scheduler.AddWithID("rules", task))
+
+	previous := 2000
+	for i := 0; i < raceIterations; i++ {
+		scheduled, err := scheduler.Lookup("rules")
+		if !assert.NoError(err) {
+			return
+		}
+
+		rules := scheduled.RescheduleRules()
+		if !assert.Len(rules, 2) {
+			return
+		}
+
+		// Remaining reschedules only ever go down
+		remaining := rules[0].Remaining + rules[1].Remaining
+		assert.LessOrEqual(remaining, previous)
+		previous = remaining
+	}
+
+	assert.Eventually(func() bool {
+		scheduled, err := scheduler.Lookup("rules")
+		if err != nil {
+			return false
+		}
+
+		rules := scheduled.RescheduleRules()
+		return rules[0].Remaining < 1000 && rules[1].Remaining < 1000
+	}, time.Second, time.Millisecond)
+}
+
+func TestRaceCloneRetries(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < raceIterations/10; i++ {
+		id := fmt.Sprintf("retries-%d", i)
+
+		err := scheduler.AddWithID(id, &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       5,
+			RetryOnErrorInterval: time.Millisecond,
+			TaskFunc:             func() error { return errors.New("some error") },
+			ErrFunc:              func(error) {},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for k := 0; k < raceIterations; k++ {
+				if task, err := scheduler.Lookup(id); err == nil {
+					_ = task.Clone().RetriesOnError
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRaceLogger(t *testing.T) {
+	defer logger.SetDefault(logger.Default())
+
+	var mu sync.Mutex
+	var b bytes.Buffer
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < raceIterations; i++ {
+				if w == 0 && i%10 == 0 {
+					mu.Lock()
+					b.Reset()
+					mu.Unlock()
+					logger.SetDefault(logger.NewSimpleLogger(log.New(&lockedWriter{mu: &mu, w: &b}, "", 0),
+						logger.LevelDebug))
+				}
+
+				if logger.Enabled(logger.LevelDebug) {
+					logger.Debugf("worker %d iteration %d", w, i)
+				}
+				logger.Infof("worker %d iteration %d", w, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// lockedWriter serializes writes to a shared buffer.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *bytes.Buffer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
+
+func (l *lockedWriter) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.String()
+}
+
+func TestRaceRescheduleBudgetOverlappingFailures(t *testing.T) {
+	assert := assertions.New(t)
+
+	var reschedules atomic.Int32
+	scheduler := NewStdScheduler(StdSchedulerOptions{
+		OnScheduleChange: func(id string, next time.Time, reason string) {
+			if reason == TriggerRescheduleOnError.String() {
+				reschedules.Add(1)
+			}
+		},
+	})
+	defer scheduler.Stop()
+
+	// Slow failures overlap with the next executions
+	errSlow := errors.New("slow error")
+	task := &Task{
+		Interval: 50 * time.Millisecond,
+		TaskFunc: func() error {
+			time.Sleep(200 * time.Millisecond)
+			return errSlow
+		},
+		ErrFunc: func(error) {},
+	}
+	task.WithRescheduleOnError(errSlow, 50*time.Millisecond, 5)
+	assert.NoError(scheduler.AddWithID("overlapping", task))
+
+	remaining := func() int {
+		scheduled, err := scheduler.Lookup("overlapping")
+		if err != nil {
+			return -1
+		}
+		return scheduled.RescheduleRules()[0].Remaining
+	}
+	assert.Eventually(func() bool { return remaining() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// The budget is consumed exactly once per reschedule, and never below zero
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(0, remaining())
+	assert.Equal(int32(5), reschedules.Load())
+}
+
+func TestRaceErrFuncReplacesTask(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	const (
+		ids         = 100
+		generations = 4
+	)
+
+	var (
+		violations atomic.Int32
+		done       sync.WaitGroup
+		runs       [ids][generations]atomic.Int32
+		current    [ids]atomic.Int32
+	)
+
+	// Every failure deletes the task from its error function and adds it again with a longer interval. Half of the
+	// tasks have no retry left when they fail, the other half have one pending.
+	var incarnation func(i, gen int) *Task
+	incarnation = func(i, gen int) *Task {
+		id := fmt.Sprintf("self-healing-%d", i)
+		var replaced sync.Once
+
+		return &Task{
+			Interval:             time.Duration(gen+1) * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       i % 2,
+			RetryOnErrorInterval: 100 * time.Millisecond,
+			TaskFunc: func() error {
+				runs[i][gen].Add(1)
+				if current[i].Load() != int32(gen) {
+					violations.Add(1)
+				}
+
+				if gen == generations-1 {
+					done.Done()
+
+					return nil
+				}
+				return errors.New("unhealthy")
+			},
+			ErrFunc: func(error) {
+				replaced.Do(func() {
+					scheduler.Del(id)
+					current[i].Store(int32(gen + 1))
+					if err := scheduler.AddWithID(id, incarnation(i, gen+1)); err != nil {
+						t.Errorf("Unexpected errors when adding the task again - %s", err)
+					}
+				})
+			},
+		}
+	}
+
+	done.Add(ids)
+	for i := 0; i < ids; i++ {
+		assert.NoError(scheduler.AddWithID(fmt.Sprintf("self-healing-%d", i), incarnation(i, 0)))
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		done.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a new incarnation has been removed with the old one")
+	}
+
+	// Pending retries of the replaced incarnations do not fire
+	time.Sleep(150 * time.Millisecond)
+	assert.Zero(violations.Load())
+	for i := 0; i < ids; i++ {
+		for gen := 0; gen < generations; gen++ {
+			assert.Equal(int32(1), runs[i][gen].Load(), "task %d, generation %d", i, gen)
+		}
+	}
+}
+
+func TestRaceCompletionDel(t *testing.T) {
+	assert := assertions.New(t)
+
+	var (
+		mu       sync.Mutex
+		removals = make(map[string]int)
+	)
+	scheduler := NewStdScheduler(StdSchedulerOptions{
+		OnScheduleChange: func(id string, next time.Time, reason string) {
+			if next.IsZero() {
+				mu.Lock()
+				removals[id]++
+				mu.Unlock()
+			}
+		},
+	})
+	defer scheduler.Stop()
+
+	// RunOnce completions race external deletions of the same IDs
+	var (
+		wg   sync.WaitGroup
+		runs atomic.Int32
+	)
+	for i := 0; i < raceIterations*5; i++ {
+		id := fmt.Sprintf("completion-%d", i)
+		ran := make(chan struct{})
+
+		err := scheduler.AddWithID(id, &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				runs.Add(1)
+				close(ran)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case <-ran:
+			case <-time.After(time.Second):
+			}
+			scheduler.Del(id)
+		}()
+	}
+	wg.Wait()
+
+	assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, time.Second, time.Millisecond)
+	assert.Equal(int32(raceIterations*5), runs.Load())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(removals, raceIterations*5)
+	for id, n := range removals {
+		assert.Equal(1, n, id)
+	}
+}
+
+func TestRaceCompletionDeadline(t *testing.T) {
+	assert := assertions.New(t)
+
+	var (
+		mu       sync.Mutex
+		reasons  = make(map[string]string)
+		expiries = make(map[string]int)
+	)
+	scheduler := NewStdScheduler(StdSchedulerOptions{
+		OnScheduleChange: func(id string, next time.Time, reason string) {
+			if next.IsZero() {
+				mu.Lock()
+				reasons[id] = reason
+				mu.Unlock()
+			}
+		},
+	})
+	defer scheduler.Stop()
+
+	// RunOnce tasks complete right around their deadline
+	for i := 0; i < raceIterations*5; i++ {
+		id := fmt.Sprintf("deadline-%d", i)
+
+		err := scheduler.AddWithID(id, &Task{
+			Interval:   time.Millisecond,
+			RunOnce:    true,
+			CompleteBy: time.Now().Add(time.Millisecond + time.Duration(i%20)*50*time.Microsecond),
+			TaskFunc:   func() error { return nil },
+			ErrFunc: func(err error) {
+				if errors.Is(err, ErrDeadlineExceeded) {
+					mu.Lock()
+					expiries[id]++
+					mu.Unlock()
+				}
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+	}
+
+	assert.Eventually(func() bool { return len(scheduler.Tasks()) == 0 }, 5*time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	// Only the tasks removed by their deadline get the deadline error, once
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(reasons, raceIterations*5)
+	for id, reason := range reasons {
+		if reason == removalReasonDeadline {
+			assert.Equal(1, expiries[id], id)
+		} else {
+			assert.Zero(expiries[id], id)
+		}
+	}
+}
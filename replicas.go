@@ -0,0 +1,175 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrReplicasInvalid is returned when a replicated task is added or rescaled with less than one replica.
+var ErrReplicasInvalid = errors.New("replicas must be greater than zero")
+
+// ErrReplicatedTaskNotFound is returned when a replicated task operation targets an unknown base ID.
+var ErrReplicatedTaskNotFound = errors.New("could not find replicated task")
+
+// replicaSet is the definition of a replicated task, kept to re-phase replicas on Rescale.
+type replicaSet struct {
+	template *Task
+	ids      []string
+}
+
+// ReplicaIndex will return the index of the replica this context belongs to. Tasks that were not added with
+// AddReplicated always report 0.
+func (ctx TaskContext) ReplicaIndex() int {
+	return ctx.replicaIndex
+}
+
+// AddReplicated will add replicas copies of the task, with IDs baseID-0 to baseID-(replicas-1). Replicas share the
+// task configuration but are evenly phased across the interval: replica k starts k*Interval/replicas after the
+// others. The replica index is available to task functions via TaskContext.ReplicaIndex.
+//
+//	// Add 4 pollers, one of them firing every 15 seconds
+//	ids, err := scheduler.AddReplicated("poller", 4, &tasks.Task{
+//		Interval: time.Duration(60 * time.Second),
+//		FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+//			// Put your logic here, taskCtx.ReplicaIndex() tells which replica is running
+//		},
+//		ErrFunc: func(err error) {
+//			// Put custom error handling here
+//		},
+//	})
+//	if err != nil {
+//		// Do stuff
+//	}
+//
+// Either all replicas are added or none of them.
+func (s *StdScheduler) AddReplicated(baseID string, replicas int, t *Task) ([]string, error) {
+	if replicas < 1 {
+		return nil, ErrReplicasInvalid
+	}
+
+	s.replicasMu.Lock()
+	defer s.replicasMu.Unlock()
+
+	if _, ok := s.replicas[baseID]; ok {
+		return nil, ErrIDInUse
+	}
+
+	template := t.Clone()
+
+	ids, err := s.addReplicas(baseID, replicas, template)
+	if err != nil {
+		return nil, err
+	}
+
+	s.replicas[baseID] = &replicaSet{template: template, ids: ids}
+
+	return append([]string(nil), ids...), nil
+}
+
+// DelReplicated will delete every replica of the replicated task added with the specified base ID. The replicas are
+// removed at once, Lookup and Tasks see either all of them or none.
+func (s *StdScheduler) DelReplicated(baseID string) {
+	s.replicasMu.Lock()
+	defer s.replicasMu.Unlock()
+
+	set, ok := s.replicas[baseID]
+	if !ok {
+		return
+	}
+
+	s.delReplicas(set.ids)
+	delete(s.replicas, baseID)
+}
+
+// Rescale will change the number of replicas of a replicated task. The replicas are re-added and re-phased evenly
+// across the interval for the new count. If re-adding fails, the previous replicas are restored. If restoring them
+// fails too, the replicated task is forgotten and both errors are returned.
+func (s *StdScheduler) Rescale(baseID string, replicas int) error {
+	if replicas < 1 {
+		return ErrReplicasInvalid
+	}
+
+	s.replicasMu.Lock()
+	defer s.replicasMu.Unlock()
+
+	set, ok := s.replicas[baseID]
+	if !ok {
+		return ErrReplicatedTaskNotFound
+	}
+
+	s.delReplicas(set.ids)
+
+	ids, err := s.addReplicas(baseID, replicas, set.template)
+	if err != nil {
+		// Restore the previous replicas, they were valid a moment ago, unless their IDs have been taken meanwhile
+		restored, restoreErr := s.addReplicas(baseID, len(set.ids), set.template)
+		if restoreErr != nil {
+			delete(s.replicas, baseID)
+
+			return errors.Join(err, restoreErr)
+		}
+		set.ids = restored
+
+		return err
+	}
+
+	set.ids = ids
+
+	return nil
+}
+
+// addReplicas adds replicas of the template phased across its interval, removing the already added ones on error.
+func (s *StdScheduler) addReplicas(baseID string, replicas int, template *Task) ([]string, error) {
+	start := template.StartAfter
+	if now := time.Now(); start.Before(now) {
+		start = now
+	}
+
+	phase := template.Interval / time.Duration(replicas)
+	ids := make([]string, 0, replicas)
+
+	for k := 0; k < replicas; k++ {
+		replica := template.Clone()
+		replica.StartAfter = start.Add(time.Duration(k) * phase)
+		replica.TaskContext.replicaIndex = k
+
+		id := fmt.Sprintf("%s-%d", baseID, k)
+		if err := s.AddWithID(id, replica); err != nil {
+			s.delReplicas(ids)
+
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// delReplicas deletes the replicas with the specified IDs like Del, removing them from the task list in one go.
+func (s *StdScheduler) delReplicas(ids []string) {
+	removed := make([]*Task, 0, len(ids))
+	s.Lock()
+	for _, id := range ids {
+		if t, ok := s.tasks[id]; ok {
+			delete(s.tasks, id)
+			removed = append(removed, t)
+		}
+	}
+	if len(removed) > 0 {
+		s.freeCapacity()
+	}
+	s.Unlock()
+
+	// Replicas still waiting for their AfterAll dependencies are not in the task list yet
+	for _, id := range ids {
+		s.cancelFanIn(id)
+	}
+	for _, t := range removed {
+		s.teardown(t.id, t, removalReasonDeleted)
+	}
+	for _, id := range ids {
+		s.forget(id)
+	}
+}
@@ -0,0 +1,204 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestAddReplicated(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify replicas are phased across the interval", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		ids, err := scheduler.AddReplicated("poller", 4, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+		assert.Equal([]string{"poller-0", "poller-1", "poller-2", "poller-3"}, ids)
+		defer scheduler.DelReplicated("poller")
+
+		first, err := scheduler.Lookup("poller-0")
+		assert.NoError(err)
+
+		for k, id := range ids {
+			replica, err := scheduler.Lookup(id)
+			assert.NoError(err)
+			assert.Equal(time.Duration(k)*15*time.Second, replica.StartAfter.Sub(first.StartAfter))
+			assert.Equal(k, replica.TaskContext.ReplicaIndex())
+		}
+	})
+
+	t.Run("Verify first fires are spaced by the phase", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		type fire struct {
+			index int
+			at    time.Time
+		}
+		fireCh := make(chan fire, 10)
+
+		_, err := scheduler.AddReplicated("spaced", 4, &Task{
+			Interval: 200 * time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				fireCh <- fire{index: taskCtx.ReplicaIndex(), at: time.Now()}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.DelReplicated("spaced")
+
+		fires := make([]time.Time, 4)
+		for i := 0; i < 4; i++ {
+			select {
+			case f := <-fireCh:
+				fires[f.index] = f.at
+			case <-time.After(time.Second):
+				t.Fatalf("Replica did not execute within 1 second")
+			}
+		}
+		for k := 1; k < 4; k++ {
+			assert.InDelta(50*time.Millisecond, fires[k].Sub(fires[k-1]), float64(20*time.Millisecond), k)
+		}
+	})
+
+	t.Run("Verify replica index is passed to the task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		indexCh := make(chan int, 10)
+
+		_, err := scheduler.AddReplicated("indexed", 2, &Task{
+			Interval: 20 * time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				indexCh <- taskCtx.ReplicaIndex()
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.DelReplicated("indexed")
+
+		seen := map[int]bool{}
+		for i := 0; i < 2; i++ {
+			select {
+			case index := <-indexCh:
+				seen[index] = true
+			case <-time.After(time.Second):
+				t.Fatalf("Replica did not execute within 1 second")
+			}
+		}
+		assert.Equal(map[int]bool{0: true, 1: true}, seen)
+	})
+
+	t.Run("Verify DelReplicated removes every replica", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		ids, err := scheduler.AddReplicated("removed", 3, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		scheduler.DelReplicated("removed")
+		for _, id := range ids {
+			assert.False(scheduler.Has(id))
+		}
+	})
+
+	t.Run("Verify Rescale re-phases replicas", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		_, err := scheduler.AddReplicated("rescaled", 2, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.DelReplicated("rescaled")
+
+		assert.NoError(scheduler.Rescale("rescaled", 3))
+		assert.True(scheduler.Has("rescaled-2"))
+
+		first, err := scheduler.Lookup("rescaled-0")
+		assert.NoError(err)
+		second, err := scheduler.Lookup("rescaled-1")
+		assert.NoError(err)
+		assert.Equal(20*time.Second, second.StartAfter.Sub(first.StartAfter))
+
+		assert.NoError(scheduler.Rescale("rescaled", 1))
+		assert.False(scheduler.Has("rescaled-1"))
+		assert.False(scheduler.Has("rescaled-2"))
+
+		assert.ErrorIs(scheduler.Rescale("unknown", 2), ErrReplicatedTaskNotFound)
+		assert.ErrorIs(scheduler.Rescale("rescaled", 0), ErrReplicasInvalid)
+	})
+
+	t.Run("Verify replicated tasks are added atomically", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		assert.NoError(scheduler.AddWithID("partial-1", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		}))
+		defer scheduler.Del("partial-1")
+
+		_, err := scheduler.AddReplicated("partial", 3, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.ErrorIs(err, ErrIDInUse)
+		assert.False(scheduler.Has("partial-0"))
+		assert.False(scheduler.Has("partial-2"))
+	})
+
+	t.Run("Verify Rescale reports a failed restore", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		// Take the ID of the first replica as soon as Rescale deletes it, so that neither the new replicas nor the
+		// previous ones can be added back
+		var (
+			scheduler *StdScheduler
+			intruded  bool
+		)
+		scheduler = NewStdScheduler(StdSchedulerOptions{
+			OnScheduleChange: func(id string, next time.Time, reason string) {
+				if id != "restored-0" || !next.IsZero() || intruded {
+					return
+				}
+				intruded = true
+				assert.NoError(scheduler.AddWithID("restored-0", &Task{
+					Interval: time.Minute,
+					TaskFunc: func() error { return nil },
+					ErrFunc:  func(e error) {},
+				}))
+			},
+		})
+		defer scheduler.Stop()
+
+		_, err := scheduler.AddReplicated("restored", 2, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		err = scheduler.Rescale("restored", 3)
+		assert.ErrorIs(err, ErrIDInUse)
+		assert.True(intruded)
+
+		// Nothing of the replicated task is left to manage
+		assert.False(scheduler.Has("restored-1"))
+		assert.ErrorIs(scheduler.Rescale("restored", 2), ErrReplicatedTaskNotFound)
+	})
+}
@@ -0,0 +1,128 @@
+package tasks
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// errorType is the type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// rescheduleRule is a reschedule on error rule. It matches failures with errors.Is on err, errors.As on the type
+// target points to, or with match, whichever is set.
+type rescheduleRule struct {
+	err      error
+	target   any
+	match    func(error) bool
+	interval time.Duration
+	count    int
+}
+
+// matches reports whether the failure matches the rule.
+func (r rescheduleRule) matches(err error) bool {
+	switch {
+	case r.match != nil:
+		return r.match(err)
+	case r.target != nil:
+		// Every failure gets a target of its own, executions may overlap
+		return errors.As(err, reflect.New(reflect.TypeOf(r.target).Elem()).Interface())
+	default:
+		return errors.Is(err, r.err)
+	}
+}
+
+// replaces reports whether the rule takes the place of other: rules of the same error value or of the same target
+// type replace each other, rules with a match function never do.
+func (r rescheduleRule) replaces(other rescheduleRule) bool {
+	switch {
+	case r.match != nil || other.match != nil:
+		return false
+	case r.target != nil || other.target != nil:
+		return r.target != nil && other.target != nil && reflect.TypeOf(r.target) == reflect.TypeOf(other.target)
+	default:
+		return r.err == other.err
+	}
+}
+
+// WithRescheduleOnErrorAs will reschedule the task after interval when it fails with an error that errors.As finds
+// in target, at most count times. It suits typed errors such as *net.OpError, where WithRescheduleOnError needs a
+// sentinel value. Like for errors.As, target is a non-nil pointer to a type implementing error or to an interface:
+//
+//	task.WithRescheduleOnErrorAs(new(*net.OpError), 5*time.Second, 3)
+//
+// Only the type of target is used, it is not set. Adding a rule for the same type replaces it. Failures are matched
+// against the rules in registration order, whatever the way they were added, and the first matching rule applies.
+//
+// It returns ErrInvalidRescheduleTarget for an invalid target, ErrTooManyRescheduleRules beyond MaxRescheduleRules,
+// and ErrReadOnlyTask on a task returned by Lookup or Tasks.
+func (t *Task) WithRescheduleOnErrorAs(target any, interval time.Duration, count int) error {
+	if target == nil {
+		return ErrInvalidRescheduleTarget
+	}
+	typ := reflect.TypeOf(target)
+	if typ.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		return ErrInvalidRescheduleTarget
+	}
+	if elem := typ.Elem(); elem.Kind() != reflect.Interface && !elem.Implements(errorType) {
+		return ErrInvalidRescheduleTarget
+	}
+
+	return t.withRescheduleRule(rescheduleRule{target: target, interval: interval, count: count})
+}
+
+// WithRescheduleOnErrorFunc will reschedule the task after interval when it fails with an error match returns true
+// for, at most count times. It suits conditions no single error stands for, such as any 5xx HTTP status:
+//
+//	task.WithRescheduleOnErrorFunc(func(err error) bool {
+//		var statusErr *StatusError
+//		return errors.As(err, &statusErr) && statusErr.Code >= 500
+//	}, 5*time.Second, 3)
+//
+// Every call adds a rule. Failures are matched against the rules in registration order, whatever the way they were
+// added, and the first matching rule applies. match is called with the task locked, it must not use the scheduler.
+//
+// It returns ErrTooManyRescheduleRules beyond MaxRescheduleRules, and ErrReadOnlyTask on a task returned by Lookup or
+// Tasks.
+func (t *Task) WithRescheduleOnErrorFunc(match func(error) bool, interval time.Duration, count int) error {
+	return t.withRescheduleRule(rescheduleRule{match: match, interval: interval, count: count})
+}
+
+// withRescheduleRule sets the rule unless the task is a read-only copy.
+func (t *Task) withRescheduleRule(rule rescheduleRule) error {
+	var addErr error
+	if err := t.mutate(func() {
+		addErr = t.setRescheduleRule(rule)
+	}); err != nil {
+		return err
+	}
+
+	return addErr
+}
+
+// findRescheduleRule returns the index of the rule the given one replaces, -1 if none. The task lock must be held.
+func (t *Task) findRescheduleRule(rule rescheduleRule) int {
+	for i, r := range t.rescheduleOnError {
+		if rule.replaces(r) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setRescheduleRule replaces the rule it replaces in place, or adds it last. The task lock must be held.
+func (t *Task) setRescheduleRule(rule rescheduleRule) error {
+	if i := t.findRescheduleRule(rule); i >= 0 {
+		t.rescheduleOnError[i] = rule
+
+		return nil
+	}
+
+	if len(t.rescheduleOnError) >= MaxRescheduleRules {
+		return ErrTooManyRescheduleRules
+	}
+	t.rescheduleOnError = append(t.rescheduleOnError, rule)
+
+	return nil
+}
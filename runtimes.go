@@ -0,0 +1,60 @@
+package tasks
+
+import "time"
+
+// RunTimes are the timestamps of an execution. The next fire of a recurring task is armed one interval after its
+// execution starts: the timer latency and the time spent waiting for a worker delay the following fires, the time
+// spent running does not.
+type RunTimes struct {
+	// Scheduled is when the execution was meant to fire.
+	Scheduled time.Time
+
+	// Enqueued is when the execution started waiting for a worker.
+	Enqueued time.Time
+
+	// Started is when the execution started running.
+	Started time.Time
+
+	// Ended is when the execution ended, zero while it is running.
+	Ended time.Time
+}
+
+// Stretch will return how late the execution started compared to when it was scheduled, including the timer latency
+// and the time spent waiting for a worker.
+func (r RunTimes) Stretch() time.Duration {
+	if r.Scheduled.IsZero() || r.Started.IsZero() {
+		return 0
+	}
+
+	return r.Started.Sub(r.Scheduled)
+}
+
+// QueueWait will return how long the execution waited for a worker.
+func (r RunTimes) QueueWait() time.Duration {
+	if r.Enqueued.IsZero() || r.Started.IsZero() {
+		return 0
+	}
+
+	return r.Started.Sub(r.Enqueued)
+}
+
+// LastRun will return the timestamps of the latest started execution, or zero values if the task never ran.
+func (t *Task) LastRun() RunTimes {
+	var r RunTimes
+	t.safeOps(func() {
+		r = t.lastRun
+	})
+
+	return r
+}
+
+// endRun records the outcome of the execution started at started, and its end unless a later execution started
+// since.
+func (t *Task) endRun(started time.Time, err error) {
+	t.safeOps(func() {
+		t.lastErr = err
+		if t.lastRun.Started.Equal(started) {
+			t.lastRun.Ended = time.Now()
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestLastRun(t *testing.T) {
+	t.Run("Verify the timestamps of an execution waiting for a worker", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+
+		release := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("busy", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				<-release
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.Eventually(func() bool {
+			task, err := scheduler.Lookup("busy")
+			return err == nil && task.State() == TaskStateRunning
+		}, time.Second, time.Millisecond)
+
+		assert.NoError(scheduler.AddWithID("queued", &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		t.Cleanup(func() { scheduler.Del("queued") })
+
+		time.Sleep(50 * time.Millisecond)
+
+		task, err := scheduler.Lookup("queued")
+		assert.NoError(err)
+		assert.Equal(RunTimes{}, task.LastRun())
+
+		close(release)
+		assert.Eventually(func() bool {
+			task, err := scheduler.Lookup("queued")
+			return err == nil && !task.LastRun().Ended.IsZero()
+		}, time.Second, time.Millisecond)
+
+		task, err = scheduler.Lookup("queued")
+		assert.NoError(err)
+
+		r := task.LastRun()
+		assert.False(r.Scheduled.After(r.Enqueued))
+		assert.False(r.Enqueued.After(r.Started))
+		assert.False(r.Started.After(r.Ended))
+		assert.GreaterOrEqual(r.QueueWait(), 40*time.Millisecond)
+		assert.GreaterOrEqual(r.Stretch(), r.QueueWait())
+	})
+
+	t.Run("Verify a task that never ran has no timestamps", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		task := &Task{}
+		assert.Equal(RunTimes{}, task.LastRun())
+		assert.Zero(task.LastRun().Stretch())
+		assert.Zero(task.LastRun().QueueWait())
+	})
+}
@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySampleSize is the number of latest samples the saturation percentiles are computed over.
+const latencySampleSize = 128
+
+// Defaults used when the saturation thresholds of StdSchedulerOptions are not set.
+const (
+	defaultSaturationFireLatency = 100 * time.Millisecond
+	defaultSaturationQueueWait   = 100 * time.Millisecond
+	defaultOverdueTolerance      = time.Second
+)
+
+// Saturation factors reported in Saturation.Reasons.
+const (
+	SaturationReasonFireLatency  = "fire latency"
+	SaturationReasonQueueWait    = "queue wait"
+	SaturationReasonOverdueTasks = "overdue tasks"
+)
+
+// Saturation tells whether the scheduler is currently unable to run tasks on time, and why.
+type Saturation struct {
+	// Saturated is true when any of the factors below is over its threshold.
+	Saturated bool
+
+	// Reasons lists the factors over their threshold, e.g. SaturationReasonQueueWait.
+	Reasons []string
+
+	// FireLatencyP99 is the 99th percentile of how late timers fired compared to their expected fire time.
+	FireLatencyP99 time.Duration
+
+	// QueueWaitP99 is the 99th percentile of how long executions waited for a worker, with a WorkerLimit set.
+	QueueWaitP99 time.Duration
+
+	// OverdueTasks is the number of tasks whose next fire time is in the past by more than the overdue tolerance.
+	OverdueTasks int
+}
+
+// latencySamples is a bounded ring of the latest latency samples.
+type latencySamples struct {
+	sync.Mutex
+
+	samples [latencySampleSize]time.Duration
+	next    int
+	full    bool
+}
+
+// record adds a sample, overwriting the oldest one when the ring is full.
+func (l *latencySamples) record(d time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.samples[l.next] = d
+	l.next = (l.next + 1) % latencySampleSize
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// p99 returns the 99th percentile of the recorded samples, or 0 without samples.
+func (l *latencySamples) p99() time.Duration {
+	return l.percentile(99)
+}
+
+// percentile returns the p-th percentile of the recorded samples, or 0 without samples.
+func (l *latencySamples) percentile(p int) time.Duration {
+	l.Lock()
+	n := l.next
+	if l.full {
+		n = latencySampleSize
+	}
+	sorted := append([]time.Duration(nil), l.samples[:n]...)
+	l.Unlock()
+
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return sorted[(len(sorted)*p-1)/100]
+}
+
+// Saturation will compute whether the scheduler is keeping up with its tasks, from the fire latency and worker queue
+// wait of the latest executions and the number of overdue tasks. Thresholds are set with
+// StdSchedulerOptions.SaturationFireLatency, SaturationQueueWait and OverdueTolerance. It is also part of Health.
+func (s *StdScheduler) Saturation() Saturation {
+	fireLatency := s.opts.SaturationFireLatency
+	if fireLatency <= 0 {
+		fireLatency = defaultSaturationFireLatency
+	}
+
+	queueWait := s.opts.SaturationQueueWait
+	if queueWait <= 0 {
+		queueWait = defaultSaturationQueueWait
+	}
+
+	tolerance := s.opts.OverdueTolerance
+	if tolerance <= 0 {
+		tolerance = defaultOverdueTolerance
+	}
+
+	sat := Saturation{
+		FireLatencyP99: s.fireLatency.p99(),
+		QueueWaitP99:   s.queueWait.p99(),
+	}
+
+	overdue := time.Now().Add(-tolerance)
+
+	s.RLock()
+	for _, t := range s.tasks {
+		t.safeOps(func() {
+			if !t.nextFire.IsZero() && t.nextFire.Before(overdue) {
+				sat.OverdueTasks++
+			}
+		})
+	}
+	s.RUnlock()
+
+	if sat.FireLatencyP99 > fireLatency {
+		sat.Reasons = append(sat.Reasons, SaturationReasonFireLatency)
+	}
+	if sat.QueueWaitP99 > queueWait {
+		sat.Reasons = append(sat.Reasons, SaturationReasonQueueWait)
+	}
+	if sat.OverdueTasks > 0 {
+		sat.Reasons = append(sat.Reasons, SaturationReasonOverdueTasks)
+	}
+	sat.Saturated = len(sat.Reasons) > 0
+
+	return sat
+}
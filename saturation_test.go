@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestSaturation(t *testing.T) {
+	t.Run("Verify an idle scheduler is not saturated", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		_, err := scheduler.Add(&Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		time.Sleep(50 * time.Millisecond)
+
+		sat := scheduler.Saturation()
+		assert.False(sat.Saturated)
+		assert.Empty(sat.Reasons)
+	})
+
+	t.Run("Verify an overloaded scheduler reports queue wait", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+
+		for i := 0; i < 10; i++ {
+			id := fmt.Sprintf("slow-%d", i)
+			err := scheduler.AddWithID(id, &Task{
+				Interval: 10 * time.Millisecond,
+				TaskFunc: func() error {
+					time.Sleep(100 * time.Millisecond)
+					return nil
+				},
+				ErrFunc: func(e error) {},
+			})
+			assert.NoError(err)
+
+			t.Cleanup(func() { scheduler.Del(id) })
+		}
+
+		time.Sleep(500 * time.Millisecond)
+
+		sat := scheduler.Saturation()
+		assert.True(sat.Saturated)
+		assert.Contains(sat.Reasons, SaturationReasonQueueWait)
+		assert.Greater(sat.QueueWaitP99, 100*time.Millisecond)
+
+		// Health carries the same figures
+		health := scheduler.Health()
+		assert.True(health.Saturation.Saturated)
+		assert.Contains(health.Saturation.Reasons, SaturationReasonQueueWait)
+		assert.Greater(health.Saturation.QueueWaitP99, 100*time.Millisecond)
+	})
+}
+
+func TestLatencySamples(t *testing.T) {
+	assert := assertions.New(t)
+
+	var l latencySamples
+	assert.Zero(l.p99())
+
+	for i := 1; i <= 2*latencySampleSize; i++ {
+		l.record(time.Duration(i))
+	}
+
+	// Only the latest samples are kept
+	assert.Equal(time.Duration(2*latencySampleSize-1), l.p99())
+}
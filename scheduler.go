@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/xid"
@@ -26,42 +28,299 @@ var (
 	ErrTaskErrFunctionsNotSet = errors.New("err functions are empty")
 	// ErrTaskLimitExceeded is returned when number of tasks exceeds task limit.
 	ErrTaskLimitExceeded = errors.New("task limit exceeded")
+	// ErrTaskNotFound is returned when the specified task is not in the task list.
+	ErrTaskNotFound = errors.New("could not find task within the task list")
+	// ErrDeadlineExceeded is delivered to the error functions of a task that did not complete by Task.CompleteBy.
+	// It is also the cause of the task context of an execution still in flight at that moment.
+	ErrDeadlineExceeded = errors.New("task did not complete by its deadline")
+	// ErrCheckpointTooLarge is returned when a checkpoint is larger than MaxCheckpointSize.
+	ErrCheckpointTooLarge = errors.New("checkpoint is too large")
+	// ErrInvalidBoost is returned by Boost when the interval is not positive, the end is not in the future or the
+	// task runs only once.
+	ErrInvalidBoost = errors.New("invalid boost")
+	// ErrInvalidSnooze is returned by Snooze when the end of the snooze is not in the future.
+	ErrInvalidSnooze = errors.New("snooze end is not in the future")
+	// ErrReadOnlyTask is returned when a task returned by Lookup or Tasks is modified, use LookupForUpdate instead.
+	ErrReadOnlyTask = errors.New("task is a read-only copy")
+	// ErrInvalidCronExpr is wrapped by the error returned when Task.CronExpr cannot be parsed or never matches.
+	ErrInvalidCronExpr = errors.New("invalid cron expression")
+	// ErrCronWithInterval is returned when both Task.CronExpr and Task.Interval are set.
+	ErrCronWithInterval = errors.New("cron expression and interval are both set")
+	// ErrSchedulerStopped is returned when adding or submitting work to a stopped scheduler.
+	ErrSchedulerStopped = errors.New("scheduler is stopped")
+	// ErrYielded is returned by a task function to stop its execution early without failing, typically once
+	// TaskContext.Draining is closed. The checkpoint it set is kept for the next execution, see
+	// TaskContext.SetCheckpoint.
+	ErrYielded = errors.New("task yielded")
+	// ErrNoNextRun is returned by NextRun when the task has no pending execution, e.g. once a RunOnce task has fired.
+	ErrNoNextRun = errors.New("task has no pending execution")
+	// ErrMaxRunsWithRunOnce is returned when Task.MaxRuns is set on a RunOnce task.
+	ErrMaxRunsWithRunOnce = errors.New("max runs is set on a run once task")
+	// ErrUnknownLane is returned when Task.Lane is not one of StdSchedulerOptions.Lanes.
+	ErrUnknownLane = errors.New("unknown lane")
+	// ErrTooManyRescheduleRules is returned when adding a reschedule on error rule to a task that already has
+	// MaxRescheduleRules.
+	ErrTooManyRescheduleRules = errors.New("too many reschedule rules")
+	// ErrInvalidRescheduleTarget is returned by Task.WithRescheduleOnErrorAs when the target is not a non-nil pointer
+	// to a type implementing error or to an interface, as errors.As requires.
+	ErrInvalidRescheduleTarget = errors.New("invalid reschedule target")
+)
+
+const (
+	// skipReasonCalendar is logged when an execution is skipped because its fire time is excluded.
+	skipReasonCalendar = "calendar"
+
+	// removalReasonDeleted is reported when a task is removed with Del.
+	removalReasonDeleted = "deleted"
+	// removalReasonSkipLimit is reported when a task is removed after Task.MaxConsecutiveSkips skipped firings.
+	removalReasonSkipLimit = "skip-limit"
+	// removalReasonDeadline is reported when a task is removed because it did not complete by Task.CompleteBy.
+	removalReasonDeadline = "deadline"
+	// removalReasonExternal is reported when a task is removed by the signal bound with BindCancellation.
+	removalReasonExternal = "external"
 )
 
 // StdScheduler stores the internal task list and provides an interface for task management.
 type StdScheduler struct {
 	sync.RWMutex
 
-	taskSem chan struct{}
+	// pool is the shared pool of WorkerLimit workers, nil when unlimited.
+	pool *workerPool
 	// tasks is the internal task list used to store tasks that are currently scheduled.
 	tasks map[string]*Task
 
+	// capacityFreed is closed every time a task leaves the task list, waking up AddWait callers. It is only created
+	// when a caller waits, so that removals do not allocate a channel each.
+	capacityFreed chan struct{}
+
+	// completions batches the removals of tasks completing on their own.
+	completions completions
+
+	// fireLatency and queueWait sample the latest executions to compute the saturation.
+	fireLatency latencySamples
+	queueWait   latencySamples
+
+	// replicasMu serializes replicated task operations, replicas holds replicated tasks by base ID.
+	replicasMu sync.Mutex
+	replicas   map[string]*replicaSet
+
+	// idCollisions counts the IDs rejected because they were already in use.
+	idCollisions idCollisionCounter
+
+	// startedAt is when the scheduler was created.
+	startedAt time.Time
+
+	// deadLetters holds the RunOnce tasks that failed for the last time, when StdSchedulerOptions.DeadLetter is set.
+	deadLetters deadLetters
+
+	// draining is closed when the scheduler starts shutting down, see TaskContext.Draining.
+	draining  chan struct{}
+	drainOnce sync.Once
+
+	// paused is set between PauseAll and ResumeAll, it is changed under the scheduler lock.
+	paused atomic.Bool
+
+	// stopped is set by Stop, it is guarded by the scheduler lock.
+	stopped bool
+
+	// stopping is set by the first call to Stop, the later ones return right away.
+	stopping atomic.Bool
+
+	// dispatcher fires RunOnce tasks in order, when StdSchedulerOptions.OrderedRunOnce is set.
+	dispatcher *dispatcher
+
+	// lanes holds the worker pools of StdSchedulerOptions.Lanes by name.
+	lanes map[string]*lane
+
+	// auditor queues execution records for the AuditWriter, nil without one.
+	auditor *auditor
+
+	// exclusive keeps the executions of Task.Exclusive tasks apart from all the others.
+	exclusive exclusiveGate
+
+	// internalErrors keeps the internal errors while no logger is configured, see InternalErrors.
+	internalErrors internalErrors
+
+	// fanIns tracks the tasks added with AfterAll until their dependencies complete.
+	fanIns fanIns
+
+	// heartbeat measures how late timers fire, see Health.
+	heartbeat *heartbeat
+
 	opts StdSchedulerOptions
 }
 
+// WorkerLimitAuto sizes the worker limit from the number of usable CPUs, see StdSchedulerOptions.WorkerLimitFactor.
+const WorkerLimitAuto = -1
+
+// gomaxprocs returns the number of usable CPUs, it is replaced in tests.
+var gomaxprocs = func() int { return runtime.GOMAXPROCS(0) }
+
 type StdSchedulerOptions struct {
 	WorkerLimit int
 	TaskLimit   int
 	Logger      logger.Logger
+
+	// WorkerLimitFactor is multiplied by GOMAXPROCS to size the worker pool when WorkerLimit is WorkerLimitAuto.
+	// Defaults to 1.
+	WorkerLimitFactor float64
+
+	// ExcludedDates is consulted every time a recurring task fires. When it returns true for the fire time, the
+	// execution is skipped and the task waits for its next interval. Tasks can override it with Task.ExcludedDates.
+	ExcludedDates func(t time.Time) bool
+
+	// MissedFireThreshold is how late a task with Task.Debug enabled may fire before its decision trace is dumped
+	// at Warn level. Defaults to one second.
+	MissedFireThreshold time.Duration
+
+	// OnSLOBreach is called when the success ratio of a task with Task.SLO drops below its target. It is called once
+	// per breach episode, with the measured ratio.
+	OnSLOBreach func(id string, ratio float64)
+
+	// OnSLORecover is called when the success ratio of a breached task gets back above its target plus the recovery
+	// margin, ending the breach episode.
+	OnSLORecover func(id string, ratio float64)
+
+	// OnScheduleChange is called every time the scheduler decides when a task runs next: when its timer is armed or
+	// reset, with the next fire time, and when it is disarmed, with a zero time. It is called synchronously after the
+	// scheduler state reflects the change, so it must return quickly; heavy consumers should buffer the calls.
+	// Panics are recovered and logged.
+	OnScheduleChange func(id string, next time.Time, reason string)
+
+	// SaturationFireLatency is the 99th percentile of fire latency above which the scheduler is saturated. Defaults
+	// to 100 milliseconds.
+	SaturationFireLatency time.Duration
+
+	// SaturationQueueWait is the 99th percentile of worker queue wait above which the scheduler is saturated.
+	// Defaults to 100 milliseconds.
+	SaturationQueueWait time.Duration
+
+	// OverdueTolerance is how far in the past the next fire time of a task may be before it counts as overdue.
+	// Defaults to one second.
+	OverdueTolerance time.Duration
+
+	// MinFirstDelay is the minimum time between adding a task and its first execution, whatever its StartAfter and
+	// Interval. It guarantees Add returns before the task executes, so callers can finish their bookkeeping first.
+	MinFirstDelay time.Duration
+
+	// OnIDCollision is called every time a task ID is rejected because it is already in use, with the collision
+	// kind, IDCollisionGenerated or IDCollisionDuplicate. It is called synchronously from Add and AddWithID.
+	OnIDCollision func(id string, kind string)
+
+	// AuditWriter receives a record of every completed execution. Records are queued and written by a background
+	// flusher, so a slow writer does not stall executions; when the queue is full records are dropped. Stop writes
+	// the queued records before returning. See AuditStats.
+	AuditWriter AuditWriter
+
+	// AuditQueueSize is the number of records queued for the AuditWriter. Defaults to 1024.
+	AuditQueueSize int
+
+	// Lanes dedicates workers to tasks, by lane name and worker count. Executions of a task with Task.Lane set run
+	// only on the workers of its lane, which are not shared with the WorkerLimit pool or with other lanes. See Lanes.
+	Lanes map[string]int
+
+	// OrderedRunOnce fires RunOnce tasks from a single ordered queue instead of independent timers: tasks whose fire
+	// times are equal or already past start in the order they were added. Retries keep their own timers. Executions
+	// start one after the other, with a WorkerLimit of 1 they also complete in order, which makes a simple job queue.
+	// By default tasks are fired by independent timers and no order is guaranteed.
+	OrderedRunOnce bool
+
+	// DeadLetter keeps the RunOnce tasks that fail after exhausting their retries, with their last error and failed
+	// attempts, instead of dropping them. See DeadLetters and Requeue.
+	DeadLetter bool
+
+	// DeadLetterLimit is the number of dead letters kept, the oldest ones are evicted first. Defaults to 100.
+	DeadLetterLimit int
+
+	// ErrorDampingThreshold is the number of identical failures in a row, same error or same message, a task logs
+	// before the following ones are collapsed into a periodic summary. Failures are still counted, audited and
+	// dead-lettered individually, and a success ends the streak. Defaults to 5, a negative value disables damping.
+	// See Task.DampenRepeatedErrors to dampen the error functions too.
+	ErrorDampingThreshold int
+
+	// ErrorDampingInterval is how often the summary of the collapsed failures is logged. Defaults to one minute.
+	ErrorDampingInterval time.Duration
+
+	// DisablePanicRecovery lets a panic in a task function crash the process. By default the panic is recovered and
+	// the execution fails with a PanicError, delivered to the error functions and retried like any other failure.
+	// Panics in functions passed to Submit are recovered the same way.
+	DisablePanicRecovery bool
+
+	// OnHandlerPanic is called when the error function of a task, or of a submitted function, panics. The panic is
+	// recovered and logged whether it is set or not, with the task ID, empty for submitted functions.
+	OnHandlerPanic func(id string, recovered any)
+
+	// TimerStarvationThreshold is how late the internal heartbeat, expected every second, may fire before the timers
+	// of the scheduler are reported as starved by Health. Defaults to 5 seconds.
+	TimerStarvationThreshold time.Duration
 }
 
 // NewStdScheduler will create a new std scheduler instance that allows users to create and manage tasks.
 func NewStdScheduler(opts StdSchedulerOptions) *StdScheduler {
-	var taskSem chan struct{}
-
-	if opts.WorkerLimit > 0 {
-		taskSem = make(chan struct{}, opts.WorkerLimit)
+	if opts.WorkerLimit == WorkerLimitAuto {
+		opts.WorkerLimit = autoWorkerLimit(opts.WorkerLimitFactor)
 	}
 
 	if opts.Logger != nil {
-		logger.SetDefault(opts.Logger)
+		logger.SetDefaultOwned(opts.Logger)
+	}
+
+	var d *dispatcher
+	if opts.OrderedRunOnce {
+		d = newDispatcher()
 	}
 
-	return &StdScheduler{
-		taskSem: taskSem,
-		tasks:   make(map[string]*Task),
-		opts:    opts,
+	s := &StdScheduler{
+		lanes:      newLanes(opts.Lanes),
+		dispatcher: d,
+		draining:   make(chan struct{}),
+		pool:       newWorkerPool(opts.WorkerLimit),
+		tasks:      make(map[string]*Task),
+		replicas:   make(map[string]*replicaSet),
+		startedAt:  time.Now(),
+		heartbeat:  newHeartbeat(opts.TimerStarvationThreshold),
+		opts:       opts,
+	}
+
+	if opts.AuditWriter != nil {
+		s.auditor = newAuditor(opts.AuditWriter, opts.AuditQueueSize, s.reportInternalError)
+	}
+
+	return s
+}
+
+// StartedAt will return when the scheduler was created.
+func (s *StdScheduler) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// Uptime will return how long ago the scheduler was created, to interpret counters accumulated over its lifetime.
+func (s *StdScheduler) Uptime() time.Duration {
+	return time.Since(s.startedAt)
+}
+
+// autoWorkerLimit returns the worker limit derived from GOMAXPROCS and the factor, at least one worker.
+func autoWorkerLimit(factor float64) int {
+	if factor <= 0 {
+		factor = 1
+	}
+
+	limit := int(float64(gomaxprocs()) * factor)
+	if limit < 1 {
+		limit = 1
+	}
+
+	return limit
+}
+
+// WorkerLimit will return the maximum number of concurrent executions, or 0 when executions are not limited. With
+// WorkerLimitAuto it is the limit derived from GOMAXPROCS.
+func (s *StdScheduler) WorkerLimit() int {
+	if s.pool == nil {
+		return 0
 	}
+
+	return cap(s.pool.sem)
 }
 
 // Add will add a task to the task list and schedule it. Once added, tasks will wait the defined time interval and then
@@ -82,14 +341,20 @@ func NewStdScheduler(opts StdSchedulerOptions) *StdScheduler {
 //		// Do stuff
 //	}
 func (s *StdScheduler) Add(t *Task) (string, error) {
-	id := xid.New()
-	err := s.AddWithID(id.String(), t)
+	return s.addGenerated("", t)
+}
+
+// addGenerated adds the task under a generated ID with the given prefix, and returns the ID without the prefix.
+func (s *StdScheduler) addGenerated(prefix string, t *Task) (string, error) {
+	id := newID()
+	err := s.addWithID(prefix+id, t)
 	if errors.Is(err, ErrIDInUse) {
-		logger.Infof("id '%s' is already in use, another attempt to add", id.String())
+		s.recordIDCollision(prefix+id, IDCollisionGenerated)
+		logger.Debugf("id '%s' is already in use, another attempt to add", prefix+id)
 
-		return s.Add(t)
+		return s.addGenerated(prefix, t)
 	}
-	return id.String(), err
+	return id, err
 }
 
 // AddWithID will add a task with an ID to the task list and schedule it. It will return an error if the ID is in-use.
@@ -111,35 +376,142 @@ func (s *StdScheduler) Add(t *Task) (string, error) {
 //		// Do stuff
 //	}
 func (s *StdScheduler) AddWithID(id string, t *Task) error {
+	err := s.addWithID(id, t)
+	if errors.Is(err, ErrIDInUse) {
+		s.recordIDCollision(id, IDCollisionDuplicate)
+	}
+
+	return err
+}
+
+// addWithID adds the task under the given ID, collisions are accounted for by the callers.
+func (s *StdScheduler) addWithID(id string, t *Task) error {
+	// Work on a snapshot taken under the task lock, the caller may still be configuring the task concurrently
+	orig := t
+	t, err := s.prepareTask(t)
+	if err != nil {
+		return err
+	}
+
+	// A copy of a scheduled task is scheduled independently
+	reused := t.registered
+
+	// Check id is not in use, then add to task list and start background task
+	s.Lock()
+	if err := s.admit(id); err != nil {
+		s.Unlock()
+
+		return err
+	}
+
+	s.register(id, t)
+	orig.safeOps(func() {
+		orig.registered = true
+	})
+
+	if reused {
+		logger.Warnf("task (id: %s) has already been added to a scheduler, scheduling a copy", id)
+	}
+
+	// Timers are armed once the list is unlocked so that executions starting right away do not contend with the
+	// caller
+	s.Unlock()
+
+	s.scheduleTask(t)
+
+	return nil
+}
+
+// prepareTask validates a snapshot of the task and resets its scheduling state, ready to be registered.
+func (s *StdScheduler) prepareTask(t *Task) (*Task, error) {
+	t = t.Clone()
+
 	// Check if TaskFunc is nil before doing anything
-	if t.TaskFunc == nil && t.FuncWithTaskContext == nil {
-		return ErrTaskExecFunctionsNotSet
+	if t.TaskFunc == nil && t.FuncWithID == nil && t.FuncWithTaskContext == nil {
+		return nil, ErrTaskExecFunctionsNotSet
 	}
 
-	if t.ErrFunc == nil && t.ErrFuncWithTaskContext == nil {
-		return ErrTaskErrFunctionsNotSet
+	if t.ErrFunc == nil && t.ErrFuncWithID == nil && t.ErrFuncWithTaskContext == nil {
+		return nil, ErrTaskErrFunctionsNotSet
 	}
 
-	if !t.RunOnce && t.Interval <= time.Duration(0) {
-		return ErrIntervalEmpty
+	t.cron = nil
+	if t.CronExpr != "" {
+		if t.Interval > 0 {
+			return nil, ErrCronWithInterval
+		}
+
+		c, err := parseCron(t.CronExpr, t.Location)
+		if err != nil {
+			return nil, err
+		}
+		if c.next(time.Now()).IsZero() {
+			return nil, fmt.Errorf("%w %q: it never matches", ErrInvalidCronExpr, t.CronExpr)
+		}
+		t.cron = c
 	}
 
-	if t.RunOnce && t.RetriesOnError > 0 && t.RetryOnErrorInterval <= time.Duration(0) {
-		return ErrRetryOnErrorIntervalEmpty
+	if !t.RunOnce && t.Interval <= time.Duration(0) && t.cron == nil {
+		return nil, ErrIntervalEmpty
 	}
 
-	// Create Context used to cancel downstream Goroutines
-	t.ctx, t.cancel = context.WithCancel(context.Background())
+	if t.RetriesOnError > 0 && t.RetryOnErrorInterval <= time.Duration(0) && t.RetryBackoff == nil {
+		return nil, ErrRetryOnErrorIntervalEmpty
+	}
 
-	// Add id to TaskContext
-	t.TaskContext.id = id
-	if t.TaskContext.Context == nil {
-		t.TaskContext.Context, t.TaskContext.Cancel = context.WithCancel(context.Background())
+	if t.RunOnce && t.MaxRuns > 0 {
+		return nil, ErrMaxRunsWithRunOnce
+	}
+
+	if !s.hasLane(t.Lane) {
+		return nil, ErrUnknownLane
+	}
+
+	// A copy of a scheduled task carries the task context created for it. Reset it to the user context, so that
+	// cancelling one schedule does not cancel the other.
+	t.state = TaskStatePending
+	t.timer, t.deadlineTimer, t.cancelDeadline = nil, nil, nil
+	t.dispatchSeq = 0
+	t.boostTimer, t.boostInterval, t.boostUntil = nil, 0, time.Time{}
+	t.snoozeTimer, t.snoozeUntil = nil, time.Time{}
+	t.nextFire, t.retryPending, t.gapDeferred = time.Time{}, false, false
+	t.resetRetryCycle()
+	if t.ownsTaskContext {
+		t.TaskContext.Context, t.TaskContext.Cancel = t.userContext, nil
+		t.ownsTaskContext = false
+	}
+
+	if t.Debug {
+		t.trace = &decisionTrace{}
+	} else {
+		t.trace = nil
+	}
+
+	t.slo = newSLOTracker(t.SLO)
+
+	t.definition, t.failedAttempts = nil, nil
+	if s.opts.DeadLetter && t.RunOnce {
+		t.definition = t.CloneForReuse()
+	}
+
+	if !t.RunOnce && t.cron == nil && t.Timeout > t.Interval {
+		logger.Warnf("task timeout of %s is longer than its interval of %s, executions may overlap", t.Timeout,
+			t.Interval)
+	}
+
+	if t.RunOnce && !t.StartAfter.IsZero() && t.Interval > 0 {
+		logger.Warnf("task runs once at its StartAfter time, its Interval of %s is ignored", t.Interval)
+	}
+
+	return t, nil
+}
+
+// admit checks that a task can be added under the given ID. The scheduler lock must be held.
+func (s *StdScheduler) admit(id string) error {
+	if s.stopped {
+		return ErrSchedulerStopped
 	}
 
-	// Check id is not in use, then add to task list and start background task
-	s.Lock()
-	defer s.Unlock()
 	if s.opts.TaskLimit > 0 && len(s.tasks) >= s.opts.TaskLimit {
 		return ErrTaskLimitExceeded
 	}
@@ -147,58 +519,291 @@ func (s *StdScheduler) AddWithID(id string, t *Task) error {
 	if _, ok := s.tasks[id]; ok {
 		return ErrIDInUse
 	}
+
+	return nil
+}
+
+// register creates the contexts of a prepared task and adds it to the task list under the given ID, its timers are
+// armed by scheduleTask. The scheduler lock must be held.
+func (s *StdScheduler) register(id string, t *Task) {
+	// Contexts are only created once the task is sure to be added, failed calls have nothing to release. Create the
+	// context used to cancel downstream Goroutines.
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+
+	// Add id to TaskContext. Without a user cancel function, the user context is wrapped so that Del interrupts
+	// executions through the context handed to them.
+	t.TaskContext.id = id
+	if t.TaskContext.Cancel == nil {
+		t.userContext = t.TaskContext.Context
+
+		parent := t.userContext
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		t.TaskContext.Context, t.TaskContext.Cancel = context.WithCancel(parent)
+		t.ownsTaskContext = true
+	}
+
 	t.id = id
+	t.addedAt = time.Now()
+	t.registered = true
+
+	// Executions in flight when the deadline passes see their context cancelled with ErrDeadlineExceeded
+	if !t.CompleteBy.IsZero() {
+		t.TaskContext.Context, t.cancelDeadline = context.WithCancelCause(t.TaskContext.Context)
+	}
+
+	s.tasks[id] = t
+}
+
+// AddWait will add a task like Add, but waits for capacity to be freed while the task list is at the TaskLimit. It
+// returns the context error if the context is done first. Producers using the scheduler as a bounded queue of
+// RunOnce tasks can use it instead of polling Add.
+func (s *StdScheduler) AddWait(ctx context.Context, t *Task) (string, error) {
+	id := newID()
+
+	return id, s.AddWithIDWait(ctx, id, t)
+}
+
+// AddWithIDWait will add a task like AddWithID, but waits for capacity to be freed while the task list is at the
+// TaskLimit. It returns the context error if the context is done first.
+func (s *StdScheduler) AddWithIDWait(ctx context.Context, id string, t *Task) error {
+	for {
+		// Grab the broadcast before trying, so that capacity freed in between is not missed
+		s.Lock()
+		if s.capacityFreed == nil {
+			s.capacityFreed = make(chan struct{})
+		}
+		freed := s.capacityFreed
+		s.Unlock()
 
-	// To make up for bad design decisions we need to copy the task for execution
-	task := t.Clone()
+		err := s.AddWithID(id, t)
+		if !errors.Is(err, ErrTaskLimitExceeded) {
+			return err
+		}
+
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Submit will execute f once, as soon as a worker is available. It is the lightweight sibling of a RunOnce task
+// with no delay: the execution respects the WorkerLimit, but skips the task list, timers, contexts and ID
+// bookkeeping entirely. Submitted functions cannot be looked up or deleted. errF is called when f returns an error.
+//
+//	// Submit a fire and forget function
+//	err := scheduler.Submit(func() error {
+//		// Put your logic here
+//	}, func(err error) {
+//		// Put custom error handling here
+//	})
+//	if err != nil {
+//		// Do stuff
+//	}
+func (s *StdScheduler) Submit(f func() error, errF func(error)) error {
+	if f == nil {
+		return ErrTaskExecFunctionsNotSet
+	}
 
-	// Add task to schedule
-	s.tasks[t.id] = task
-	s.scheduleTask(task)
+	if errF == nil {
+		return ErrTaskErrFunctionsNotSet
+	}
+
+	s.RLock()
+	stopped := s.stopped
+	s.RUnlock()
+
+	if stopped || !s.lockSem("") {
+		return ErrSchedulerStopped
+	}
+
+	go func() {
+		defer s.unlockSem()
+
+		s.exclusive.enter(false)
+		defer s.exclusive.leave(false)
+
+		if err := s.callSubmitted(f); err != nil {
+			logger.Errorf("submitted task failed: %s", err.Error())
+			s.deliverSubmittedError(errF, err)
+		}
+	}()
 
 	return nil
 }
 
 // Del will unschedule the specified task and remove it from the task list. Deletion will prevent future invocations of
 // a task, but not interrupt a triggered task.
+//
+// Del does not wait for executions in flight, so it is safe to call from a task function, an error function or a
+// callback of the scheduler, including for the task being executed: an execution deleting its own task completes
+// normally, and no retry is armed after it.
 func (s *StdScheduler) Del(name string) {
-	// Grab task from task list
-	t, err := s.Lookup(name)
-	if err != nil {
-		return
+	s.cancelFanIn(name)
+	s.del(name, removalReasonDeleted)
+	s.forget(name)
+}
+
+// forget drops the dead letters of a task deleted like Del.
+func (s *StdScheduler) forget(name string) {
+	s.deadLetters.Lock()
+	s.deadLetters.remove(name)
+	s.deadLetters.Unlock()
+}
+
+// del removes the task, reporting the removal reason to StdSchedulerOptions.OnScheduleChange.
+func (s *StdScheduler) del(name, reason string) {
+	s.delTask(name, nil, reason)
+}
+
+// delTask removes the task like del, only when want is the incarnation registered under name if want is not nil, and
+// reports whether it removed it. The execution pipeline removes tasks through it or complete, so that an error
+// function deleting its task and adding it again under the same ID does not get the new incarnation removed with the
+// old one.
+func (s *StdScheduler) delTask(name string, want *Task, reason string) bool {
+	// Remove the scheduled task from the task list, copies returned by Lookup do not share its lock
+	s.Lock()
+	t, ok := s.tasks[name]
+	if ok && want != nil && t != want {
+		ok = false
 	}
+	if ok {
+		delete(s.tasks, name)
+		s.freeCapacity()
+	}
+	s.Unlock()
+	if !ok {
+		return false
+	}
+
+	s.teardown(name, t, reason)
 
-	// Stop the task
-	defer t.cancel()
+	return true
+}
+
+// teardown stops a task removed from the task list and reports its removal. No lock must be held.
+func (s *StdScheduler) teardown(name string, t *Task, reason string) {
+	// Tasks waiting for this one with AfterAll are told how it ended, once every lock is released
+	outcome := dependencyDeleted
+	defer func() {
+		s.dependencyDone(name, outcome)
+	}()
+
+	// Report the disarm once every lock is released
+	defer s.notifyScheduleChange(name, time.Time{}, reason)
+
+	// Stop the task. The task context is cancelled while holding the task lock, so that a firing racing with the
+	// deletion can not re-arm the timer afterwards.
 	if t.TaskContext.Cancel != nil {
 		defer t.TaskContext.Cancel()
 	}
+	if t.cancelDeadline != nil {
+		defer t.cancelDeadline(nil)
+	}
 
 	t.Lock()
 	defer t.Unlock()
 
-	if t.timer != nil {
-		defer t.timer.Stop()
+	if t.state == TaskStateCompleted {
+		outcome = dependencyFailed
+		if t.succeeded {
+			outcome = dependencySucceeded
+		}
+	}
+
+	_ = t.transition(eventRemove)
+	t.cancel()
+	t.disarm()
+	if t.deadlineTimer != nil {
+		t.deadlineTimer.Stop()
+	}
+	if t.boostTimer != nil {
+		t.boostTimer.Stop()
+	}
+	if t.snoozeTimer != nil {
+		t.snoozeTimer.Stop()
 	}
+	t.trace.record(DecisionDeleted, 0, reason)
+}
 
-	// Remove from task list
-	s.Lock()
-	defer s.Unlock()
-	delete(s.tasks, name)
+// freeCapacity wakes up the AddWait callers once a task left the task list. The scheduler lock must be held.
+func (s *StdScheduler) freeCapacity() {
+	if s.capacityFreed != nil {
+		close(s.capacityFreed)
+		s.capacityFreed = nil
+	}
+}
+
+// BindCancellation will delete the specified task when done is closed, without the caller managing a goroutine per
+// task. The deletion is reported to StdSchedulerOptions.OnScheduleChange with the reason "external". The binding is
+// released automatically when the task is deleted for any other reason.
+//
+//	// Delete the task when the watch is closed
+//	err := scheduler.BindCancellation(id, watch.Done())
+//	if err != nil {
+//		// Do stuff
+//	}
+func (s *StdScheduler) BindCancellation(id string, done <-chan struct{}) error {
+	s.RLock()
+	t, ok := s.tasks[id]
+	s.RUnlock()
+	if !ok {
+		return ErrTaskNotFound
+	}
+
+	go func() {
+		select {
+		case <-done:
+			// Both may be ready, a task that is already deleted must not take its successor with it
+			if !s.delTask(id, t, removalReasonExternal) {
+				return
+			}
+
+			logger.Infof("task (id: %s) has been removed: external cancellation", id)
+			s.cancelFanIn(id)
+			s.forget(id)
+		case <-t.ctx.Done():
+		}
+	}()
+
+	return nil
 }
 
 // Lookup will find the specified task from the internal task list using the task ID provided.
 //
-// The returned task should be treated as read-only, and not modified outside of this package. Doing so, may cause
-// panics.
+// The returned task is a read-only copy: its setters return ErrReadOnlyTask and changing its fields does not affect
+// the scheduled task. Use LookupForUpdate to edit the scheduled task.
 func (s *StdScheduler) Lookup(name string) (*Task, error) {
 	s.RLock()
 	defer s.RUnlock()
 	t, ok := s.tasks[name]
 	if ok {
-		return t.Clone(), nil
+		return t.readOnlyClone(), nil
 	}
-	return t, fmt.Errorf("could not find task within the task list")
+	return t, ErrTaskNotFound
+}
+
+// NextRun will return when the specified task is due to execute next, see Task.NextRun. It returns ErrTaskNotFound if
+// the task does not exist, and the zero time with ErrNoNextRun if no execution is pending, e.g. once a RunOnce task
+// has fired or while the task is paused.
+func (s *StdScheduler) NextRun(id string) (time.Time, error) {
+	s.RLock()
+	t, ok := s.tasks[id]
+	s.RUnlock()
+	if !ok {
+		return time.Time{}, ErrTaskNotFound
+	}
+
+	next := t.NextRun()
+	if next.IsZero() {
+		return next, ErrNoNextRun
+	}
+
+	return next, nil
 }
 
 // Has will return true if specified task is present.
@@ -211,6 +816,49 @@ func (s *StdScheduler) Has(name string) bool {
 	return ok
 }
 
+// HasAll will return the specified IDs that are not present, in the order provided, or nil if all of them are. The
+// task list is only locked once.
+func (s *StdScheduler) HasAll(ids []string) (missing []string) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := s.tasks[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
+
+// HasAny will return true if any of the specified IDs is present. The task list is only locked once.
+func (s *StdScheduler) HasAny(ids []string) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := s.tasks[id]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Exists will return whether each of the specified IDs is present. The task list is only locked once.
+func (s *StdScheduler) Exists(ids ...string) map[string]bool {
+	m := make(map[string]bool, len(ids))
+
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, id := range ids {
+		_, m[id] = s.tasks[id]
+	}
+
+	return m
+}
+
 // Tasks is used to return a copy of the internal tasks map.
 //
 // The returned task should be treated as read-only, and not modified outside of this package. Doing so, may cause
@@ -220,145 +868,793 @@ func (s *StdScheduler) Tasks() map[string]*Task {
 	defer s.RUnlock()
 	m := make(map[string]*Task)
 	for k, v := range s.tasks {
-		m[k] = v.Clone()
+		m[k] = v.readOnlyClone()
 	}
 	return m
 }
 
-// Stop is used to unschedule and delete all tasks owned by the scheduler instance.
+// Stop is used to unschedule and delete all tasks owned by the scheduler instance. Executions in flight see
+// TaskContext.Draining closed before their context is cancelled. Tasks that never executed are logged, see
+// NeverExecuted. Once stopped, adding tasks returns ErrSchedulerStopped.
+//
+// Stop does not wait for executions in flight, it waits for the queued audit records to be written, so an AuditWriter
+// must not call it. It is safe to call more than once and concurrently, and from a task function, an error function
+// or a callback of the scheduler: only the first call stops the scheduler, the calls made once it started return
+// right away without waiting for it to complete, so that a callback invoked while stopping can not wait for itself.
 func (s *StdScheduler) Stop() {
+	if s.stopping.Swap(true) {
+		return
+	}
+
+	s.stop()
+}
+
+// stop shuts the scheduler down, it is only called once. Executions in flight keep their worker until they return,
+// executions waiting for a worker give up.
+func (s *StdScheduler) stop() {
+	s.Lock()
+	s.stopped = true
+	s.Unlock()
+
+	s.drain()
+	s.reportNeverExecuted()
+	s.heartbeat.stop()
+
 	tt := s.Tasks()
 	for n := range tt {
 		s.Del(n)
 	}
 
-	if s.taskSem != nil {
-		close(s.taskSem)
+	if s.dispatcher != nil {
+		s.dispatcher.close()
+	}
+
+	// Write the records of the completed executions
+	if s.auditor != nil {
+		s.auditor.close()
 	}
 }
 
 // scheduleTask creates the underlying scheduled task. If StartAfter is set, this routine will wait until the
 // time specified.
 func (s *StdScheduler) scheduleTask(t *Task) {
-	_ = time.AfterFunc(time.Until(t.StartAfter), func() {
-		var err error
+	now := time.Now()
+
+	var (
+		start, first time.Time
+		removed      bool
+		dispatched   bool
+		held         bool
+	)
+	t.safeOps(func() {
+		// The task may have been deleted since it was added to the task list
+		if t.state == TaskStateRemoved {
+			removed = true
+			return
+		}
+
+		start = t.StartAfter
+		if start.Before(now) {
+			start = now
+		}
+
+		// Delay the arming so that the first execution does not happen before MinFirstDelay
+		delay := t.firstDelay(start)
+		if earliest := now.Add(s.opts.MinFirstDelay); start.Add(delay).Before(earliest) {
+			start = earliest.Add(-delay)
+		}
+		first = start.Add(delay)
+		t.firstFire = first
+
+		t.trace.record(DecisionTimerArmed, start.Sub(now), "start after")
+
+		if !t.CompleteBy.IsZero() {
+			t.deadlineTimer = time.AfterFunc(time.Until(t.CompleteBy), func() { s.expireTask(t) })
+		}
+
+		trigger := TriggerInterval
+		if !t.StartAfter.IsZero() {
+			trigger = TriggerStartAfter
+		}
+
+		// Tasks added while the scheduler is paused are armed by ResumeAll
+		if s.hold(t) {
+			_ = t.transition(eventArm)
+			t.nextFire, t.trigger = first, trigger
+			held = true
 
-		// Verify if task has been cancelled before scheduling
-		t.safeOps(func() {
-			err = t.ctx.Err()
-		})
-		if err != nil {
-			// Task has been cancelled, do not schedule
 			return
 		}
 
-		// Schedule task
+		// Ordered tasks are queued right away, so that tasks added one after the other are queued in that order
+		if s.dispatchesInOrder(t, trigger) && t.transition(eventArm) == nil {
+			_, dispatched = s.dispatchAt(t, first, DecisionTimerArmed, trigger)
+		}
+	})
+	if removed {
+		return
+	}
+
+	if held {
+		logger.Debugf("task (id: %s) is held until the scheduler is resumed", t.id)
+
+		return
+	}
+
+	s.notifyScheduleChange(t.id, first, "scheduled")
+
+	if dispatched {
+		logger.Debugf("task (id: %s) has been scheduled at %s", t.id, first.Format(logger.TimeFormatMicro))
+
+		return
+	}
+
+	_ = time.AfterFunc(start.Sub(now), func() {
 		t.safeOps(func() {
-			t.timer = time.AfterFunc(t.Interval, func() { s.execTask(t) })
+			// Task has been deleted, do not schedule
+			if t.transition(eventArm) != nil {
+				return
+			}
+
+			// Schedule task
+			trigger := TriggerInterval
+			if !t.StartAfter.IsZero() {
+				trigger = TriggerStartAfter
+			}
+
+			// The scheduler has been paused since the task was added
+			if s.hold(t) {
+				now := time.Now()
+				t.nextFire, t.trigger = now.Add(t.firstDelay(now)), trigger
+
+				return
+			}
+
+			s.resetTimer(t, t.firstDelay(time.Now()), DecisionTimerArmed, trigger)
 		})
 	})
 
-	logger.Debugf("task (id: %s) has been scheduled at %s", t.id, t.StartAfter.Format(time.RFC3339))
+	logger.Debugf("task (id: %s) has been scheduled at %s", t.id, first.Format(logger.TimeFormatMicro))
 }
 
 // execTask is the underlying scheduler, it is used to trigger and execute tasks.
 func (s *StdScheduler) execTask(t *Task) {
-	s.lockSem()
+	now := time.Now()
 
-	go func() {
-		defer func() { s.unlockSem() }()
+	var (
+		expected time.Time
+		state    TaskState
+		held     bool
+	)
+	t.safeOps(func() {
+		expected, state = t.nextFire, t.state
+		if state != TaskStateRemoved {
+			held = s.hold(t)
+		}
+	})
+
+	// The timer may fire while the task is being deleted
+	if state == TaskStateRemoved {
+		return
+	}
+
+	if held {
+		logger.Debugf("task (id: %s) fire is held until the scheduler is resumed", t.id)
+
+		return
+	}
 
-		var err error
-		if t.FuncWithTaskContext != nil {
-			err = t.FuncWithTaskContext(t.TaskContext)
-		} else {
-			err = t.TaskFunc()
+	if !expected.IsZero() {
+		s.fireLatency.record(now.Sub(expected))
+
+		if logger.Enabled(logger.LevelDebug) {
+			logger.Debugf("task (id: %s) fired at %s, %s after its scheduled time %s", t.id,
+				now.Format(logger.TimeFormatMicro), now.Sub(expected), expected.Format(logger.TimeFormatMicro))
 		}
+	}
 
-		deleteTask := true
+	if t.trace != nil {
+		t.trace.record(DecisionFireReceived, 0, "")
+		s.reportMissedFire(t, expected, now)
+	}
 
-		if err != nil {
-			deleteTask = onTaskError(t, err)
-		} else {
-			logger.Debugf("task (id: %s) has been successfully executed", t.id)
+	if !t.RunOnce && s.isExcluded(t, now) {
+		s.skipTask(t, skipReasonCalendar)
+
+		return
+	}
+
+	if s.deferForMinGap(t, now) {
+		return
+	}
+
+	slotted, ok := s.acquireSlot(t)
+	if !ok {
+		return
+	}
+
+	enqueued := time.Now()
+	if !s.acquireWorker(t) {
+		if slotted {
+			t.releaseSlot()
+		}
+
+		return
+	}
+	s.exclusive.enter(t.Exclusive)
+
+	var (
+		taskCtx TaskContext
+		started bool
+	)
+	t.safeOps(func() {
+		// The task may have been deleted, or the scheduler paused, while waiting for a worker
+		if s.hold(t) || t.transition(eventFire) != nil {
+			return
 		}
-		if t.RunOnce && deleteTask {
-			defer s.Del(t.id)
+		started = true
+		if n := t.inFlight.Add(1); n > t.peakInFlight {
+			t.peakInFlight = n
 		}
-	}()
-	if !t.RunOnce {
+
+		t.lastStart = time.Now()
+		t.lastRun = RunTimes{Scheduled: expected, Enqueued: enqueued, Started: t.lastStart}
+		t.runCount++
+		t.consecutiveSkips = 0
+
+		switch {
+		case !t.retryPending:
+			t.runSequence++
+			t.attempt = 1
+		case t.trigger == TriggerRetry || t.trigger == TriggerRescheduleOnError:
+			t.attempt++
+		}
+		t.retryPending = false
+
+		taskCtx = t.TaskContext
+		taskCtx.runSequence = t.runSequence
+		taskCtx.attempt = t.attempt
+		taskCtx.runID = xid.New()
+		taskCtx.trigger = t.trigger
+		taskCtx.checkpoint = t.checkpoint
+		taskCtx.runTimes = t.lastRun
+		taskCtx.draining = s.draining
+		taskCtx.dryRun = t.DryRun
+		taskCtx.finalRun = t.MaxRuns > 0 && t.runSequence >= uint64(t.MaxRuns)
+		taskCtx.slotted = slotted
+	})
+
+	if !started {
+		s.exclusive.leave(t.Exclusive)
+		s.releaseWorker(t)
+		if slotted {
+			t.releaseSlot()
+		}
+
+		return
+	}
+
+	go s.runTask(t, taskCtx)
+
+	// The last execution allowed by MaxRuns is not followed by another one, FixedDelay tasks are re-armed once the
+	// execution returns
+	if !t.RunOnce && !taskCtx.finalRun && t.Mode != FixedDelay {
+		var (
+			next  time.Time
+			armed bool
+		)
 		t.safeOps(func() {
-			t.timer.Reset(t.Interval)
+			next, armed = s.resetTimer(t, t.interval(), DecisionTimerArmed, TriggerInterval)
 		})
+		if armed {
+			s.notifyScheduleChange(t.id, next, TriggerInterval.String())
+		}
 	}
 }
 
-func (s *StdScheduler) lockSem() {
-	if s.taskSem != nil {
-		s.taskSem <- struct{}{}
+// expireTask removes a task that did not complete by Task.CompleteBy, cancelling any pending retry, and delivers
+// ErrDeadlineExceeded to its error functions.
+func (s *StdScheduler) expireTask(t *Task) {
+	// The task is only expired while it is still registered and has not completed, a task completing, removed or
+	// replaced at the same moment does not get a deadline error
+	s.Lock()
+	expired := s.tasks[t.id] == t
+	if expired {
+		switch t.State() {
+		case TaskStateCompleted, TaskStateRemoved:
+			expired = false
+		default:
+			delete(s.tasks, t.id)
+			s.freeCapacity()
+		}
+	}
+	s.Unlock()
+	if !expired {
+		return
 	}
+
+	logger.Errorf("task (id: %s) has been removed: %s", t.id, ErrDeadlineExceeded.Error())
+	t.cancelDeadline(ErrDeadlineExceeded)
+	s.teardown(t.id, t, removalReasonDeadline)
+
+	go s.deliverError(t, t.TaskContext, ErrDeadlineExceeded)
 }
 
-func (s *StdScheduler) unlockSem() {
-	if s.taskSem != nil {
-		<-s.taskSem
+// skipTask skips the current firing of a recurring task and waits for its next interval. The task is removed instead
+// once it reaches Task.MaxConsecutiveSkips.
+func (s *StdScheduler) skipTask(t *Task, reason string) {
+	logger.Debugf("task (id: %s) has been skipped: %s", t.id, reason)
+
+	var (
+		next           time.Time
+		armed, removed bool
+		skipped        int
+	)
+	t.safeOps(func() {
+		if t.transition(eventSkip) != nil {
+			return
+		}
+
+		t.trace.record(DecisionSkipped, 0, reason)
+		t.retryPending = false
+		t.consecutiveSkips++
+		skipped = t.consecutiveSkips
+
+		if t.MaxConsecutiveSkips > 0 && skipped >= t.MaxConsecutiveSkips {
+			removed = true
+			return
+		}
+
+		next, armed = s.resetTimer(t, t.interval(), DecisionTimerArmed, TriggerInterval)
+	})
+
+	if removed {
+		logger.Infof("task (id: %s) has been removed after %d consecutive skips", t.id, skipped)
+		s.delTask(t.id, t, removalReasonSkipLimit)
+
+		return
+	}
+
+	if armed {
+		s.notifyScheduleChange(t.id, next, TriggerInterval.String())
 	}
 }
 
-func onTaskError(t *Task, err error) (deleteTask bool) {
-	if rescheduleExists := rescheduleTaskOnError(t, err); rescheduleExists {
-		return deleteTask
+// deferForMinGap reports whether an execution requested at now falls within Task.MinGap of the previous start. Such
+// executions are deferred to the end of the gap, and any further request within the gap is coalesced into it.
+func (s *StdScheduler) deferForMinGap(t *Task, now time.Time) bool {
+	if t.MinGap <= 0 {
+		return false
 	}
 
-	logger.Errorf("task (id: %s, retries left: %d) failed: %s", t.id, t.RetriesOnError, err.Error())
+	var wait time.Duration
+	var deferred bool
+	t.safeOps(func() {
+		if t.lastStart.IsZero() {
+			return
+		}
+
+		wait = t.MinGap - now.Sub(t.lastStart)
+		if wait <= 0 {
+			return
+		}
 
-	if t.ErrFuncWithTaskContext != nil {
-		go t.ErrFuncWithTaskContext(t.TaskContext, err)
-	} else {
-		go t.ErrFunc(err)
+		deferred = true
+		if t.gapDeferred {
+			wait = 0
+			return
+		}
+		t.gapDeferred = true
+	})
+	if !deferred {
+		return false
 	}
 
-	if t.RunOnce && t.RetriesOnError > 0 {
-		deleteTask = false
+	if wait == 0 {
+		logger.Debugf("task (id: %s) execution has been coalesced into the pending one", t.id)
 
+		return true
+	}
+
+	logger.Debugf("task (id: %s) execution has been deferred by %s to respect the minimum gap", t.id, wait)
+	t.trace.record(DecisionTimerArmed, wait, "min gap")
+	s.notifyScheduleChange(t.id, now.Add(wait), "min gap")
+
+	time.AfterFunc(wait, func() {
 		t.safeOps(func() {
-			t.RetriesOnError--
-			t.timer.Reset(t.RetryOnErrorInterval)
+			t.gapDeferred = false
 		})
+
+		s.execTask(t)
+	})
+
+	return true
+}
+
+// isExcluded reports whether the fire time lands on a date excluded by the task or by the scheduler options.
+func (s *StdScheduler) isExcluded(t *Task, at time.Time) bool {
+	if t.ExcludedDates != nil {
+		return t.ExcludedDates(at)
+	}
+
+	if s.opts.ExcludedDates != nil {
+		return s.opts.ExcludedDates(at)
+	}
+
+	return false
+}
+
+// runTask calls the task function and handles its result. It is the body of the execution goroutine and avoids
+// allocations on the success path when debug logging is disabled.
+func (s *StdScheduler) runTask(t *Task, taskCtx TaskContext) {
+	defer s.releaseWorker(t)
+	defer s.exclusive.leave(t.Exclusive)
+	defer t.endFlight()
+	if taskCtx.slotted {
+		defer t.releaseSlot()
+	}
+
+	if s.bypassesWorkers(t) {
+		t.trace.record(DecisionExecutionStarted, 0, "bypass worker limit")
 	} else {
+		t.trace.record(DecisionExecutionStarted, 0, "")
+	}
+
+	// Every execution runs with a context of its own, so that cancelling it does not affect the following ones. The
+	// error functions get the task context, with the Cancel of the execution. Functions without a task context have
+	// nothing to cancel.
+	runCtx := taskCtx
+	if t.hasRunContext() {
+		runCtx.Context, runCtx.Cancel = context.WithCancel(taskCtx.Context)
+		defer func() { runCtx.Cancel() }()
+		taskCtx.Cancel = runCtx.Cancel
+	}
+
+	if taskCtx.dryRun {
+		dryRun(runCtx, t.DryRunDuration)
+		t.endRun(taskCtx.runTimes.Started, nil)
+		s.audit(t, taskCtx, nil, false)
+
+		t.trace.record(DecisionExecutionFinished, 0, "dry run")
+		logger.Debugf("task (id: %s, run: %s) has been successfully executed (dry run)", t.id, taskCtx.runID)
+
+		if state := t.finish(true); t.RunOnce || taskCtx.finalRun || state == TaskStateRemoved {
+			s.complete(t)
+
+			return
+		}
+		s.armAfterRun(t, taskCtx)
+
+		return
+	}
+
+	var (
+		err        error
+		deleteTask bool
+		cancelled  bool
+	)
+	for {
+		err = s.callAttempt(t, &taskCtx, &runCtx)
+
+		if errors.Is(err, ErrYielded) {
+			s.yieldTask(t, taskCtx)
+
+			return
+		}
+
+		if t.trace != nil {
+			if err != nil {
+				t.trace.record(DecisionExecutionFinished, 0, "error")
+			} else {
+				t.trace.record(DecisionExecutionFinished, 0, "success")
+			}
+		}
+
+		s.recordSLO(t, err == nil)
+
 		deleteTask = true
+		if err == nil {
+			break
+		}
+
+		var (
+			inline bool
+			delay  time.Duration
+		)
+		if deleteTask, inline, delay = s.onTaskError(t, taskCtx, err); !inline {
+			break
+		}
+
+		// Each inline attempt is accounted for like an execution of its own
+		t.endRun(taskCtx.runTimes.Started, err)
+		s.audit(t, taskCtx, err, true)
+
+		if cancelled = !s.retryInline(t, &taskCtx, &runCtx, delay); cancelled {
+			break
+		}
+	}
+
+	if cancelled {
+		// The task was deleted during the retry delay, the last attempt has already been accounted for
+		t.finish(false)
+		s.complete(t)
+
+		return
+	}
+
+	if err == nil {
+		t.commitCheckpoint(taskCtx.pendingCheckpoint)
+		t.resetDamping()
+		t.safeOps(t.resetRetryCycle)
+
+		if logger.Enabled(logger.LevelDebug) {
+			logger.Debugf("task (id: %s, run: %s) has been successfully executed, started at %s, took %s", t.id,
+				taskCtx.runID, taskCtx.runTimes.Started.Format(logger.TimeFormatMicro),
+				time.Since(taskCtx.runTimes.Started))
+		}
+	}
+
+	t.endRun(taskCtx.runTimes.Started, err)
+	s.audit(t, taskCtx, err, err != nil && !deleteTask)
+
+	state := t.finish(err == nil)
+
+	if ((t.RunOnce || taskCtx.finalRun) && deleteTask) || state == TaskStateRemoved {
+		if err != nil && deleteTask && (t.RunOnce || taskCtx.finalRun) {
+			go s.deliverExhausted(t, taskCtx, err)
+		}
+		s.complete(t)
+
+		return
+	}
+	s.armAfterRun(t, taskCtx)
+}
+
+// callAttempt calls the task function once with the execution context, and waits for the goroutines it started.
+func (s *StdScheduler) callAttempt(t *Task, taskCtx, runCtx *TaskContext) error {
+	if t.FuncWithTaskContext == nil {
+		return s.callFunc(t, *runCtx)
+	}
+
+	taskCtx.pendingCheckpoint = &runCheckpoint{}
+	runCtx.pendingCheckpoint = taskCtx.pendingCheckpoint
+
+	// The timeout applies to this attempt only, the error functions get the task context
+	attemptCtx := *runCtx
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx.Context, cancel = context.WithTimeout(runCtx.Context, t.Timeout)
+		defer cancel()
+	}
+
+	// Goroutines started with TaskContext.Go are part of the execution
+	attemptCtx.group = newRunGroup(attemptCtx.Context)
+	err := s.callFunc(t, attemptCtx)
+	if groupErr := attemptCtx.group.wait(); err == nil {
+		err = groupErr
+	}
+
+	return err
+}
+
+// retryInline waits for delay on the execution goroutine, then starts the next attempt of a task with InlineRetries.
+// It returns false when the task has been deleted in the meantime.
+func (s *StdScheduler) retryInline(t *Task, taskCtx, runCtx *TaskContext, delay time.Duration) bool {
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-t.ctx.Done():
+			timer.Stop()
+
+			return false
+		}
+	} else if t.ctx.Err() != nil {
+		return false
+	}
+
+	var retried bool
+	t.safeOps(func() {
+		if t.state == TaskStateRemoved {
+			return
+		}
+		retried = true
+
+		t.lastStart = time.Now()
+		t.lastRun = RunTimes{Scheduled: t.lastStart, Enqueued: t.lastStart, Started: t.lastStart}
+		t.runCount++
+		t.attempt++
+
+		taskCtx.runID = xid.New()
+		taskCtx.attempt = t.attempt
+		taskCtx.trigger = TriggerRetry
+		taskCtx.runTimes = t.lastRun
+	})
+	if !retried {
+		return false
+	}
+
+	// Like executions, attempts have a context of their own
+	if t.hasRunContext() {
+		runCtx.Cancel()
+		runCtx.Context, runCtx.Cancel = context.WithCancel(taskCtx.Context)
+		taskCtx.Cancel = runCtx.Cancel
+	}
+	runCtx.runID, runCtx.trigger, runCtx.runTimes = taskCtx.runID, taskCtx.trigger, taskCtx.runTimes
+	runCtx.attempt = taskCtx.attempt
+	t.trace.record(DecisionExecutionStarted, 0, "inline retry")
+
+	return true
+}
+
+// hasRunContext reports whether the executions of the task get a context of their own, which only functions with a
+// task context can see.
+func (t *Task) hasRunContext() bool {
+	return t.FuncWithTaskContext != nil || t.ErrFuncWithTaskContext != nil || t.ExhaustedFuncWithTaskContext != nil
+}
+
+// dryRun simulates the load of an execution by waiting for d, or until the task is cancelled.
+func dryRun(taskCtx TaskContext, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-taskCtx.Context.Done():
+	}
+}
+
+// resetTimer arms or re-arms the task timer to fire after d for the trigger, records the decision and returns the next
+// fire time. It returns false without arming the timer when the task has been deleted. Callers must hold the task
+// lock, and report the change with notifyScheduleChange once they released it.
+func (s *StdScheduler) resetTimer(t *Task, d time.Duration, decision Decision, trigger Trigger) (time.Time, bool) {
+	if t.state == TaskStateRemoved {
+		return time.Time{}, false
+	}
+
+	at := time.Now().Add(d)
+
+	// Cron fires are on whole seconds, the delay was computed from a slightly earlier time
+	if t.cron != nil && (trigger == TriggerInterval || trigger == TriggerStartAfter) && !t.boosted(at) {
+		at = at.Round(time.Second)
+	}
+
+	if s.dispatchesInOrder(t, trigger) {
+		return s.dispatchAt(t, at, decision, trigger)
 	}
 
-	return deleteTask
+	// The timer is created by the first arm, whichever path it comes from
+	if t.timer == nil {
+		t.timer = time.AfterFunc(d, func() { s.execTask(t) })
+	} else {
+		t.timer.Reset(d)
+	}
+	t.nextFire = at
+	t.trigger = trigger
+	t.trace.record(decision, d, trigger.String())
+
+	return t.nextFire, true
 }
 
-func rescheduleTaskOnError(t *Task, err error) (exists bool) {
-	if len(t.rescheduleOnError) == 0 {
-		return exists
+// notifyScheduleChange reports a scheduling decision to StdSchedulerOptions.OnScheduleChange.
+func (s *StdScheduler) notifyScheduleChange(id string, next time.Time, reason string) {
+	if s.opts.OnScheduleChange == nil {
+		return
 	}
 
-	for e, opts := range t.rescheduleOnError {
-		if !errors.Is(err, e) {
-			continue
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("task (id: %s) schedule change callback panicked: %v", id, r)
+			s.reportInternalError(InternalErrorCallbackPanic,
+				fmt.Errorf("task (id: %s) schedule change callback panicked: %v", id, r))
 		}
+	}()
+
+	s.opts.OnScheduleChange(id, next, reason)
+}
 
-		exists = true
+// onTaskError handles a failed execution, and reports whether the task is done with. For tasks with InlineRetries,
+// it reports the delay before the retry to run on the execution goroutine instead of arming the timer.
+func (s *StdScheduler) onTaskError(t *Task, taskCtx TaskContext, err error) (deleteTask, inline bool,
+	delay time.Duration) {
+	s.recordFailedAttempt(t, taskCtx, err)
+
+	// A matching rule with no reschedule left ends the task, like exhausted retries
+	if matched, rescheduled := s.rescheduleTaskOnError(t, err); matched {
+		return !rescheduled, false, 0
+	}
+
+	// The retry budget is read and consumed in one go, so that concurrent failures are accounted for one at a time
+	var (
+		retries int
+		next    time.Time
+		armed   bool
+	)
+	t.safeOps(func() {
+		retries = t.retriesRemaining
+		if retries <= 0 {
+			// The failing cycle has used its retries, the next failure starts a new one
+			t.resetRetryCycle()
+
+			return
+		}
+
+		// Inline retries keep the execution running
+		if t.InlineRetries {
+			if t.state == TaskStateRemoved {
+				return
+			}
+		} else if t.transition(eventRetry) != nil {
+			return
+		}
+
+		t.retriesRemaining--
+		t.retryAttempts++
+		if t.InlineRetries {
+			inline, delay = true, t.retryDelay()
+			t.trace.record(DecisionRetryArmed, delay, "inline")
+
+			return
+		}
+		t.retryPending = true
+		next, armed = s.resetTimer(t, t.retryDelay(), DecisionRetryArmed, TriggerRetry)
+	})
+
+	s.notifyFailure(t, taskCtx, err, retries)
+
+	if retries <= 0 {
+		s.deadLetter(t, err)
+
+		return true, false, 0
+	}
+
+	if armed {
+		s.notifyScheduleChange(t.id, next, TriggerRetry.String())
+	}
+
+	return false, inline, delay
+}
+
+func (s *StdScheduler) rescheduleTaskOnError(t *Task, err error) (exists, armed bool) {
+	var (
+		next time.Time
+		left int
+	)
+
+	// Executions of a recurring task may overlap, the reschedule rules are only read and updated under the task lock
+	t.safeOps(func() {
+		for i := range t.rescheduleOnError {
+			r := &t.rescheduleOnError[i]
+			if !r.matches(err) {
+				continue
+			}
+
+			exists = true
+
+			if r.count <= 0 || t.transition(eventRetry) != nil {
+				break
+			}
+
+			r.count--
+			t.retryPending = true
+			left = r.count
+
+			next, armed = s.resetTimer(t, r.interval, DecisionRetryArmed, TriggerRescheduleOnError)
 
-		if opts.count <= 0 {
 			break
 		}
+	})
 
-		opts.count--
-		t.safeOps(func() {
-			t.timer.Reset(opts.interval)
-			t.rescheduleOnError[e] = opts
-		})
+	if armed {
+		s.notifyScheduleChange(t.id, next, TriggerRescheduleOnError.String())
 
 		logger.Infof("task (id: %s) has been rescheduled on error: %s, reschedules left: %d",
-			t.id, err.Error(), opts.count)
-
-		exists = true
+			t.id, err.Error(), left)
 	}
 
-	return exists
+	return exists, armed
 }
@@ -0,0 +1,138 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AuditSchemaVersion is the version of the JSON form of AuditRecord, written in its schema_version field. Records
+// without the field were written before it was versioned and are read as version 0, which has the same fields.
+const AuditSchemaVersion = 1
+
+// parseEnum returns the value of the enum up to last whose String is s.
+func parseEnum[T ~int](kind, s string, last T) (T, error) {
+	for v := T(0); v <= last; v++ {
+		if fmt.Sprint(v) == s {
+			return v, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown %s %q", kind, s)
+}
+
+// MarshalText encodes the trigger as its name, so that adding triggers does not shift encoded values.
+func (tr Trigger) MarshalText() ([]byte, error) {
+	return []byte(tr.String()), nil
+}
+
+// UnmarshalText decodes a trigger encoded by MarshalText.
+func (tr *Trigger) UnmarshalText(b []byte) error {
+	v, err := parseEnum("trigger", string(b), TriggerRescheduleOnError)
+	if err != nil {
+		return err
+	}
+	*tr = v
+
+	return nil
+}
+
+// MarshalText encodes the state as its name, so that adding states does not shift encoded values.
+func (st TaskState) MarshalText() ([]byte, error) {
+	return []byte(st.String()), nil
+}
+
+// UnmarshalText decodes a state encoded by MarshalText.
+func (st *TaskState) UnmarshalText(b []byte) error {
+	v, err := parseEnum("task state", string(b), TaskStateRemoved)
+	if err != nil {
+		return err
+	}
+	*st = v
+
+	return nil
+}
+
+// MarshalText encodes the decision as its name, so that adding decisions does not shift encoded values.
+func (d Decision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a decision encoded by MarshalText.
+func (d *Decision) UnmarshalText(b []byte) error {
+	v, err := parseEnum("decision", string(b), DecisionDeleted)
+	if err != nil {
+		return err
+	}
+	*d = v
+
+	return nil
+}
+
+// auditRecordJSON is the JSON form of an AuditRecord.
+type auditRecordJSON struct {
+	SchemaVersion int       `json:"schema_version"`
+	TaskID        string    `json:"task_id"`
+	RunSequence   uint64    `json:"run_sequence"`
+	Scheduled     time.Time `json:"scheduled"`
+	Enqueued      time.Time `json:"enqueued"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	Outcome       string    `json:"outcome"`
+	Error         string    `json:"error,omitempty"`
+	Trigger       Trigger   `json:"trigger"`
+	DryRun        bool      `json:"dry_run,omitempty"`
+}
+
+// MarshalJSON encodes the record in the versioned form described by AuditSchemaVersion. The error is encoded as its
+// message and the trigger as its name.
+func (r AuditRecord) MarshalJSON() ([]byte, error) {
+	rec := auditRecordJSON{
+		SchemaVersion: AuditSchemaVersion,
+		TaskID:        r.TaskID,
+		RunSequence:   r.RunSequence,
+		Scheduled:     r.Scheduled,
+		Enqueued:      r.Enqueued,
+		Start:         r.Start,
+		End:           r.End,
+		Outcome:       r.Outcome,
+		Trigger:       r.Trigger,
+		DryRun:        r.DryRun,
+	}
+	if r.Err != nil {
+		rec.Error = r.Err.Error()
+	}
+
+	return json.Marshal(rec)
+}
+
+// UnmarshalJSON decodes a record encoded by MarshalJSON, by this or an earlier schema version. Unknown fields are
+// ignored. The error is restored from its message, it no longer matches the original error with errors.Is.
+func (r *AuditRecord) UnmarshalJSON(b []byte) error {
+	var rec auditRecordJSON
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return err
+	}
+
+	if rec.SchemaVersion > AuditSchemaVersion {
+		return fmt.Errorf("audit record schema version %d is newer than %d", rec.SchemaVersion, AuditSchemaVersion)
+	}
+
+	*r = AuditRecord{
+		TaskID:      rec.TaskID,
+		RunSequence: rec.RunSequence,
+		Scheduled:   rec.Scheduled,
+		Enqueued:    rec.Enqueued,
+		Start:       rec.Start,
+		End:         rec.End,
+		Outcome:     rec.Outcome,
+		Trigger:     rec.Trigger,
+		DryRun:      rec.DryRun,
+	}
+	if rec.Error != "" {
+		r.Err = errors.New(rec.Error)
+	}
+
+	return nil
+}
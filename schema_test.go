@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestAuditRecordJSON(t *testing.T) {
+	start := time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC)
+
+	t.Run("Verify records round-trip", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		r := AuditRecord{
+			TaskID:      "task",
+			RunSequence: 4,
+			Scheduled:   start.Add(-time.Second),
+			Enqueued:    start.Add(-time.Millisecond),
+			Start:       start,
+			End:         start.Add(time.Second),
+			Outcome:     AuditOutcomeRetry,
+			Err:         errors.New("failed"),
+			Trigger:     TriggerRescheduleOnError,
+			DryRun:      true,
+		}
+
+		b, err := json.Marshal(r)
+		assert.NoError(err)
+		assert.Contains(string(b), `"schema_version":1`)
+		assert.Contains(string(b), `"trigger":"reschedule on error"`)
+
+		var decoded AuditRecord
+		assert.NoError(json.Unmarshal(b, &decoded))
+		assert.EqualError(decoded.Err, "failed")
+		decoded.Err, r.Err = nil, nil
+		assert.Equal(r, decoded)
+	})
+
+	t.Run("Verify records of the unversioned schema are read", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		// Written by the JSON lines writer before the schema was versioned, with a field unknown to this version
+		fixture := `{"task_id":"legacy","run_sequence":2,"start":"2024-01-10T10:30:00Z",` +
+			`"end":"2024-01-10T10:30:01Z","outcome":"failure","error":"boom","trigger":"retry","host":"a"}`
+
+		var r AuditRecord
+		assert.NoError(json.Unmarshal([]byte(fixture), &r))
+		assert.Equal("legacy", r.TaskID)
+		assert.Equal(uint64(2), r.RunSequence)
+		assert.Equal(start, r.Start)
+		assert.Equal(start.Add(time.Second), r.End)
+		assert.Equal(AuditOutcomeFailure, r.Outcome)
+		assert.EqualError(r.Err, "boom")
+		assert.Equal(TriggerRetry, r.Trigger)
+		assert.True(r.Scheduled.IsZero())
+	})
+
+	t.Run("Verify records of a newer schema are rejected", func(t *testing.T) {
+		var r AuditRecord
+		assertions.Error(t, json.Unmarshal([]byte(`{"schema_version":2,"task_id":"next"}`), &r))
+	})
+}
+
+func TestEnumText(t *testing.T) {
+	assert := assertions.New(t)
+
+	for tr := TriggerInterval; tr <= TriggerRescheduleOnError; tr++ {
+		b, err := json.Marshal(tr)
+		assert.NoError(err)
+		assert.Equal(`"`+tr.String()+`"`, string(b))
+
+		var decoded Trigger
+		assert.NoError(json.Unmarshal(b, &decoded))
+		assert.Equal(tr, decoded)
+	}
+
+	for st := TaskStatePending; st <= TaskStateRemoved; st++ {
+		b, err := json.Marshal(st)
+		assert.NoError(err)
+		assert.Equal(`"`+st.String()+`"`, string(b))
+
+		var decoded TaskState
+		assert.NoError(json.Unmarshal(b, &decoded))
+		assert.Equal(st, decoded)
+	}
+
+	for d := DecisionTimerArmed; d <= DecisionDeleted; d++ {
+		b, err := json.Marshal(DecisionRecord{Decision: d, Reason: "r"})
+		assert.NoError(err)
+		assert.Contains(string(b), `"decision":"`+d.String()+`"`)
+
+		var rec DecisionRecord
+		assert.NoError(json.Unmarshal(b, &rec))
+		assert.Equal(d, rec.Decision)
+	}
+
+	var tr Trigger
+	assert.Error(json.Unmarshal([]byte(`"sometimes"`), &tr))
+	var st TaskState
+	assert.Error(json.Unmarshal([]byte(`"asleep"`), &st))
+}
@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"strings"
+)
+
+// Scheduler is the set of task operations shared by StdScheduler and the views returned by Scoped.
+type Scheduler interface {
+	Add(t *Task) (string, error)
+	AddWithID(id string, t *Task) error
+	Del(id string)
+	Lookup(id string) (*Task, error)
+	Has(id string) bool
+	Tasks() map[string]*Task
+	Stop()
+	Scoped(prefix string) Scheduler
+}
+
+var (
+	_ Scheduler = (*StdScheduler)(nil)
+	_ Scheduler = (*ScopedScheduler)(nil)
+)
+
+// ScopedScheduler is a view of a StdScheduler restricted to the tasks whose ID starts with a prefix, see
+// StdScheduler.Scoped.
+type ScopedScheduler struct {
+	s      *StdScheduler
+	prefix string
+}
+
+// Scoped will return a view of the scheduler restricted to the tasks whose ID starts with prefix, to hand subsystems
+// a scheduler they can stop without stopping the others. The view is a cheap handle, the tasks are still run by the
+// scheduler and share its worker and task limits.
+//
+// IDs passed to and returned by the view are relative to it: the view adds the prefix to them, so a task added with
+// ID "sync" to the view scoped "billing/" is scheduled as "billing/sync". Task functions receive the full ID.
+//
+//	billing := scheduler.Scoped("billing/")
+//	id, err := billing.Add(task)
+//	// ...
+//	billing.Stop() // Only deletes the billing tasks
+func (s *StdScheduler) Scoped(prefix string) Scheduler {
+	return &ScopedScheduler{s: s, prefix: prefix}
+}
+
+// Scoped will return a view restricted to the tasks of this view whose ID starts with prefix.
+func (v *ScopedScheduler) Scoped(prefix string) Scheduler {
+	return &ScopedScheduler{s: v.s, prefix: v.prefix + prefix}
+}
+
+// Add will add a task like StdScheduler.Add, under a generated ID within the view.
+func (v *ScopedScheduler) Add(t *Task) (string, error) {
+	return v.s.addGenerated(v.prefix, t)
+}
+
+// AddWithID will add a task like StdScheduler.AddWithID, under the given ID within the view.
+func (v *ScopedScheduler) AddWithID(id string, t *Task) error {
+	return v.s.AddWithID(v.prefix+id, t)
+}
+
+// Del will delete the task with the given ID within the view, like StdScheduler.Del.
+func (v *ScopedScheduler) Del(id string) {
+	v.s.Del(v.prefix + id)
+}
+
+// Lookup will find the task with the given ID within the view, like StdScheduler.Lookup.
+func (v *ScopedScheduler) Lookup(id string) (*Task, error) {
+	return v.s.Lookup(v.prefix + id)
+}
+
+// Has will return true if the task with the given ID within the view is present.
+func (v *ScopedScheduler) Has(id string) bool {
+	return v.s.Has(v.prefix + id)
+}
+
+// Tasks will return read-only copies of the tasks of the view, keyed by their ID within the view.
+func (v *ScopedScheduler) Tasks() map[string]*Task {
+	v.s.RLock()
+	defer v.s.RUnlock()
+
+	m := make(map[string]*Task)
+	for k, t := range v.s.tasks {
+		if id, ok := strings.CutPrefix(k, v.prefix); ok {
+			m[id] = t.readOnlyClone()
+		}
+	}
+
+	return m
+}
+
+// Stop will delete the tasks of the view. Other tasks and the scheduler itself keep running.
+func (v *ScopedScheduler) Stop() {
+	v.s.RLock()
+	var ids []string
+	for k := range v.s.tasks {
+		if strings.HasPrefix(k, v.prefix) {
+			ids = append(ids, k)
+		}
+	}
+	v.s.RUnlock()
+
+	for _, id := range ids {
+		v.s.Del(id)
+	}
+}
@@ -0,0 +1,115 @@
+package tasks
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestScoped(t *testing.T) {
+	t.Run("Verify views only see their own tasks", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		billing := scheduler.Scoped("billing/")
+		reports := scheduler.Scoped("reports/")
+
+		newTask := func() *Task {
+			return &Task{
+				Interval: time.Hour,
+				FuncWithID: func(id string) error {
+					return nil
+				},
+				ErrFunc: func(e error) {},
+			}
+		}
+
+		assert.NoError(billing.AddWithID("sync", newTask()))
+		id, err := reports.Add(newTask())
+		assert.NoError(err)
+
+		assert.True(scheduler.Has("billing/sync"))
+		assert.True(scheduler.Has("reports/" + id))
+
+		assert.True(billing.Has("sync"))
+		assert.False(reports.Has("sync"))
+		assert.True(reports.Has(id))
+
+		_, err = billing.Lookup("sync")
+		assert.NoError(err)
+		_, err = reports.Lookup("sync")
+		assert.ErrorIs(err, ErrTaskNotFound)
+
+		tasks := billing.Tasks()
+		assert.Len(tasks, 1)
+		assert.Contains(tasks, "sync")
+
+		// Deleting through another view does nothing
+		reports.Del("sync")
+		assert.True(billing.Has("sync"))
+
+		// Nested views add their prefix
+		assert.NoError(billing.Scoped("nightly/").AddWithID("close", newTask()))
+		assert.True(scheduler.Has("billing/nightly/close"))
+		assert.True(billing.Has("nightly/close"))
+	})
+
+	t.Run("Verify stopping a view leaves the others running within the shared limits", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+
+		var running, overlaps atomic.Int32
+		runs := map[string]*atomic.Int32{"first/": {}, "second/": {}}
+
+		var wg sync.WaitGroup
+		for prefix, counter := range runs {
+			view := scheduler.Scoped(prefix)
+			counter := counter
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for i := 0; i < 5; i++ {
+					_, err := view.Add(&Task{
+						Interval: 5 * time.Millisecond,
+						TaskFunc: func() error {
+							if running.Add(1) > 1 {
+								overlaps.Add(1)
+							}
+							counter.Add(1)
+							time.Sleep(time.Millisecond)
+							running.Add(-1)
+							return nil
+						},
+						ErrFunc: func(e error) {},
+					})
+					assert.NoError(err)
+				}
+			}()
+		}
+		wg.Wait()
+		t.Cleanup(func() { scheduler.Scoped("").Stop() })
+
+		assert.Eventually(func() bool { return runs["first/"].Load() > 5 }, time.Second, time.Millisecond)
+
+		scheduler.Scoped("first/").Stop()
+		assert.Empty(scheduler.Scoped("first/").Tasks())
+		assert.Len(scheduler.Scoped("second/").Tasks(), 5)
+
+		// Let executions in flight end
+		time.Sleep(20 * time.Millisecond)
+		stopped, before := runs["first/"].Load(), runs["second/"].Load()
+
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(stopped, runs["first/"].Load())
+		assert.Greater(runs["second/"].Load(), before)
+		assert.Zero(overlaps.Load())
+	})
+}
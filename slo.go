@@ -0,0 +1,171 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// sloBuckets is the number of buckets the SLO window is split into.
+const sloBuckets = 60
+
+// SLO defines the minimum ratio of successful executions a task must achieve over a sliding window.
+type SLO struct {
+	// SuccessRatio is the target ratio of successful executions, between 0 and 1.
+	SuccessRatio float64
+
+	// Window is the sliding window the ratio is measured over.
+	Window time.Duration
+
+	// RecoveryMargin is added to SuccessRatio to decide when a breach is over. It avoids flapping between breached
+	// and recovered when the measured ratio hovers around the target.
+	RecoveryMargin float64
+}
+
+// sloBucket counts executions that finished within a slice of the SLO window.
+type sloBucket struct {
+	start   int64
+	success int
+	total   int
+}
+
+// sloTracker measures the success ratio of a task and tracks breach episodes.
+type sloTracker struct {
+	sync.Mutex
+
+	slo      SLO
+	width    int64
+	buckets  [sloBuckets]sloBucket
+	breached bool
+}
+
+// newSLOTracker returns a tracker for the SLO, or nil when the SLO is not set.
+func newSLOTracker(slo *SLO) *sloTracker {
+	if slo == nil || slo.Window <= 0 {
+		return nil
+	}
+
+	width := int64(slo.Window) / sloBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	return &sloTracker{slo: *slo, width: width}
+}
+
+// record adds an execution outcome and returns the measured ratio together with the breach state transition: the
+// breach flag is only meaningful when changed is true.
+func (s *sloTracker) record(now time.Time, success bool) (ratio float64, breached, changed bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	at := now.UnixNano()
+	start := at - at%s.width
+	b := &s.buckets[(start/s.width)%sloBuckets]
+	if b.start != start {
+		*b = sloBucket{start: start}
+	}
+
+	b.total++
+	if success {
+		b.success++
+	}
+
+	var successes, total int
+	for _, b := range s.buckets {
+		if b.start > at-int64(s.slo.Window) {
+			successes += b.success
+			total += b.total
+		}
+	}
+
+	ratio = float64(successes) / float64(total)
+
+	switch {
+	case !s.breached && ratio < s.slo.SuccessRatio:
+		s.breached = true
+		return ratio, true, true
+	case s.breached && ratio >= s.slo.SuccessRatio+s.slo.RecoveryMargin:
+		s.breached = false
+		return ratio, false, true
+	}
+
+	return ratio, s.breached, false
+}
+
+// isBreached reports whether the task is currently in a breach episode.
+func (s *sloTracker) isBreached() bool {
+	if s == nil {
+		return false
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return s.breached
+}
+
+// SLOBreached reports whether the task is currently below its SLO. It is always false for tasks without an SLO.
+func (t *Task) SLOBreached() bool {
+	return t.slo.isBreached()
+}
+
+// recordSLO records the outcome of an execution and notifies the scheduler callbacks when a breach episode starts
+// or ends.
+func (s *StdScheduler) recordSLO(t *Task, success bool) {
+	if t.slo == nil {
+		return
+	}
+
+	ratio, breached, changed := t.slo.record(time.Now(), success)
+	if !changed {
+		return
+	}
+
+	if breached {
+		logger.Warnf("task (id: %s) is below its SLO, success ratio: %.4f", t.id, ratio)
+		s.notifySLO(s.opts.OnSLOBreach, "breach", t.id, ratio)
+
+		return
+	}
+
+	logger.Infof("task (id: %s) has recovered its SLO, success ratio: %.4f", t.id, ratio)
+	s.notifySLO(s.opts.OnSLORecover, "recover", t.id, ratio)
+}
+
+// notifySLO calls an SLO callback of the scheduler, recovering its panics like notifyScheduleChange.
+func (s *StdScheduler) notifySLO(callback func(id string, ratio float64), event, id string, ratio float64) {
+	if callback == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("task (id: %s) SLO %s callback panicked: %v", id, event, r)
+			s.reportInternalError(InternalErrorCallbackPanic,
+				fmt.Errorf("task (id: %s) SLO %s callback panicked: %v", id, event, r))
+		}
+	}()
+
+	callback(id, ratio)
+}
+
+// sloBreaches returns the IDs of the tasks currently below their SLO, sorted.
+func (s *StdScheduler) sloBreaches() []string {
+	var ids []string
+
+	s.RLock()
+	for id, t := range s.tasks {
+		if t.slo.isBreached() {
+			ids = append(ids, id)
+		}
+	}
+	s.RUnlock()
+
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,139 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestSLO(t *testing.T) {
+	t.Run("Verify a single breach and recovery are notified", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var mu sync.Mutex
+		var breaches, recoveries []float64
+		recoveredCh := make(chan struct{})
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			OnSLOBreach: func(id string, ratio float64) {
+				mu.Lock()
+				defer mu.Unlock()
+				breaches = append(breaches, ratio)
+			},
+			OnSLORecover: func(id string, ratio float64) {
+				mu.Lock()
+				defer mu.Unlock()
+				recoveries = append(recoveries, ratio)
+				close(recoveredCh)
+			},
+		})
+		defer scheduler.Stop()
+
+		// success, two failures, then successes only
+		outcomes := []bool{true, false, false}
+		run := 0
+
+		_, err := scheduler.Add(&Task{
+			Interval: 5 * time.Millisecond,
+			SLO:      &SLO{SuccessRatio: 0.8, Window: time.Hour, RecoveryMargin: 0.05},
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				defer func() { run++ }()
+				if run < len(outcomes) && !outcomes[run] {
+					return errors.New("some error")
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case <-recoveredCh:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("SLO did not recover within 2 seconds")
+		}
+
+		// Let a few more successes through, they must not notify again
+		time.Sleep(30 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if assert.Len(breaches, 1) {
+			assert.InDelta(0.5, breaches[0], 0.0001)
+		}
+		if assert.Len(recoveries, 1) {
+			assert.GreaterOrEqual(recoveries[0], 0.85)
+		}
+	})
+
+	t.Run("Verify panicking callbacks are recovered and breaches reported by Health", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var failing atomic.Bool
+		failing.Store(true)
+		breachedCh := make(chan struct{}, 1)
+		recoveredCh := make(chan struct{}, 1)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			OnSLOBreach: func(id string, ratio float64) {
+				breachedCh <- struct{}{}
+				panic("breach callback")
+			},
+			OnSLORecover: func(id string, ratio float64) {
+				recoveredCh <- struct{}{}
+				panic("recover callback")
+			},
+		})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("breaching", &Task{
+			Interval: 5 * time.Millisecond,
+			SLO:      &SLO{SuccessRatio: 0.5, Window: 50 * time.Millisecond},
+			TaskFunc: func() error {
+				if failing.Load() {
+					return errors.New("some error")
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+
+		select {
+		case <-breachedCh:
+		case <-time.After(time.Second):
+			t.Fatalf("SLO was not breached within 1 second")
+		}
+		assert.Equal([]string{"breaching"}, scheduler.Health().SLOBreaches)
+
+		failing.Store(false)
+		select {
+		case <-recoveredCh:
+		case <-time.After(time.Second):
+			t.Fatalf("SLO did not recover within 1 second")
+		}
+		assert.Empty(scheduler.Health().SLOBreaches)
+		assert.True(scheduler.Has("breaching"))
+	})
+
+	t.Run("Verify outcomes outside the window are ignored", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		tracker := newSLOTracker(&SLO{SuccessRatio: 0.9, Window: time.Minute})
+		now := time.Now()
+
+		_, breached, changed := tracker.record(now, false)
+		assert.True(breached)
+		assert.True(changed)
+
+		ratio, breached, changed := tracker.record(now.Add(2*time.Minute), true)
+		assert.Equal(1.0, ratio)
+		assert.False(breached)
+		assert.True(changed)
+	})
+}
@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"time"
+)
+
+// Reasons reported to StdSchedulerOptions.OnScheduleChange when a snooze starts and ends.
+const (
+	scheduleReasonSnoozed     = "snoozed"
+	scheduleReasonSnoozeEnded = "snooze ended"
+)
+
+// Snooze will suppress the executions of the task until the given time, e.g. until the end of a maintenance window.
+// The task is paused meanwhile, and then resumes on its normal cadence: a recurring task runs at the first of its
+// regular fire times after until, a RunOnce task or a pending retry runs at its planned time or at until, whichever
+// is later. Snoozing a snoozed task replaces the end of its snooze. An execution already in flight is not
+// interrupted.
+//
+// It returns ErrTaskNotFound if the task does not exist, ErrInvalidSnooze if until is not in the future, and a
+// TransitionError if the task cannot be paused in its current state.
+//
+//	// Do not run before the end of the maintenance window
+//	err := scheduler.Snooze(id, time.Date(2024, 6, 1, 14, 0, 0, 0, time.Local))
+func (s *StdScheduler) Snooze(id string, until time.Time) error {
+	t, err := s.scheduled(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !until.After(now) {
+		return ErrInvalidSnooze
+	}
+
+	var snoozed bool
+	t.safeOps(func() {
+		// A snoozed task only has its wake-up replaced
+		if t.state != TaskStatePaused || t.snoozeTimer == nil {
+			if err = t.transition(eventPause); err != nil {
+				return
+			}
+			t.disarm()
+		} else {
+			t.snoozeTimer.Stop()
+		}
+
+		t.snoozeSeq++
+		seq := t.snoozeSeq
+		t.snoozeTimer = time.AfterFunc(until.Sub(now), func() { s.wake(t, seq) })
+		t.snoozeUntil = until
+		t.trace.record(DecisionSkipped, until.Sub(now), scheduleReasonSnoozed)
+		snoozed = true
+	})
+	if snoozed {
+		s.notifyScheduleChange(id, time.Time{}, scheduleReasonSnoozed)
+	}
+
+	if err != nil && t.State() == TaskStateRemoved {
+		return ErrTaskNotFound
+	}
+
+	return err
+}
+
+// SnoozedUntil will return when the snooze of the task ends, see StdScheduler.Snooze, or the zero time if the task
+// is not snoozed.
+func (t *Task) SnoozedUntil() time.Time {
+	var until time.Time
+	t.safeOps(func() {
+		if t.state == TaskStatePaused && t.snoozeTimer != nil {
+			until = t.snoozeUntil
+		}
+	})
+
+	return until
+}
+
+// wake resumes a snoozed task on its normal cadence, unless the snooze numbered seq has been replaced.
+func (s *StdScheduler) wake(t *Task, seq uint64) {
+	var (
+		next  time.Time
+		armed bool
+	)
+	t.safeOps(func() {
+		if t.snoozeSeq != seq || t.snoozeTimer == nil {
+			return
+		}
+		t.snoozeTimer, t.snoozeUntil = nil, time.Time{}
+
+		if t.transition(eventResume) != nil {
+			return
+		}
+
+		now := time.Now()
+		planned := t.nextFire
+
+		// A task snoozed before its first arm starts like scheduleTask would have started it
+		if !t.armed() {
+			start := t.StartAfter
+			if start.Before(t.addedAt) {
+				start = t.addedAt
+			}
+			planned = start.Add(t.firstDelay(start))
+
+			t.trigger = TriggerInterval
+			if !t.StartAfter.IsZero() {
+				t.trigger = TriggerStartAfter
+			}
+		}
+
+		next, armed = s.resetTimer(t, t.resumeAt(planned, now).Sub(now), DecisionTimerArmed, t.trigger)
+	})
+	if armed {
+		s.notifyScheduleChange(t.id, next, scheduleReasonSnoozeEnded)
+	}
+}
+
+// resumeAt returns when a task whose fire was planned at planned runs once resumed at now: a recurring task waiting
+// for its interval runs at the first of its regular fire times after now, a RunOnce task or a pending retry runs at
+// its planned time or now, whichever is later. The task lock must be held.
+func (t *Task) resumeAt(planned, now time.Time) time.Time {
+	if planned.Before(now) && t.waitsForInterval() && !t.RunOnce {
+		if t.cron != nil {
+			planned = now.Add(t.interval())
+		} else {
+			interval := t.interval()
+			missed := now.Sub(planned)/interval + 1
+			planned = planned.Add(missed * interval)
+		}
+	}
+	if planned.Before(now) {
+		planned = now
+	}
+
+	return planned
+}
@@ -0,0 +1,138 @@
+package tasks
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestSnooze(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	addTask := func(t *testing.T, scheduler *StdScheduler, id string, runs *atomic.Int32) {
+		err := scheduler.AddWithID(id, &Task{
+			Interval: 20 * time.Millisecond,
+			TaskFunc: func() error {
+				runs.Add(1)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assertions.NoError(t, err)
+		t.Cleanup(func() { scheduler.Del(id) })
+	}
+
+	t.Run("Verify a snoozed task wakes up on its cadence", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, scheduler, "snoozed", &runs)
+
+		until := time.Now().Add(100 * time.Millisecond)
+		assert.NoError(scheduler.Snooze("snoozed", until))
+		snoozed := runs.Load()
+
+		task, err := scheduler.Lookup("snoozed")
+		assert.NoError(err)
+		assert.Equal(TaskStatePaused, task.State())
+		assert.Equal(until, task.SnoozedUntil())
+
+		time.Sleep(time.Until(until))
+		assert.LessOrEqual(runs.Load(), snoozed+1)
+
+		assert.Eventually(func() bool { return runs.Load() >= snoozed+3 }, time.Second, time.Millisecond)
+
+		task, err = scheduler.Lookup("snoozed")
+		assert.NoError(err)
+		assert.True(task.SnoozedUntil().IsZero())
+	})
+
+	t.Run("Verify snoozing a snoozed task replaces its wake-up", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, scheduler, "replaced", &runs)
+
+		assert.NoError(scheduler.Snooze("replaced", time.Now().Add(time.Hour)))
+		snoozed := runs.Load()
+
+		assert.NoError(scheduler.Snooze("replaced", time.Now().Add(50*time.Millisecond)))
+		assert.Eventually(func() bool { return runs.Load() >= snoozed+2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify a task can be snoozed before its first execution", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		assert.NoError(scheduler.AddWithID("pending", &Task{
+			Interval:   20 * time.Millisecond,
+			StartAfter: time.Now().Add(50 * time.Millisecond),
+			TaskFunc: func() error {
+				runs.Add(1)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}))
+		defer scheduler.Del("pending")
+
+		until := time.Now().Add(200 * time.Millisecond)
+		assert.NoError(scheduler.Snooze("pending", until))
+
+		time.Sleep(time.Until(until))
+		assert.Zero(runs.Load())
+		assert.Eventually(func() bool { return runs.Load() >= 1 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify Del during a snooze cleans up the wake-up", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, scheduler, "deleted", &runs)
+
+		assert.NoError(scheduler.Snooze("deleted", time.Now().Add(50*time.Millisecond)))
+		snoozed := runs.Load()
+
+		scheduler.RLock()
+		task := scheduler.tasks["deleted"]
+		scheduler.RUnlock()
+
+		scheduler.Del("deleted")
+		task.safeOps(func() {
+			assert.False(task.snoozeTimer.Stop(), "Wake-up timer still armed after Del")
+		})
+
+		time.Sleep(100 * time.Millisecond)
+		assert.LessOrEqual(runs.Load(), snoozed+1)
+		assert.ErrorIs(scheduler.Snooze("deleted", time.Now().Add(time.Minute)), ErrTaskNotFound)
+	})
+
+	t.Run("Verify Stop during a snooze", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		stopped := NewStdScheduler(StdSchedulerOptions{})
+
+		var runs atomic.Int32
+		addTask(t, stopped, "stopped", &runs)
+
+		assert.NoError(stopped.Snooze("stopped", time.Now().Add(50*time.Millisecond)))
+		snoozed := runs.Load()
+		stopped.Stop()
+
+		time.Sleep(100 * time.Millisecond)
+		assert.LessOrEqual(runs.Load(), snoozed+1)
+		assert.Empty(stopped.Tasks())
+	})
+
+	t.Run("Verify invalid snoozes are rejected", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		addTask(t, scheduler, "invalid", &runs)
+
+		assert.ErrorIs(scheduler.Snooze("missing", time.Now().Add(time.Minute)), ErrTaskNotFound)
+		assert.ErrorIs(scheduler.Snooze("invalid", time.Now().Add(-time.Minute)), ErrInvalidSnooze)
+	})
+}
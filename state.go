@@ -0,0 +1,265 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTransition is wrapped by the TransitionError returned when an event is not valid in the current state
+// of a task.
+var ErrInvalidTransition = errors.New("invalid task state transition")
+
+// TaskState is the lifecycle state of a task.
+//
+//	pending --arm--> scheduled --fire--> running --success/failure--> scheduled
+//	                                     running --retry--> retrying --fire--> running
+//	                                     running --complete/retry exhausted--> completed
+//	any state but removed --remove--> removed
+type TaskState int
+
+// Task states.
+const (
+	// TaskStatePending is a task added to a scheduler, waiting for its StartAfter time.
+	TaskStatePending TaskState = iota
+	// TaskStateScheduled is a task waiting for its next fire.
+	TaskStateScheduled
+	// TaskStateRunning is a task with an execution in flight.
+	TaskStateRunning
+	// TaskStateRetrying is a task waiting to retry or reschedule a failed execution.
+	TaskStateRetrying
+	// TaskStatePaused is a task whose fires are suspended until it is resumed.
+	TaskStatePaused
+	// TaskStateDisabled is a task whose fires are suspended until it is enabled.
+	TaskStateDisabled
+	// TaskStateDraining is a task finishing its in-flight execution before being removed.
+	TaskStateDraining
+	// TaskStateCompleted is a RunOnce task that will not run again.
+	TaskStateCompleted
+	// TaskStateRemoved is a task deleted from its scheduler, it is final.
+	TaskStateRemoved
+)
+
+// String returns the human readable name of the state.
+func (st TaskState) String() string {
+	switch st {
+	case TaskStatePending:
+		return "pending"
+	case TaskStateScheduled:
+		return "scheduled"
+	case TaskStateRunning:
+		return "running"
+	case TaskStateRetrying:
+		return "retrying"
+	case TaskStatePaused:
+		return "paused"
+	case TaskStateDisabled:
+		return "disabled"
+	case TaskStateDraining:
+		return "draining"
+	case TaskStateCompleted:
+		return "completed"
+	case TaskStateRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("state(%d)", int(st))
+	}
+}
+
+// taskEvent is an operation or an internal event changing the state of a task.
+type taskEvent int
+
+// Task events.
+const (
+	// eventArm is the timer being armed for the first fire.
+	eventArm taskEvent = iota
+	// eventFire is an execution starting.
+	eventFire
+	// eventSkip is a fire being skipped.
+	eventSkip
+	// eventSuccess is an execution of a recurring task succeeding.
+	eventSuccess
+	// eventComplete is an execution of a RunOnce task succeeding.
+	eventComplete
+	// eventFailure is an execution of a recurring task failing without retry.
+	eventFailure
+	// eventRetry is a retry or a reschedule being armed after a failure.
+	eventRetry
+	// eventRetryExhausted is an execution of a RunOnce task failing without retries left.
+	eventRetryExhausted
+	// eventPause suspends the fires of a task.
+	eventPause
+	// eventResume resumes the fires of a paused task.
+	eventResume
+	// eventDisable suspends the fires of a task.
+	eventDisable
+	// eventEnable resumes the fires of a disabled task.
+	eventEnable
+	// eventDrain removes a task once its in-flight execution is finished.
+	eventDrain
+	// eventRemove deletes a task.
+	eventRemove
+)
+
+// String returns the human readable name of the event.
+func (e taskEvent) String() string {
+	switch e {
+	case eventArm:
+		return "arm"
+	case eventFire:
+		return "fire"
+	case eventSkip:
+		return "skip"
+	case eventSuccess:
+		return "success"
+	case eventComplete:
+		return "complete"
+	case eventFailure:
+		return "failure"
+	case eventRetry:
+		return "retry"
+	case eventRetryExhausted:
+		return "retry exhausted"
+	case eventPause:
+		return "pause"
+	case eventResume:
+		return "resume"
+	case eventDisable:
+		return "disable"
+	case eventEnable:
+		return "enable"
+	case eventDrain:
+		return "drain"
+	case eventRemove:
+		return "remove"
+	default:
+		return fmt.Sprintf("event(%d)", int(e))
+	}
+}
+
+// transitions is the table of valid transitions, by state and event. Events missing from the table are rejected.
+var transitions = map[TaskState]map[taskEvent]TaskState{
+	TaskStatePending: {
+		eventArm: TaskStateScheduled,
+		// A task may be snoozed before its timer is armed
+		eventPause:  TaskStatePaused,
+		eventRemove: TaskStateRemoved,
+	},
+	TaskStateScheduled: {
+		eventFire: TaskStateRunning,
+		eventSkip: TaskStateScheduled,
+		// An overlapping execution of a recurring task may fail after another one succeeded
+		eventRetry:   TaskStateRetrying,
+		eventPause:   TaskStatePaused,
+		eventDisable: TaskStateDisabled,
+		eventRemove:  TaskStateRemoved,
+	},
+	TaskStateRunning: {
+		// Executions of a recurring task may overlap
+		eventFire:           TaskStateRunning,
+		eventSkip:           TaskStateRunning,
+		eventSuccess:        TaskStateScheduled,
+		eventComplete:       TaskStateCompleted,
+		eventFailure:        TaskStateScheduled,
+		eventRetry:          TaskStateRetrying,
+		eventRetryExhausted: TaskStateCompleted,
+		eventPause:          TaskStatePaused,
+		eventDisable:        TaskStateDisabled,
+		eventDrain:          TaskStateDraining,
+		eventRemove:         TaskStateRemoved,
+	},
+	TaskStateRetrying: {
+		eventFire:    TaskStateRunning,
+		eventSkip:    TaskStateScheduled,
+		eventPause:   TaskStatePaused,
+		eventDisable: TaskStateDisabled,
+		eventRemove:  TaskStateRemoved,
+	},
+	TaskStatePaused: {
+		eventResume:  TaskStateScheduled,
+		eventDisable: TaskStateDisabled,
+		eventRemove:  TaskStateRemoved,
+	},
+	TaskStateDisabled: {
+		eventEnable: TaskStateScheduled,
+		eventRemove: TaskStateRemoved,
+	},
+	TaskStateDraining: {
+		eventSuccess:        TaskStateRemoved,
+		eventComplete:       TaskStateRemoved,
+		eventFailure:        TaskStateRemoved,
+		eventRetry:          TaskStateRemoved,
+		eventRetryExhausted: TaskStateRemoved,
+		eventRemove:         TaskStateRemoved,
+	},
+	TaskStateCompleted: {
+		eventRemove: TaskStateRemoved,
+	},
+	TaskStateRemoved: {},
+}
+
+// TransitionError is returned when an event is not valid in the current state of a task.
+type TransitionError struct {
+	// State is the state of the task when the event was rejected.
+	State TaskState
+
+	// Event is the name of the rejected event.
+	Event string
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s in state %s", ErrInvalidTransition.Error(), e.Event, e.State)
+}
+
+// Unwrap returns ErrInvalidTransition.
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// transition applies the event to the task state. Callers must hold the task lock.
+func (t *Task) transition(e taskEvent) error {
+	next, ok := transitions[t.state][e]
+	if !ok {
+		return &TransitionError{State: t.state, Event: e.String()}
+	}
+
+	t.state = next
+
+	return nil
+}
+
+// finish applies the outcome of an execution to the task state and returns the new state. Executions that armed a
+// retry have already left the running state and are not affected.
+func (t *Task) finish(success bool) TaskState {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.state != TaskStateRunning && t.state != TaskStateDraining {
+		return t.state
+	}
+
+	switch {
+	case success && t.RunOnce:
+		_ = t.transition(eventComplete)
+		t.succeeded = true
+	case success:
+		_ = t.transition(eventSuccess)
+	case t.RunOnce:
+		_ = t.transition(eventRetryExhausted)
+	default:
+		_ = t.transition(eventFailure)
+	}
+
+	return t.state
+}
+
+// State will return the lifecycle state of the task. Called on a task returned by Lookup or Tasks, it reflects the
+// state at the time of the lookup.
+func (t *Task) State() TaskState {
+	var st TaskState
+	t.safeOps(func() {
+		st = t.state
+	})
+
+	return st
+}
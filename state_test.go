@@ -0,0 +1,168 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTable(t *testing.T) {
+	type pair struct {
+		state TaskState
+		event taskEvent
+	}
+
+	valid := map[pair]TaskState{
+		{TaskStatePending, eventArm}:    TaskStateScheduled,
+		{TaskStatePending, eventPause}:  TaskStatePaused,
+		{TaskStatePending, eventRemove}: TaskStateRemoved,
+
+		{TaskStateScheduled, eventFire}:    TaskStateRunning,
+		{TaskStateScheduled, eventSkip}:    TaskStateScheduled,
+		{TaskStateScheduled, eventRetry}:   TaskStateRetrying,
+		{TaskStateScheduled, eventPause}:   TaskStatePaused,
+		{TaskStateScheduled, eventDisable}: TaskStateDisabled,
+		{TaskStateScheduled, eventRemove}:  TaskStateRemoved,
+
+		{TaskStateRunning, eventFire}:           TaskStateRunning,
+		{TaskStateRunning, eventSkip}:           TaskStateRunning,
+		{TaskStateRunning, eventSuccess}:        TaskStateScheduled,
+		{TaskStateRunning, eventComplete}:       TaskStateCompleted,
+		{TaskStateRunning, eventFailure}:        TaskStateScheduled,
+		{TaskStateRunning, eventRetry}:          TaskStateRetrying,
+		{TaskStateRunning, eventRetryExhausted}: TaskStateCompleted,
+		{TaskStateRunning, eventPause}:          TaskStatePaused,
+		{TaskStateRunning, eventDisable}:        TaskStateDisabled,
+		{TaskStateRunning, eventDrain}:          TaskStateDraining,
+		{TaskStateRunning, eventRemove}:         TaskStateRemoved,
+
+		{TaskStateRetrying, eventFire}:    TaskStateRunning,
+		{TaskStateRetrying, eventSkip}:    TaskStateScheduled,
+		{TaskStateRetrying, eventPause}:   TaskStatePaused,
+		{TaskStateRetrying, eventDisable}: TaskStateDisabled,
+		{TaskStateRetrying, eventRemove}:  TaskStateRemoved,
+
+		{TaskStatePaused, eventResume}:  TaskStateScheduled,
+		{TaskStatePaused, eventDisable}: TaskStateDisabled,
+		{TaskStatePaused, eventRemove}:  TaskStateRemoved,
+
+		{TaskStateDisabled, eventEnable}: TaskStateScheduled,
+		{TaskStateDisabled, eventRemove}: TaskStateRemoved,
+
+		{TaskStateDraining, eventSuccess}:        TaskStateRemoved,
+		{TaskStateDraining, eventComplete}:       TaskStateRemoved,
+		{TaskStateDraining, eventFailure}:        TaskStateRemoved,
+		{TaskStateDraining, eventRetry}:          TaskStateRemoved,
+		{TaskStateDraining, eventRetryExhausted}: TaskStateRemoved,
+		{TaskStateDraining, eventRemove}:         TaskStateRemoved,
+
+		{TaskStateCompleted, eventRemove}: TaskStateRemoved,
+	}
+
+	for state := TaskStatePending; state <= TaskStateRemoved; state++ {
+		for event := eventArm; event <= eventRemove; event++ {
+			t.Run(state.String()+"/"+event.String(), func(t *testing.T) {
+				assert := assertions.New(t)
+
+				task := &Task{state: state}
+				err := task.transition(event)
+
+				expected, ok := valid[pair{state, event}]
+				if !ok {
+					var transitionErr *TransitionError
+					if assert.ErrorAs(err, &transitionErr) {
+						assert.Equal(state, transitionErr.State)
+						assert.Equal(event.String(), transitionErr.Event)
+					}
+					assert.ErrorIs(err, ErrInvalidTransition)
+					assert.Equal(state, task.State())
+
+					return
+				}
+
+				assert.NoError(err)
+				assert.Equal(expected, task.State())
+			})
+		}
+	}
+}
+
+func TestTaskState(t *testing.T) {
+	t.Run("Verify RunOnce lifecycle", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		runningCh := make(chan TaskState, 1)
+		releaseCh := make(chan struct{})
+
+		err := scheduler.AddWithID("lifecycle", &Task{
+			Interval: 50 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				task, err := scheduler.Lookup("lifecycle")
+				if err == nil {
+					runningCh <- task.State()
+				}
+				<-releaseCh
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		scheduler.RLock()
+		task := scheduler.tasks["lifecycle"]
+		scheduler.RUnlock()
+
+		// The timer of a task without StartAfter is armed right away
+		assert.Contains([]TaskState{TaskStatePending, TaskStateScheduled}, task.State())
+		assert.Eventually(func() bool { return task.State() == TaskStateScheduled }, time.Second, time.Millisecond)
+
+		select {
+		case state := <-runningCh:
+			assert.Equal(TaskStateRunning, state)
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to execute the scheduled task within 1 second")
+		}
+
+		close(releaseCh)
+		assert.Eventually(func() bool { return task.State() == TaskStateRemoved }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify retries go through the retrying state", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		failedCh := make(chan struct{}, 1)
+
+		err := scheduler.AddWithID("retrying", &Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Hour,
+			TaskFunc: func() error {
+				failedCh <- struct{}{}
+				return errors.New("some error")
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		<-failedCh
+
+		scheduler.RLock()
+		task := scheduler.tasks["retrying"]
+		scheduler.RUnlock()
+
+		assert.Eventually(func() bool { return task.State() == TaskStateRetrying }, time.Second, time.Millisecond)
+
+		scheduler.Del("retrying")
+		assert.Equal(TaskStateRemoved, task.State())
+	})
+}
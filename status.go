@@ -0,0 +1,99 @@
+package tasks
+
+import "time"
+
+// TaskStatus is a snapshot of what a task did so far and what it does next, see StdScheduler.TaskStatus.
+type TaskStatus struct {
+	// State is the lifecycle state of the task.
+	State TaskState
+
+	// RunCount is the number of executions started, retries and reschedules on error included. It only increases.
+	RunCount uint64
+
+	// LastRun holds the timestamps of the latest started execution, zero values if the task never ran.
+	LastRun RunTimes
+
+	// LastError is the error returned by the latest finished execution, nil if it succeeded or if none finished yet.
+	LastError error
+
+	// NextRun is when the task is due to execute next, zero if no execution is pending. See Task.NextRun.
+	NextRun time.Time
+
+	// InFlight is the number of executions in flight.
+	InFlight int
+
+	// PeakConcurrency is the highest number of executions in flight at once so far, see Task.MaxConcurrent.
+	PeakConcurrency int
+
+	// SkippedWhileRunning is the number of fires skipped so far because the previous execution was still running,
+	// see Task.SkipIfRunning.
+	SkippedWhileRunning uint64
+
+	// RetriesRemaining is the number of retries left to the current failing cycle, see Task.RetriesRemaining.
+	RetriesRemaining int
+}
+
+// TaskStatus will return a snapshot of the execution history and next run of the specified task, taken at once so
+// that its fields are consistent with each other. It is cheap enough to be polled. It returns ErrTaskNotFound if the
+// task does not exist.
+func (s *StdScheduler) TaskStatus(id string) (TaskStatus, error) {
+	s.RLock()
+	t, ok := s.tasks[id]
+	s.RUnlock()
+	if !ok {
+		return TaskStatus{}, ErrTaskNotFound
+	}
+
+	var status TaskStatus
+	t.safeOps(func() {
+		status = TaskStatus{
+			State:     t.state,
+			RunCount:  t.runCount,
+			LastRun:   t.lastRun,
+			LastError: t.lastErr,
+			NextRun:   t.nextRun(),
+
+			InFlight:            int(t.inFlight.Load()),
+			PeakConcurrency:     int(t.peakInFlight),
+			SkippedWhileRunning: t.skippedRunning,
+			RetriesRemaining:    t.retriesRemaining,
+		}
+	})
+
+	return status, nil
+}
+
+// RunCount will return the number of executions of the task started so far, retries and reschedules on error
+// included. Called on a task returned by Lookup or Tasks, it reflects the executions started at the time of the
+// lookup.
+func (t *Task) RunCount() uint64 {
+	var n uint64
+	t.safeOps(func() {
+		n = t.runCount
+	})
+
+	return n
+}
+
+// RetriesRemaining will return the number of retries left to the current failing cycle of the task, out of its
+// RetriesOnError. It is back to RetriesOnError after every success. Called on a task returned by Lookup or Tasks, it
+// reflects the retries left at the time of the lookup.
+func (t *Task) RetriesRemaining() int {
+	var n int
+	t.safeOps(func() {
+		n = t.retriesRemaining
+	})
+
+	return n
+}
+
+// LastError will return the error returned by the latest finished execution of the task, or nil if it succeeded or
+// if no execution finished yet. Executions that yielded count as successes.
+func (t *Task) LastError() error {
+	var err error
+	t.safeOps(func() {
+		err = t.lastErr
+	})
+
+	return err
+}
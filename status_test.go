@@ -0,0 +1,108 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTaskStatus(t *testing.T) {
+	t.Run("Verify the status follows the executions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errOdd := errors.New("odd run")
+		var runs int64
+		assert.NoError(scheduler.AddWithID("flapping", &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				if atomic.AddInt64(&runs, 1)%2 == 1 {
+					return errOdd
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		status, err := scheduler.TaskStatus("flapping")
+		assert.NoError(err)
+		assert.Zero(status.RunCount)
+		assert.NoError(status.LastError)
+		assert.True(status.LastRun.Started.IsZero())
+		assert.False(status.NextRun.IsZero())
+
+		var (
+			last              uint64
+			failed, succeeded bool
+		)
+		assert.Eventually(func() bool {
+			status, err := scheduler.TaskStatus("flapping")
+			if err != nil {
+				return false
+			}
+
+			// The run count never goes back
+			assert.GreaterOrEqual(status.RunCount, last)
+			last = status.RunCount
+
+			if status.RunCount > 0 && !status.LastRun.Ended.IsZero() {
+				if status.LastError == nil {
+					succeeded = true
+				} else {
+					assert.ErrorIs(status.LastError, errOdd)
+					failed = true
+				}
+			}
+
+			return failed && succeeded && last >= 6
+		}, 2*time.Second, time.Millisecond)
+
+		// Lookup copies carry the same values
+		task, err := scheduler.Lookup("flapping")
+		assert.NoError(err)
+		assert.GreaterOrEqual(task.RunCount(), last)
+		assert.Equal(task.RunCount() > 0, !task.LastRun().Started.IsZero())
+	})
+
+	t.Run("Verify retries are counted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errFailed := errors.New("failed")
+		task := &Task{
+			Interval: 100 * time.Millisecond,
+			TaskFunc: func() error { return errFailed },
+			ErrFunc:  func(error) {},
+		}
+		task.WithRescheduleOnError(errFailed, time.Millisecond, 2)
+		assert.NoError(scheduler.AddWithID("rescheduled", task))
+
+		assert.Eventually(func() bool {
+			status, err := scheduler.TaskStatus("rescheduled")
+			return err == nil && status.RunCount >= 3 && !status.LastRun.Ended.IsZero()
+		}, 2*time.Second, time.Millisecond)
+
+		// The first execution cycle has been rescheduled twice
+		task, err := scheduler.Lookup("rescheduled")
+		assert.NoError(err)
+		assert.Equal(task.RunSequence()+2, task.RunCount())
+		assert.ErrorIs(task.LastError(), errFailed)
+	})
+
+	t.Run("Verify an unknown task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		_, err := scheduler.TaskStatus("missing")
+		assert.ErrorIs(err, ErrTaskNotFound)
+	})
+}
@@ -0,0 +1,237 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestStop(t *testing.T) {
+	t.Run("Verify Stop while executions hold and wait for workers", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 5})
+
+		release := make(chan struct{})
+		var started, finished atomic.Int32
+		for i := 0; i < 10; i++ {
+			_, err := scheduler.Add(&Task{
+				Interval: 5 * time.Millisecond,
+				RunOnce:  true,
+				TaskFunc: func() error {
+					started.Add(1)
+					<-release
+					finished.Add(1)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			})
+			assert.NoError(err)
+		}
+
+		assert.Eventually(func() bool { return started.Load() == 5 }, time.Second, time.Millisecond)
+
+		assert.NotPanics(scheduler.Stop)
+		assert.NotPanics(scheduler.Stop)
+
+		close(release)
+		assert.Eventually(func() bool { return finished.Load() == 5 }, time.Second, time.Millisecond)
+
+		// Executions waiting for a worker gave up
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(int32(5), started.Load())
+		assert.Empty(scheduler.Tasks())
+	})
+
+	t.Run("Verify concurrent Stop calls", func(t *testing.T) {
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+		_, err := scheduler.Add(&Task{
+			Interval: time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		})
+		assertions.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				scheduler.Stop()
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Verify work is rejected after Stop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+		scheduler.Stop()
+
+		task := &Task{
+			Interval: time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}
+
+		_, err := scheduler.Add(task)
+		assert.ErrorIs(err, ErrSchedulerStopped)
+		assert.ErrorIs(scheduler.AddWithID("late", task), ErrSchedulerStopped)
+		assert.ErrorIs(scheduler.Submit(func() error { return nil }, func(error) {}), ErrSchedulerStopped)
+		assert.Empty(scheduler.Tasks())
+	})
+}
+
+func TestReentrancy(t *testing.T) {
+	// returns fails the test if f does not return within a second
+	returns := func(t *testing.T, f func()) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			f()
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("deadlock")
+		}
+	}
+
+	t.Run("Verify a task deleting itself", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs atomic.Int32
+		assert.NoError(scheduler.AddWithID("self", &Task{
+			Interval:             time.Millisecond,
+			RetriesOnError:       3,
+			RetryOnErrorInterval: time.Millisecond,
+			TaskFunc: func() error {
+				runs.Add(1)
+				scheduler.Del("self")
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("self") }, time.Second, time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(int32(1), runs.Load())
+	})
+
+	t.Run("Verify a task deleting a sibling", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		release := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("sibling", &Task{
+			Interval: time.Millisecond,
+			TaskFunc: func() error {
+				<-release
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.NoError(scheduler.AddWithID("reaper", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				scheduler.Del("sibling")
+				close(release)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool {
+			return !scheduler.Has("sibling") && !scheduler.Has("reaper")
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify an error function deleting its own task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var calls atomic.Int32
+		assert.NoError(scheduler.AddWithID("failing", &Task{
+			Interval: time.Millisecond,
+			TaskFunc: func() error { return errors.New("obsolete") },
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, _ error) {
+				calls.Add(1)
+				scheduler.Del(taskCtx.ID())
+			},
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("failing") }, time.Second, time.Millisecond)
+		assert.GreaterOrEqual(calls.Load(), int32(1))
+	})
+
+	t.Run("Verify a task calling Stop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 2})
+
+		stopped := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("stopper", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				scheduler.Stop()
+				close(stopped)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		assert.NoError(scheduler.AddWithID("other", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("Stop called from a task did not return")
+		}
+		assert.Empty(scheduler.Tasks())
+		assert.ErrorIs(scheduler.AddWithID("late", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}), ErrSchedulerStopped)
+		returns(t, scheduler.Stop)
+	})
+
+	t.Run("Verify a schedule change callback calling Stop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var scheduler *StdScheduler
+		scheduler = NewStdScheduler(StdSchedulerOptions{
+			OnScheduleChange: func(string, time.Time, string) {
+				scheduler.Stop()
+			},
+		})
+
+		// The schedule change of the added task stops the scheduler, which reports its removal while stopping
+		returns(t, func() {
+			assert.NoError(scheduler.AddWithID("watched", &Task{
+				Interval: time.Hour,
+				TaskFunc: func() error { return nil },
+				ErrFunc:  func(error) {},
+			}))
+		})
+		assert.Empty(scheduler.Tasks())
+	})
+}
@@ -59,6 +59,19 @@ waiting for 60 seconds.
 		// Do Stuff
 	}
 
+For high volumes of short-lived one time work, Submit is a lighter alternative to RunOnce tasks. Submitted functions
+run as soon as a worker is available and are never added to the task list.
+
+	// Submit a fire and forget function
+	err := scheduler.Submit(func() error {
+		// Put your logic here
+	}, func(e error) {
+		log.Printf("An error occurred when executing submitted function - %s", e)
+	})
+	if err != nil {
+		// Do Stuff
+	}
+
 One powerful feature of Tasks is that it allows users to specify custom error handling. This is done by allowing users to
 define a function that is called when a task returns an error. The below example shows scheduling a task that logs when an
 error occurs.
@@ -82,11 +95,19 @@ package tasks
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/shaelmaar/tasks/logger"
 )
 
 // Task contains the scheduled task details and control mechanisms. This struct is used during the creation of tasks.
 // It allows users to control how and when tasks are executed.
+//
+// Fields may be written directly only while the task is not shared with other goroutines. Tasks configured
+// concurrently must use the setters, such as SetInterval and WithRescheduleOnError, which hold the task lock.
 type Task struct {
 	sync.Mutex
 
@@ -110,6 +131,8 @@ type Task struct {
 	//  // Every 30 days
 	//  time.Duration(30 * (24 * time.Hour))
 	//
+	// For RunOnce tasks, Interval is the delay between Add and the single execution. It is ignored, with a warning,
+	// when StartAfter is set: the task then runs at the StartAfter time.
 	Interval time.Duration
 
 	// RunOnce is used to set this task as a single execution task. By default, tasks will continue executing at
@@ -117,43 +140,329 @@ type Task struct {
 	// the task self deleting.
 	RunOnce bool
 
-	// RetriesOnError if greater than 0, task will be rescheduled in case of an error on execution.
+	// DampenRepeatedErrors collapses the error function calls of repeated identical failures like their logs, see
+	// StdSchedulerOptions.ErrorDampingThreshold: past the threshold, the error functions are only called with a
+	// RepeatedError summarizing the failures, once every StdSchedulerOptions.ErrorDampingInterval. By default they are
+	// called for every failure.
+	DampenRepeatedErrors bool
+
+	// MaxRuns, if greater than 0, removes a recurring task once it has executed that many times, the same way a RunOnce
+	// task removes itself after its execution. Retries and reschedules on error are part of the execution that failed
+	// and do not count. It cannot be combined with RunOnce. See Task.RunsRemaining.
+	MaxRuns int
+
+	// MaxConcurrent, if greater than 0, is the maximum number of executions of the task in flight at once, e.g. the
+	// number of connections a rate limited API accepts. A fire over the limit is handled as set by ConcurrencyPolicy.
+	// Executions of a recurring task otherwise overlap when they last longer than its interval.
+	MaxConcurrent int
+
+	// ConcurrencyPolicy is what happens to a fire over MaxConcurrent: it is skipped by default, or queued until an
+	// execution returns with ConcurrencyQueue. A queued fire holds the timer of the task like a fire waiting for a
+	// worker, the next fire is armed once it starts.
+	ConcurrencyPolicy ConcurrencyPolicy
+
+	// MaxQueueDelay is how long a fire queued with ConcurrencyQueue waits for an execution to return before it is
+	// skipped. Zero waits as long as needed.
+	MaxQueueDelay time.Duration
+
+	// SkipIfRunning skips a fire while the previous execution is still running, the task keeps its cadence and waits
+	// for its next interval. It takes precedence over MaxConcurrent and ConcurrencyPolicy. The skips are counted in
+	// TaskStatus.SkippedWhileRunning.
+	SkipIfRunning bool
+
+	// Mode is when the interval of a recurring task starts counting: when an execution starts with FixedRate, the
+	// default, or when it returns with FixedDelay. With FixedDelay, executions fired by the interval never overlap.
+	Mode ScheduleMode
+
+	// RetriesOnError if greater than 0, task will be rescheduled in case of an error on execution. A recurring task
+	// retries a failed tick up to RetriesOnError times, then resumes its Interval. It is the configured budget, it is
+	// not consumed by retries: the budget is restored after every success, and for the next failed tick of a
+	// recurring task, see RetriesRemaining.
 	RetriesOnError int
 
 	// RetryOnErrorInterval interval for another execution attempt.
 	RetryOnErrorInterval time.Duration
 
+	// RetryBackoff, when set, computes the delay before each retry instead of RetryOnErrorInterval, which is then
+	// optional, e.g. an ExponentialBackoff for transient failures against a rate limited API. Reschedules on error
+	// keep their own intervals.
+	RetryBackoff RetryBackoff
+
+	// InlineRetries, when set, runs the retries on the execution goroutine instead of re-arming the timer, which cuts
+	// the overhead and jitter of retry intervals of a few milliseconds. Each attempt is still reported to the error
+	// functions, the audit log and the stats, and the task timeout applies to every attempt. The worker, the
+	// MaxConcurrent slot and the Exclusive hold are kept for the whole retry loop, which counts as one execution in
+	// flight, and the retry delay is cut short by the deletion of the task. The interval of a recurring task keeps
+	// running during the retries.
+	InlineRetries bool
+
 	// StartAfter is used to specify a start time for the scheduler. When set, tasks will wait for the specified
-	// time to start the schedule timer.
+	// time to start the schedule timer. RunOnce tasks run at that time.
 	StartAfter time.Time
 
-	// TaskFunc is the user defined function to execute as part of this task.
+	// ExcludedDates overrides StdSchedulerOptions.ExcludedDates for this task. When it returns true for the fire
+	// time, the execution is skipped and the task waits for its next interval. It is ignored for RunOnce tasks.
+	//
+	//  // Never run on weekends
+	//  func(t time.Time) bool {
+	//  	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	//  }
+	//
+	ExcludedDates func(t time.Time) bool
+
+	// Debug enables the decision trace of the task. Every scheduling decision taken for the task is recorded in a
+	// bounded in-memory log, available via StdScheduler.DebugTrace, and dumped at Warn level when the task fires
+	// later than StdSchedulerOptions.MissedFireThreshold after its expected fire time.
+	Debug bool
+
+	// SLO, when set, tracks the ratio of successful executions over a sliding window. StdSchedulerOptions.OnSLOBreach
+	// is called once when the ratio drops below the target and StdSchedulerOptions.OnSLORecover once it recovers.
+	SLO *SLO
+
+	// DryRun exercises the full scheduling pipeline of the task without calling its functions. Every execution goes
+	// through the timers, worker limit and logging, but replaces the task function with a no-op that succeeds after
+	// DryRunDuration. Dry runs are marked in logs and decision traces, and are not counted towards the SLO. It can be
+	// toggled on a scheduled task with TaskEditor.SetDryRun.
+	DryRun bool
+
+	// DryRunDuration is how long a dry run execution takes, to simulate the load of the real task function.
+	DryRunDuration time.Duration
+
+	// MinGap guarantees at least that much time between the start of one execution and the start of the next,
+	// whatever requested them. Executions requested within the gap are deferred to its end and coalesced into one.
+	MinGap time.Duration
+
+	// BypassWorkerLimit lets executions of the task start without waiting for a worker, so that watchdog or heartbeat
+	// tasks are never queued behind bulk work. Bypassing executions are not counted against
+	// StdSchedulerOptions.WorkerLimit.
+	BypassWorkerLimit bool
+
+	// Exclusive keeps the executions of the task apart from any other execution of the scheduler, submitted
+	// functions included: they wait for the executions in flight to finish, and no other execution starts until they
+	// are done. Executions waiting for an exclusive one do not overtake it, so it is not starved by a steady stream of
+	// others. Exclusive executions still take a worker. See StdScheduler.ExclusiveStats.
+	Exclusive bool
+
+	// CronExpr schedules the task at the times matching a cron expression instead of every Interval: a standard
+	// 5-field expression, minute hour day-of-month month day-of-week, a 6-field one starting with the second, or a
+	// macro such as @daily. The next fire is computed after every execution. Either Interval or CronExpr is set.
+	CronExpr string
+
+	// Location is the time zone CronExpr is matched in. Defaults to time.Local.
+	Location *time.Location
+
+	// Timeout bounds every execution of the task: the context passed to FuncWithTaskContext is cancelled with
+	// context.DeadlineExceeded once it elapses, and a fresh deadline is set for the next execution. The returned
+	// error goes through the error functions and retries as usual. TaskFunc and FuncWithID have no context to cancel,
+	// so the timeout has no effect on them.
+	Timeout time.Duration
+
+	// Lane is the name of the StdSchedulerOptions.Lanes the executions of the task run on. When empty, they run on
+	// the shared worker pool. Tasks with a lane always wait for a worker of their lane, BypassWorkerLimit is ignored.
+	Lane string
+
+	// Group is the dispatch group of the task on the shared worker pool. When executions wait for a worker of
+	// StdSchedulerOptions.WorkerLimit, freed workers go to each group in turn, oldest execution first, so that a group
+	// with many fires due at once does not starve the other ones. Tasks without a group form a group of their own,
+	// with the functions passed to Submit. It has no effect on tasks with a Lane or BypassWorkerLimit.
+	Group string
+
+	// CompleteBy, when set, is the moment the task must be done by, retries included. Once it passes, any pending
+	// retry is cancelled, ErrDeadlineExceeded is delivered to the error functions and the task is removed. An
+	// execution still in flight has its task context cancelled with ErrDeadlineExceeded as the cause.
+	CompleteBy time.Time
+
+	// MaxConsecutiveSkips, if greater than 0, removes a recurring task once that many consecutive firings have been
+	// skipped, whatever the skip reason. Any execution resets the count. The removal is reported to
+	// StdSchedulerOptions.OnScheduleChange with the reason "skip-limit", so an external reconciler can add the task
+	// again when conditions change.
+	MaxConsecutiveSkips int
+
+	// TaskFunc is the user defined function to execute as part of this task. It has no context, long running
+	// functions can poll StdScheduler.IsCancelled to stop once the task is deleted.
 	//
-	// Either TaskFunc or FuncWithTaskContext must be defined. If both are defined, FuncWithTaskContext will be used.
+	// One of TaskFunc, FuncWithID or FuncWithTaskContext must be defined. If several are defined,
+	// FuncWithTaskContext is used first, then FuncWithID.
 	TaskFunc func() error
 
 	// ErrFunc allows users to define a function that is called when tasks return an error. If ErrFunc is nil,
 	// errors from tasks will be ignored.
 	//
-	// Either ErrFunc or ErrFuncWithTaskContext must be defined. If both are defined, ErrFuncWithTaskContext will be used.
+	// One of ErrFunc, ErrFuncWithID or ErrFuncWithTaskContext must be defined. If several are defined,
+	// ErrFuncWithTaskContext is used first, then ErrFuncWithID. A panic in the error function is recovered, see
+	// StdSchedulerOptions.OnHandlerPanic.
 	ErrFunc func(error)
 
+	// FuncWithID is a user defined function to execute as part of this task. This function is used in place of
+	// TaskFunc with the difference in that it will pass the task ID, which lets tasks created from a template share
+	// one function.
+	FuncWithID func(id string) error
+
+	// ErrFuncWithID allows users to define a function that is called when tasks return an error. This function is
+	// used in place of ErrFunc with the difference in that it will pass the ID of the failed task, which lets many
+	// tasks share one error function.
+	ErrFuncWithID func(id string, err error)
+
 	// FuncWithTaskContext is a user defined function to execute as part of this task. This function is used in
 	// place of TaskFunc with the difference in that it will pass the user defined context from the Task configurations.
 	//
-	// Either TaskFunc or FuncWithTaskContext must be defined. If both are defined, FuncWithTaskContext will be used.
+	// One of TaskFunc, FuncWithID or FuncWithTaskContext must be defined. If several are defined,
+	// FuncWithTaskContext is used first, then FuncWithID.
 	FuncWithTaskContext func(TaskContext) error
 
 	// ErrFuncWithTaskContext allows users to define a function that is called when tasks return an error.
 	// If ErrFunc is nil, errors from tasks will be ignored. This function is used in place of ErrFunc with
 	// the difference in that it will pass the user defined context from the Task configurations.
 	//
-	// Either ErrFunc or ErrFuncWithTaskContext must be defined. If both are defined, ErrFuncWithTaskContext will be used.
+	// One of ErrFunc, ErrFuncWithID or ErrFuncWithTaskContext must be defined. If several are defined,
+	// ErrFuncWithTaskContext is used first, then ErrFuncWithID.
 	ErrFuncWithTaskContext func(TaskContext, error)
 
+	// ExhaustedFunc, when set, is called once a task fails for the last time and is about to be removed: a RunOnce
+	// task, or the final execution allowed by MaxRuns, that has no retry or reschedule left. It tells a permanent
+	// failure apart from the error function calls of failures that are retried, and is not called if the task
+	// eventually succeeds. Like the error functions, it is called in a goroutine of its own and a panic in it is
+	// recovered.
+	ExhaustedFunc func(error)
+
+	// ExhaustedFuncWithTaskContext is used in place of ExhaustedFunc with the difference in that it will pass the
+	// task context of the last execution. If both are defined, ExhaustedFuncWithTaskContext is used.
+	ExhaustedFuncWithTaskContext func(TaskContext, error)
+
+	// registered is set once the task has been added to a scheduler. Adding a registered task again schedules a copy.
+	registered bool
+
+	// ownsTaskContext is set when TaskContext.Context was created by the scheduler rather than by the user.
+	ownsTaskContext bool
+
+	// userContext is the context set by the user before the scheduler wrapped it, nil if none.
+	userContext context.Context
+
+	// readOnly is set on the copies returned by Lookup and Tasks, it is never copied by Clone.
+	readOnly bool
+
+	// runSequence is the number of the current execution cycle. Retries and reschedules on error belong to the
+	// cycle that failed and do not increment it.
+	runSequence uint64
+
+	// attempt is the number of the current execution within its cycle, see TaskContext.Attempt.
+	attempt int
+
+	// trigger is why the task timer has been armed, reported to the next execution.
+	trigger Trigger
+
+	// state is the lifecycle state of the task, only changed through transition.
+	state TaskState
+
+	// retryPending is set when the next execution is a retry or a reschedule of the current cycle.
+	retryPending bool
+
 	// rescheduleOnError allows users to define reschedule on error mechanism.
-	// If task execution returns one of specified errors, task will reset its timer to specified duration.
-	rescheduleOnError map[error]rescheduleOnErrorOpts
+	// If task execution returns one of specified errors, task will reset its timer to specified duration. Rules are
+	// kept in registration order, the first matching one applies.
+	rescheduleOnError []rescheduleRule
+
+	// trace is the decision trace of the task, it is only set when Debug is enabled.
+	trace *decisionTrace
+
+	// slo tracks the SLO of the task, it is only set when SLO is defined.
+	slo *sloTracker
+
+	// definition is a copy of the task as it was added, kept for dead letters of RunOnce tasks.
+	definition *Task
+
+	// failedAttempts lists the failed executions of a RunOnce task, kept for dead letters.
+	failedAttempts []FailedAttempt
+
+	// addedAt is when the task was added to the scheduler.
+	addedAt time.Time
+
+	// checkpoint is the checkpoint stored by the latest successful execution, see TaskContext.SetCheckpoint.
+	checkpoint []byte
+
+	// lastStart is when the latest execution started, used to enforce MinGap.
+	lastStart time.Time
+
+	// lastRun holds the timestamps of the latest started execution.
+	lastRun RunTimes
+
+	// runCount is the number of executions started, retries and reschedules on error included.
+	runCount uint64
+
+	// lastErr is the error returned by the latest finished execution, nil if it succeeded.
+	lastErr error
+
+	// cron is the parsed CronExpr, nil for interval tasks.
+	cron *cronSchedule
+
+	// dispatchSeq identifies the latest entry of the task in the ordered dispatch queue, see
+	// StdSchedulerOptions.OrderedRunOnce.
+	dispatchSeq uint64
+
+	// firstFire is when the task was scheduled to execute for the first time.
+	firstFire time.Time
+
+	// gapDeferred is set while an execution is deferred to the end of the MinGap.
+	gapDeferred bool
+
+	// consecutiveSkips is the number of firings skipped since the latest execution.
+	consecutiveSkips int
+
+	// nextFire is the time the task timer is expected to fire at.
+	nextFire time.Time
+
+	// boostInterval replaces Interval until boostUntil, see StdScheduler.Boost.
+	boostInterval time.Duration
+	boostUntil    time.Time
+
+	// boostTimer ends the boost at boostUntil, boostSeq numbers the boosts so that a replaced one does not end the
+	// next.
+	boostTimer *time.Timer
+	boostSeq   uint64
+
+	// snoozeTimer resumes the task at snoozeUntil, snoozeSeq numbers the snoozes so that a replaced one does not
+	// wake the task. See StdScheduler.Snooze.
+	snoozeTimer *time.Timer
+	snoozeUntil time.Time
+	snoozeSeq   uint64
+
+	// damping counts the consecutive identical failures, see StdSchedulerOptions.ErrorDampingThreshold.
+	damping errorDamping
+
+	// succeeded is set when a RunOnce task completed successfully, see StdScheduler.AfterAll.
+	succeeded bool
+
+	// held is set when a fire is suppressed while the scheduler is paused, ResumeAll re-arms the task. See
+	// StdScheduler.PauseAll.
+	held bool
+
+	// inFlight is the number of executions in flight, it is incremented under the task lock.
+	inFlight atomic.Int32
+
+	// peakInFlight is the highest number of executions in flight at once so far.
+	peakInFlight int32
+
+	// skippedRunning is the number of fires skipped by SkipIfRunning so far.
+	skippedRunning uint64
+
+	// retryAttempts is the number of retries armed so far, see RetryBackoff.
+	retryAttempts int
+
+	// retriesRemaining is the number of retries left to the current failing cycle, out of RetriesOnError.
+	retriesRemaining int
+
+	// slots counts the executions against MaxConcurrent.
+	slots concurrencySlots
+
+	// carried is set when the task was transferred to another scheduler with executions in flight, its task
+	// context is released once they return. See StdScheduler.TransferWithOptions.
+	carried bool
+
+	// deadlineTimer removes the task at CompleteBy.
+	deadlineTimer *time.Timer
+
+	// cancelDeadline releases the task context bound to CompleteBy.
+	cancelDeadline context.CancelCauseFunc
 
 	// timer is the internal task timer. This is stored here to provide control via main scheduler functions.
 	timer *time.Timer
@@ -168,18 +477,83 @@ type Task struct {
 
 type TaskContext struct {
 	// Context is a user-defined context.
+	//
+	// Every execution is handed a context of its own, derived from it and cancelled once the execution returns, so
+	// that cancelling the context of one execution does not affect the following ones. Cancelling the user context
+	// still cancels every execution.
 	Context context.Context
 
 	// Cancel is used to cancel task execution on FuncWithTaskContext.
+	//
+	// When Cancel is not set, the scheduler wraps Context with its own cancel function, which becomes Cancel. Del
+	// then interrupts executions through the context handed to them, while the user context still cancels them too.
+	// The Cancel handed to the task and error functions cancels the context of their execution only.
 	Cancel context.CancelFunc
 
 	// id is the Unique ID created for each task. This ID is generated by the Add() function.
 	id string
+
+	// runSequence is the number of the execution cycle this context was created for.
+	runSequence uint64
+
+	// attempt is the number of the execution this context was created for within its cycle.
+	attempt int
+
+	// runID identifies the execution this context was created for. It is only formatted when read, so that executions
+	// nobody asks the ID of do not pay for it.
+	runID xid.ID
+
+	// runTimes holds the timestamps of the execution this context was created for.
+	runTimes RunTimes
+
+	// draining is closed when the scheduler starts shutting down.
+	draining <-chan struct{}
+
+	// replicaIndex is the index of the replica for tasks added with AddReplicated.
+	replicaIndex int
+
+	// trigger is why the execution this context was created for is happening.
+	trigger Trigger
+
+	// group tracks the goroutines started with Go during the execution this context was created for.
+	group *runGroup
+
+	// checkpoint is the checkpoint of the task when the execution this context was created for started.
+	checkpoint []byte
+
+	// pendingCheckpoint holds the checkpoint set during the execution this context was created for.
+	pendingCheckpoint *runCheckpoint
+
+	// dryRun is set when the execution this context was created for is a dry run, see Task.DryRun.
+	dryRun bool
+
+	// finalRun is set when the execution this context was created for is the last one allowed by Task.MaxRuns.
+	finalRun bool
+
+	// slotted is set when the execution this context was created for holds one of the Task.MaxConcurrent slots.
+	slotted bool
 }
 
-type rescheduleOnErrorOpts struct {
-	interval time.Duration
-	count    int
+// MaxRescheduleRules is the maximum number of reschedule on error rules of a task, see Task.WithRescheduleOnError.
+const MaxRescheduleRules = 32
+
+// RescheduleRule is a reschedule on error rule of a task, as added with Task.WithRescheduleOnError,
+// Task.WithRescheduleOnErrorAs or Task.WithRescheduleOnErrorFunc.
+type RescheduleRule struct {
+	// Err is the error the rule matches, using errors.Is.
+	Err error
+
+	// Target is the target the rule matches, using errors.As.
+	Target any
+
+	// Match is the function the rule matches with.
+	Match func(error) bool
+
+	// Interval is the delay before the task runs again after a matching error.
+	Interval time.Duration
+
+	// Remaining is the number of reschedules left.
+	Remaining int
 }
 
 // safeOps safely change task's data
@@ -190,54 +564,414 @@ func (t *Task) safeOps(f func()) {
 	f()
 }
 
+// callErrFunc calls the error function of the task with the highest precedence.
+func (t *Task) callErrFunc(taskCtx TaskContext, err error) {
+	switch {
+	case t.ErrFuncWithTaskContext != nil:
+		t.ErrFuncWithTaskContext(taskCtx, err)
+	case t.ErrFuncWithID != nil:
+		t.ErrFuncWithID(t.id, err)
+	default:
+		t.ErrFunc(err)
+	}
+}
+
 // ID will return the task ID. This is the same as the ID generated by the scheduler when adding a task.
 // If the task was added with AddWithID, this will be the same as the ID provided.
 func (ctx TaskContext) ID() string {
 	return ctx.id
 }
 
-func (t *Task) WithRescheduleOnError(err error, interval time.Duration, count int) {
+// RunSequence will return the number of the execution cycle the task is currently in, starting at 1 for the first
+// execution. Retries and reschedules on error are part of the cycle that failed and report the same number.
+func (ctx TaskContext) RunSequence() uint64 {
+	return ctx.runSequence
+}
+
+// Attempt will return the number of the execution within its cycle, starting at 1 for the first execution and
+// incremented by each retry and reschedule on error. It starts over at 1 with the next cycle, see RunSequence. The
+// resumption of a yielded execution keeps the attempt it yielded in.
+func (ctx TaskContext) Attempt() int {
+	return ctx.attempt
+}
+
+// RunID will return the unique ID of the execution, generated when it starts. Retries and reschedules on error get
+// their own. The scheduler logs it along with the task ID, e.g. "task (id: ..., run: ...)", so that the logs of an
+// execution can be correlated with the ones of the task and error functions. It is empty outside an execution.
+func (ctx TaskContext) RunID() string {
+	if ctx.runID.IsNil() {
+		return ""
+	}
+
+	return ctx.runID.String()
+}
+
+// ID will return the ID the task was added with, or an empty string if it has not been added. It is kept by the
+// copies returned by Lookup and Tasks, mirroring TaskContext.ID.
+func (t *Task) ID() string {
+	return t.id
+}
+
+// AddedAt will return when the task was added to the scheduler, or the zero time if it has not been added. Called on
+// a task returned by Lookup or Tasks, it tells how long the scheduled task has existed.
+func (t *Task) AddedAt() time.Time {
+	var addedAt time.Time
 	t.safeOps(func() {
-		if t.rescheduleOnError == nil {
-			t.rescheduleOnError = make(map[error]rescheduleOnErrorOpts)
+		addedAt = t.addedAt
+	})
+
+	return addedAt
+}
+
+// RunSequence will return the number of the last started execution cycle of the task, or 0 if it never ran.
+func (t *Task) RunSequence() uint64 {
+	var seq uint64
+	t.safeOps(func() {
+		seq = t.runSequence
+	})
+
+	return seq
+}
+
+// RunsRemaining will return how many executions of a task with MaxRuns are left before it is removed, or -1 if
+// MaxRuns is not set. Called on a task returned by Lookup or Tasks, it reflects the executions started at the time of
+// the lookup.
+func (t *Task) RunsRemaining() int {
+	remaining := -1
+	t.safeOps(func() {
+		if t.MaxRuns <= 0 {
+			return
 		}
 
-		t.rescheduleOnError[err] = rescheduleOnErrorOpts{
-			interval: interval,
-			count:    count,
+		remaining = t.MaxRuns - int(t.runSequence)
+		if remaining < 0 {
+			remaining = 0
 		}
 	})
+
+	return remaining
+}
+
+// NextRun will return when the task is due to execute next: its first fire while it waits for its StartAfter time,
+// then the time its timer is armed for. It returns the zero time when no execution is pending, e.g. while the task is
+// paused, including by StdScheduler.PauseAll, or once a RunOnce task has fired. It is kept by the copies returned by
+// Lookup and Tasks.
+func (t *Task) NextRun() time.Time {
+	var next time.Time
+	t.safeOps(func() {
+		next = t.nextRun()
+	})
+
+	return next
 }
 
-// Clone will create a copy of the existing task. This is useful for creating a new task with the same properties as
-// an existing task. It is also used internally when creating a new task.
+// nextRun returns the next run of the task like NextRun. The task lock must be held.
+func (t *Task) nextRun() time.Time {
+	if t.held {
+		return time.Time{}
+	}
+
+	switch t.state {
+	case TaskStatePending:
+		return t.firstFire
+	case TaskStateScheduled, TaskStateRunning, TaskStateRetrying:
+		// The fire the timer was armed for has already started
+		if !t.lastStart.IsZero() && !t.nextFire.After(t.lastStart) {
+			return time.Time{}
+		}
+		return t.nextFire
+	default:
+		return time.Time{}
+	}
+}
+
+// SetInterval will set the task Interval. It returns ErrReadOnlyTask on a task returned by Lookup or Tasks.
+func (t *Task) SetInterval(interval time.Duration) error {
+	return t.mutate(func() {
+		t.Interval = interval
+	})
+}
+
+// SetRunOnce will set whether the task runs only once. It returns ErrReadOnlyTask on a task returned by Lookup or
+// Tasks.
+func (t *Task) SetRunOnce(runOnce bool) error {
+	return t.mutate(func() {
+		t.RunOnce = runOnce
+	})
+}
+
+// SetRetries will set the number of retries on error, and the interval between them. It returns ErrReadOnlyTask on
+// a task returned by Lookup or Tasks.
+func (t *Task) SetRetries(retries int, interval time.Duration) error {
+	return t.mutate(func() {
+		t.RetriesOnError = retries
+		t.RetryOnErrorInterval = interval
+	})
+}
+
+// SetFuncs will set the task function and its error function. It returns ErrReadOnlyTask on a task returned by
+// Lookup or Tasks.
+func (t *Task) SetFuncs(taskFunc func() error, errFunc func(error)) error {
+	return t.mutate(func() {
+		t.TaskFunc = taskFunc
+		t.ErrFunc = errFunc
+	})
+}
+
+// WithRescheduleOnError will reschedule the task after interval when it fails with err, at most count times.
+//
+// Rules are identified by their error value: adding a rule for the same value replaces it, while two distinct values
+// with the same message, such as two errors.New or fmt.Errorf calls, make two rules. Failures match a rule with
+// errors.Is, so err should be a sentinel declared once, typically a package variable, see WithRescheduleOnErrorAs and
+// WithRescheduleOnErrorFunc for typed errors and other conditions. Failures are matched against the rules in
+// registration order, and the first matching rule applies. A task holds at most MaxRescheduleRules rules, see
+// RescheduleRules to list them.
+//
+// It returns ErrTooManyRescheduleRules beyond MaxRescheduleRules, and ErrReadOnlyTask on a task returned by Lookup or
+// Tasks.
+func (t *Task) WithRescheduleOnError(err error, interval time.Duration, count int) error {
+	var addErr error
+	if err := t.mutate(func() {
+		addErr = t.addRescheduleRule(err, interval, count)
+	}); err != nil {
+		return err
+	}
+
+	return addErr
+}
+
+// addRescheduleRule sets the reschedule rule of err, the task lock must be held.
+func (t *Task) addRescheduleRule(err error, interval time.Duration, count int) error {
+	rule := rescheduleRule{err: err, interval: interval, count: count}
+	if t.findRescheduleRule(rule) < 0 && len(t.rescheduleOnError) < MaxRescheduleRules {
+		// A distinct error with the same message is most likely created per call by mistake, it never matches
+		for _, r := range t.rescheduleOnError {
+			if r.err != nil && r.err.Error() == err.Error() {
+				logger.Warnf("task (id: %s) already has a reschedule rule for a distinct error with the same "+
+					"message, rules match errors by identity: %s", t.id, err.Error())
+
+				break
+			}
+		}
+	}
+
+	return t.setRescheduleRule(rule)
+}
+
+// mutate applies f under the task lock, unless the task is a read-only copy. Modifying a copy would silently have
+// no effect on the scheduled task, so it is logged at Error level.
+func (t *Task) mutate(f func()) error {
+	if t.readOnly {
+		logger.Errorf("task (id: %s) is a read-only copy and cannot be modified, use LookupForUpdate", t.id)
+
+		return ErrReadOnlyTask
+	}
+
+	t.safeOps(f)
+
+	return nil
+}
+
+// RescheduleRules will return the reschedule on error rules of the task with their remaining reschedules, in
+// registration order, which is the order failures are matched in. Called on a task returned by Lookup or Tasks, it
+// reflects the rules at the time of the lookup.
+func (t *Task) RescheduleRules() []RescheduleRule {
+	var rules []RescheduleRule
+	t.safeOps(func() {
+		for _, r := range t.rescheduleOnError {
+			rules = append(rules, RescheduleRule{
+				Err:       r.err,
+				Target:    r.target,
+				Match:     r.match,
+				Interval:  r.interval,
+				Remaining: r.count,
+			})
+		}
+	})
+
+	return rules
+}
+
+// Clone will create a copy of the existing task, including its scheduling state such as its ID, contexts and timers.
+// It is used internally when creating a new task. To create a new task with the same properties as an existing
+// task, use CloneForReuse.
 func (t *Task) Clone() *Task {
+	var task *Task
+	t.safeOps(func() {
+		task = t.clone()
+	})
+
+	return task
+}
+
+// clone copies the task like Clone. The task lock must be held.
+func (t *Task) clone() *Task {
+	task := &Task{}
+	task.TaskFunc = t.TaskFunc
+	task.FuncWithTaskContext = t.FuncWithTaskContext
+	task.ErrFunc = t.ErrFunc
+	task.ErrFuncWithTaskContext = t.ErrFuncWithTaskContext
+	task.ExhaustedFunc = t.ExhaustedFunc
+	task.ExhaustedFuncWithTaskContext = t.ExhaustedFuncWithTaskContext
+	task.FuncWithID = t.FuncWithID
+	task.ErrFuncWithID = t.ErrFuncWithID
+	task.Interval = t.Interval
+	task.StartAfter = t.StartAfter
+	task.ExcludedDates = t.ExcludedDates
+	task.Debug = t.Debug
+	task.trace = t.trace
+	task.nextFire = t.nextFire
+	task.SLO = t.SLO
+	task.DryRun = t.DryRun
+	task.DryRunDuration = t.DryRunDuration
+	task.MinGap = t.MinGap
+	task.MaxConsecutiveSkips = t.MaxConsecutiveSkips
+	task.CompleteBy = t.CompleteBy
+	task.BypassWorkerLimit = t.BypassWorkerLimit
+	task.Exclusive = t.Exclusive
+	task.Lane = t.Lane
+	task.Group = t.Group
+	task.Timeout = t.Timeout
+	task.CronExpr = t.CronExpr
+	task.Location = t.Location
+	task.deadlineTimer = t.deadlineTimer
+	task.boostInterval = t.boostInterval
+	task.boostUntil = t.boostUntil
+	task.boostTimer = t.boostTimer
+	task.boostSeq = t.boostSeq
+	task.snoozeTimer = t.snoozeTimer
+	task.snoozeUntil = t.snoozeUntil
+	task.snoozeSeq = t.snoozeSeq
+	task.held = t.held
+	task.cancelDeadline = t.cancelDeadline
+	task.consecutiveSkips = t.consecutiveSkips
+	task.addedAt = t.addedAt
+	task.checkpoint = t.checkpoint
+	task.lastStart = t.lastStart
+	task.lastRun = t.lastRun
+	task.runCount = t.runCount
+	task.peakInFlight = t.peakInFlight
+	task.skippedRunning = t.skippedRunning
+	task.retryAttempts = t.retryAttempts
+	task.retriesRemaining = t.retriesRemaining
+	task.lastErr = t.lastErr
+	task.cron = t.cron
+	task.firstFire = t.firstFire
+	task.slo = t.slo
+	task.RunOnce = t.RunOnce
+	task.MaxRuns = t.MaxRuns
+	task.MaxConcurrent = t.MaxConcurrent
+	task.ConcurrencyPolicy = t.ConcurrencyPolicy
+	task.MaxQueueDelay = t.MaxQueueDelay
+	task.SkipIfRunning = t.SkipIfRunning
+	task.Mode = t.Mode
+	task.DampenRepeatedErrors = t.DampenRepeatedErrors
+	task.RetriesOnError = t.RetriesOnError
+	task.RetryOnErrorInterval = t.RetryOnErrorInterval
+	task.RetryBackoff = t.RetryBackoff
+	task.InlineRetries = t.InlineRetries
+	task.id = t.id
+	task.ctx = t.ctx
+	task.cancel = t.cancel
+	task.timer = t.timer
+	task.TaskContext = t.TaskContext
+	task.registered = t.registered
+	task.ownsTaskContext = t.ownsTaskContext
+	task.userContext = t.userContext
+	task.runSequence = t.runSequence
+	task.attempt = t.attempt
+	task.retryPending = t.retryPending
+	task.trigger = t.trigger
+	task.state = t.state
+
+	if t.rescheduleOnError == nil {
+		return task
+	}
+	task.rescheduleOnError = append([]rescheduleRule(nil), t.rescheduleOnError...)
+
+	return task
+}
+
+// CloneForReuse will create a new task with the same properties as the existing task. Only the configuration and
+// the user functions are copied, along with the remaining reschedules of the reschedule on error rules. The ID,
+// contexts created by the scheduler and timers are not, so the copy is safe to pass to Add and is scheduled
+// independently of the existing task.
+func (t *Task) CloneForReuse() *Task {
 	task := &Task{}
 	t.safeOps(func() {
 		task.TaskFunc = t.TaskFunc
 		task.FuncWithTaskContext = t.FuncWithTaskContext
 		task.ErrFunc = t.ErrFunc
 		task.ErrFuncWithTaskContext = t.ErrFuncWithTaskContext
+		task.ExhaustedFunc = t.ExhaustedFunc
+		task.ExhaustedFuncWithTaskContext = t.ExhaustedFuncWithTaskContext
+		task.FuncWithID = t.FuncWithID
+		task.ErrFuncWithID = t.ErrFuncWithID
 		task.Interval = t.Interval
 		task.StartAfter = t.StartAfter
+		task.ExcludedDates = t.ExcludedDates
+		task.Debug = t.Debug
+		task.SLO = t.SLO
+		task.DryRun = t.DryRun
+		task.DryRunDuration = t.DryRunDuration
+		task.MinGap = t.MinGap
+		task.MaxConsecutiveSkips = t.MaxConsecutiveSkips
+		task.CompleteBy = t.CompleteBy
+		task.BypassWorkerLimit = t.BypassWorkerLimit
+		task.Exclusive = t.Exclusive
+		task.Lane = t.Lane
+		task.Group = t.Group
+		task.Timeout = t.Timeout
+		task.CronExpr = t.CronExpr
+		task.Location = t.Location
 		task.RunOnce = t.RunOnce
+		task.MaxRuns = t.MaxRuns
+		task.MaxConcurrent = t.MaxConcurrent
+		task.ConcurrencyPolicy = t.ConcurrencyPolicy
+		task.MaxQueueDelay = t.MaxQueueDelay
+		task.SkipIfRunning = t.SkipIfRunning
+		task.Mode = t.Mode
+		task.DampenRepeatedErrors = t.DampenRepeatedErrors
 		task.RetriesOnError = t.RetriesOnError
 		task.RetryOnErrorInterval = t.RetryOnErrorInterval
-		task.id = t.id
-		task.ctx = t.ctx
-		task.cancel = t.cancel
-		task.timer = t.timer
-		task.TaskContext = t.TaskContext
+		task.RetryBackoff = t.RetryBackoff
+		task.InlineRetries = t.InlineRetries
+
+		// Only contexts supplied by the user are carried over
+		if t.ownsTaskContext {
+			task.TaskContext.Context = t.userContext
+		} else {
+			task.TaskContext.Context, task.TaskContext.Cancel = t.TaskContext.Context, t.TaskContext.Cancel
+		}
 
 		if t.rescheduleOnError == nil {
 			return
 		}
-		rescheduleOnError := make(map[error]rescheduleOnErrorOpts, len(t.rescheduleOnError))
-		for k, v := range t.rescheduleOnError {
-			rescheduleOnError[k] = v
-		}
-		task.rescheduleOnError = rescheduleOnError
+		task.rescheduleOnError = append([]rescheduleRule(nil), t.rescheduleOnError...)
 	})
 
 	return task
 }
+
+// firstDelay returns the delay between the start of the schedule, StartAfter or the time the task was added, and its
+// first execution. A RunOnce task with a StartAfter time runs at that time, otherwise the first execution waits for
+// the interval, or the next time matching its cron expression. The task lock must be held.
+func (t *Task) firstDelay(start time.Time) time.Duration {
+	if t.RunOnce && !t.StartAfter.IsZero() {
+		return 0
+	}
+
+	if t.cron != nil && !t.boosted(start) {
+		return t.cron.delay(start)
+	}
+
+	return t.interval()
+}
+
+// readOnlyClone returns a copy of the task whose setters return ErrReadOnlyTask.
+func (t *Task) readOnlyClone() *Task {
+	task := t.Clone()
+	task.readOnly = true
+
+	return task
+}
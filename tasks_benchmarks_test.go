@@ -1,6 +1,8 @@
 package tasks
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -57,3 +59,179 @@ func BenchmarkTasks(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkFireAndForget(b *testing.B) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	b.Run("Adding a RunOnce task", func(b *testing.B) {
+		var wg sync.WaitGroup
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			wg.Add(1)
+			_, err := scheduler.Add(&Task{
+				RunOnce:  true,
+				TaskFunc: func() error { wg.Done(); return nil },
+				ErrFunc:  func(e error) {},
+			})
+			if err != nil {
+				b.Fatalf("Unable to add new scheduled task - %s", err)
+			}
+		}
+		wg.Wait()
+	})
+
+	b.Run("Submitting a function", func(b *testing.B) {
+		var wg sync.WaitGroup
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			wg.Add(1)
+			err := scheduler.Submit(func() error { wg.Done(); return nil }, func(e error) {})
+			if err != nil {
+				b.Fatalf("Unable to submit function - %s", err)
+			}
+		}
+		wg.Wait()
+	})
+}
+
+func BenchmarkHasBatch(b *testing.B) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	// Check 2k IDs, half of them scheduled
+	ids := make([]string, 2000)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("task-%d", i)
+		if i%2 == 1 {
+			continue
+		}
+
+		err := scheduler.AddWithID(ids[i], &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		if err != nil {
+			b.Fatalf("Unable to schedule example task - %s", err)
+		}
+	}
+
+	b.Run("Has loop", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, id := range ids {
+				_ = scheduler.Has(id)
+			}
+		}
+	})
+
+	b.Run("HasAll", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = scheduler.HasAll(ids)
+		}
+	})
+
+	b.Run("Exists", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = scheduler.Exists(ids...)
+		}
+	})
+}
+
+func BenchmarkAddConcurrent(b *testing.B) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	b.Run("Adding RunOnce tasks from parallel goroutines", func(b *testing.B) {
+		var wg sync.WaitGroup
+
+		b.ReportAllocs()
+		b.SetParallelism(16)
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				wg.Add(1)
+				_, err := scheduler.Add(&Task{
+					RunOnce:  true,
+					TaskFunc: func() error { wg.Done(); return nil },
+					ErrFunc:  func(e error) {},
+				})
+				if err != nil {
+					b.Errorf("Unable to add new scheduled task - %s", err)
+					wg.Done()
+				}
+			}
+		})
+		wg.Wait()
+	})
+}
+
+func BenchmarkRunOnceCompletions(b *testing.B) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	// Lookups compete with the removals of the completed tasks for the scheduler lock, run with -mutexprofile to
+	// compare the contention
+	taskID, err := scheduler.Add(&Task{
+		Interval: time.Hour,
+		TaskFunc: func() error { return nil },
+		ErrFunc:  func(e error) {},
+	})
+	if err != nil {
+		b.Fatalf("Unable to schedule example task - %s", err)
+	}
+
+	done := make(chan struct{})
+	var lookups sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		lookups.Add(1)
+		go func() {
+			defer lookups.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					_, _ = scheduler.Lookup(taskID)
+				}
+			}
+		}()
+	}
+
+	b.Run("Completing RunOnce tasks under lookups", func(b *testing.B) {
+		var wg sync.WaitGroup
+
+		b.ReportAllocs()
+		b.SetParallelism(16)
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				wg.Add(1)
+				_, err := scheduler.Add(&Task{
+					RunOnce:  true,
+					TaskFunc: func() error { wg.Done(); return nil },
+					ErrFunc:  func(e error) {},
+				})
+				if err != nil {
+					b.Errorf("Unable to add new scheduled task - %s", err)
+					wg.Done()
+				}
+			}
+		})
+		wg.Wait()
+	})
+
+	close(done)
+	lookups.Wait()
+}
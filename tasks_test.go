@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -240,12 +244,14 @@ func TestTaskExecution(t *testing.T) {
 		name:      "Valid Task with TaskContext",
 		callsFunc: true,
 	}
-	tc2.ctx, tc2.cancel = context.WithCancel(context.Background())
+	type ctxKey struct{}
+	tc2.ctx, tc2.cancel = context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "tc2"))
 	tc2.task = &Task{
 		Interval:    1 * time.Second,
 		TaskContext: TaskContext{Context: tc2.ctx, Cancel: tc2.cancel},
 		FuncWithTaskContext: func(taskCtx TaskContext) error {
-			if taskCtx.Context != tc2.ctx {
+			// Executions get a context derived from the user context
+			if taskCtx.Context.Value(ctxKey{}) != "tc2" {
 				t.Logf("TaskContext.Context does not match expected context")
 				// return with no error to trigger a timeout failure
 				return nil
@@ -740,6 +746,163 @@ func TestTaskCancellation(t *testing.T) {
 	})
 }
 
+func TestTaskCancellationWithUserContext(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify Del interrupts executions with a user context", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errCh := make(chan error, 1)
+		execStartedCh := make(chan struct{})
+
+		id, err := scheduler.Add(&Task{
+			RunOnce:     true,
+			StartAfter:  time.Now(),
+			TaskContext: TaskContext{Context: context.Background()},
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				close(execStartedCh)
+
+				select {
+				case <-taskCtx.Context.Done():
+					errCh <- taskCtx.Context.Err()
+				case <-time.After(time.Second):
+					errCh <- nil
+				}
+
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		<-execStartedCh
+		scheduler.Del(id)
+
+		assert.ErrorIs(<-errCh, context.Canceled)
+	})
+
+	t.Run("Verify the user context still cancels executions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		type key struct{}
+		userCtx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+
+		errCh := make(chan error, 1)
+
+		_, err := scheduler.Add(&Task{
+			RunOnce:     true,
+			StartAfter:  time.Now(),
+			TaskContext: TaskContext{Context: userCtx},
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				assert.Equal("value", taskCtx.Context.Value(key{}))
+				cancel()
+
+				select {
+				case <-taskCtx.Context.Done():
+					errCh <- taskCtx.Context.Err()
+				case <-time.After(time.Second):
+					errCh <- nil
+				}
+
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		assert.ErrorIs(<-errCh, context.Canceled)
+	})
+}
+
+func TestPerRunContext(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify cancelling an execution does not affect the next one", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs int64
+		live := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("cancelled", &Task{
+			Interval: 5 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				if atomic.AddInt64(&runs, 1) == 1 {
+					taskCtx.Cancel()
+					assert.ErrorIs(taskCtx.Context.Err(), context.Canceled)
+					return errors.New("cancelled")
+				}
+
+				if taskCtx.Context.Err() == nil {
+					select {
+					case <-live:
+					default:
+						close(live)
+					}
+				}
+				return nil
+			},
+			// Cancelling from the error function only affects the failed execution too
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, _ error) {
+				taskCtx.Cancel()
+			},
+		}))
+
+		select {
+		case <-live:
+		case <-time.After(time.Second):
+			t.Fatal("the following executions got a cancelled context")
+		}
+		scheduler.Del("cancelled")
+	})
+
+	t.Run("Verify the context of an execution is cancelled once it returns", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		ctxCh := make(chan context.Context, 1)
+		assert.NoError(scheduler.AddWithID("leaked", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				ctxCh <- taskCtx.Context
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		ctx := <-ctxCh
+		assert.Eventually(func() bool { return ctx.Err() != nil }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify Del still cancels the execution", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		started := make(chan struct{})
+		errCh := make(chan error, 1)
+		assert.NoError(scheduler.AddWithID("deleted", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				close(started)
+				<-taskCtx.Context.Done()
+				errCh <- taskCtx.Context.Err()
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		<-started
+		scheduler.Del("deleted")
+
+		select {
+		case err := <-errCh:
+			assert.ErrorIs(err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("the execution was not cancelled")
+		}
+	})
+}
+
 func TestSchedulerWorkerLimit(t *testing.T) {
 	scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 5})
 
@@ -747,9 +910,9 @@ func TestSchedulerWorkerLimit(t *testing.T) {
 		doneCh := make(chan struct{})
 		t.Cleanup(func() { close(doneCh) })
 
-		done := false
+		var done atomic.Bool
 		t.Cleanup(func() {
-			done = true
+			done.Store(true)
 		})
 
 		taskIDs := make([]string, 0, 10)
@@ -760,7 +923,7 @@ func TestSchedulerWorkerLimit(t *testing.T) {
 				RunOnce:  true,
 				TaskFunc: func() error {
 					time.Sleep(time.Second)
-					if done {
+					if done.Load() {
 						return nil
 					}
 
@@ -876,6 +1039,181 @@ func TestRetriesOnError(t *testing.T) {
 			scheduler.Del(id)
 		})
 	})
+
+	t.Run("Verify schedule error on adding recurring task with retries on error", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		_, err := scheduler.Add(&Task{
+			Interval:       200 * time.Millisecond,
+			RetriesOnError: 3,
+			TaskFunc: func() error {
+				return nil
+			},
+			ErrFunc: func(err error) {},
+		})
+
+		assert.ErrorIs(err, ErrRetryOnErrorIntervalEmpty)
+	})
+
+	t.Run("Verify recurring task retries every failed tick", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu     sync.Mutex
+			starts []time.Time
+		)
+		id, err := scheduler.Add(&Task{
+			Interval:             200 * time.Millisecond,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				starts = append(starts, time.Now())
+				return errors.New("some error")
+			},
+			ErrFunc: func(err error) {},
+		})
+		assert.NoError(err)
+
+		t.Cleanup(func() {
+			scheduler.Del(id)
+		})
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(starts) >= 6
+		}, 2*time.Second, 5*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Each tick is retried twice, then the task resumes its interval with its retries back
+		for i := 1; i < 6; i++ {
+			gap := starts[i].Sub(starts[i-1])
+			if i%3 == 0 {
+				assert.Greater(gap, 150*time.Millisecond, "execution %d", i)
+			} else {
+				assert.Less(gap, 100*time.Millisecond, "execution %d", i)
+			}
+		}
+	})
+
+	t.Run("Verify recurring task gets its retries back after a success", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		// Fail, retry successfully, then fail twice and retry twice within the next tick
+		outcomes := []bool{false, true, false, false, true}
+		var (
+			mu     sync.Mutex
+			starts []time.Time
+		)
+		id, err := scheduler.Add(&Task{
+			Interval:             200 * time.Millisecond,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				starts = append(starts, time.Now())
+				if n := len(starts); n > len(outcomes) || outcomes[n-1] {
+					return nil
+				}
+				return errors.New("some error")
+			},
+			ErrFunc: func(err error) {},
+		})
+		assert.NoError(err)
+
+		t.Cleanup(func() {
+			scheduler.Del(id)
+		})
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(starts) >= len(outcomes)
+		}, 2*time.Second, 5*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Less(starts[1].Sub(starts[0]), 100*time.Millisecond)
+		assert.Greater(starts[2].Sub(starts[1]), 150*time.Millisecond)
+		assert.Less(starts[3].Sub(starts[2]), 100*time.Millisecond)
+		assert.Less(starts[4].Sub(starts[3]), 100*time.Millisecond)
+	})
+}
+
+func TestRetriesRemaining(t *testing.T) {
+	t.Run("Verify the retries are restored after a success", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		// Fail twice, succeed, then fail three times and succeed on the third retry
+		outcomes := []bool{false, false, true, false, false, false, true}
+		var (
+			mu        sync.Mutex
+			triggers  []Trigger
+			remaining []int
+		)
+		done := make(chan struct{})
+		assert.NoError(scheduler.AddWithID("restored", &Task{
+			Interval:             100 * time.Millisecond,
+			RetriesOnError:       3,
+			RetryOnErrorInterval: 5 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				triggers = append(triggers, taskCtx.Trigger())
+				if task, err := scheduler.Lookup("restored"); err == nil {
+					remaining = append(remaining, task.RetriesRemaining())
+				}
+
+				n := len(triggers)
+				if n == len(outcomes) {
+					close(done)
+				}
+				if n > len(outcomes) || outcomes[n-1] {
+					return nil
+				}
+				return errors.New("some error")
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("the task did not execute 7 times")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal([]Trigger{
+			TriggerInterval, TriggerRetry, TriggerRetry,
+			TriggerInterval, TriggerRetry, TriggerRetry, TriggerRetry,
+		}, triggers)
+		assert.Equal([]int{3, 2, 1, 3, 2, 1, 0}, remaining)
+
+		assert.Eventually(func() bool {
+			status, err := scheduler.TaskStatus("restored")
+			return err == nil && status.RetriesRemaining == 3
+		}, time.Second, time.Millisecond)
+
+		task, err := scheduler.Lookup("restored")
+		assert.NoError(err)
+		assert.Equal(3, task.RetriesOnError)
+	})
 }
 
 func TestRescheduleOnError(t *testing.T) {
@@ -930,6 +1268,165 @@ func TestRescheduleOnError(t *testing.T) {
 		}
 
 	})
+
+	t.Run("Verify typed errors and predicates reschedule wrapped errors", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errs := []error{
+			fmt.Errorf("dial: %w", &statusError{code: 503}),
+			fmt.Errorf("query: %w", &timeoutError{}),
+			fmt.Errorf("fetch: %w", &statusError{code: 404}),
+		}
+		var (
+			mu       sync.Mutex
+			triggers []Trigger
+		)
+		task := &Task{
+			StartAfter: time.Now().Add(10 * time.Millisecond),
+			RunOnce:    true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				triggers = append(triggers, taskCtx.Trigger())
+				if n := len(triggers); n <= len(errs) {
+					return errs[n-1]
+				}
+				return nil
+			},
+			ErrFunc: func(err error) {},
+		}
+
+		// Any 5xx status, then any timeout
+		assert.NoError(task.WithRescheduleOnErrorFunc(func(err error) bool {
+			var statusErr *statusError
+			return errors.As(err, &statusErr) && statusErr.code >= 500
+		}, 10*time.Millisecond, 1))
+		assert.NoError(task.WithRescheduleOnErrorAs(new(*timeoutError), 10*time.Millisecond, 1))
+
+		id, err := scheduler.Add(task)
+		assert.NoError(err)
+		t.Cleanup(func() {
+			scheduler.Del(id)
+		})
+
+		// The 404 matches no rule and ends the task
+		assert.Eventually(func() bool { return !scheduler.Has(id) }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal([]Trigger{TriggerStartAfter, TriggerRescheduleOnError, TriggerRescheduleOnError}, triggers)
+	})
+
+	t.Run("Verify the first matching rule applies", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errSentinel := errors.New("unavailable")
+		task := &Task{Interval: time.Minute, TaskFunc: func() error { return nil }, ErrFunc: func(error) {}}
+		assert.NoError(task.WithRescheduleOnErrorAs(new(*statusError), time.Second, 1))
+		assert.NoError(task.WithRescheduleOnError(errSentinel, time.Minute, 1))
+		assert.NoError(task.WithRescheduleOnErrorFunc(func(error) bool { return true }, time.Hour, 1))
+
+		// Rules of the same target type replace each other in place
+		assert.NoError(task.WithRescheduleOnErrorAs(new(*statusError), 2*time.Second, 2))
+
+		rules := task.RescheduleRules()
+		if assert.Len(rules, 3) {
+			assert.Equal(2*time.Second, rules[0].Interval)
+			assert.IsType(new(*statusError), rules[0].Target)
+			assert.Equal(errSentinel, rules[1].Err)
+			assert.NotNil(rules[2].Match)
+		}
+
+		// A status error wrapping an exhausted sentinel is matched by the typed rule first
+		var runs atomic.Int32
+		ordered := &Task{
+			StartAfter: time.Now().Add(10 * time.Millisecond),
+			RunOnce:    true,
+			TaskFunc: func() error {
+				if runs.Add(1) == 1 {
+					return fmt.Errorf("wrapped: %w", &statusError{code: 500, err: errSentinel})
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}
+		assert.NoError(ordered.WithRescheduleOnErrorAs(new(*statusError), 10*time.Millisecond, 1))
+		assert.NoError(ordered.WithRescheduleOnError(errSentinel, time.Minute, 0))
+
+		id, err := scheduler.Add(ordered)
+		assert.NoError(err)
+		t.Cleanup(func() {
+			scheduler.Del(id)
+		})
+
+		assert.Eventually(func() bool { return !scheduler.Has(id) }, time.Second, time.Millisecond)
+		assert.Equal(int32(2), runs.Load())
+	})
+
+	t.Run("Verify invalid targets are refused", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		task := &Task{Interval: time.Minute, TaskFunc: func() error { return nil }, ErrFunc: func(error) {}}
+		var statusErr *statusError
+		assert.ErrorIs(task.WithRescheduleOnErrorAs(nil, time.Second, 1), ErrInvalidRescheduleTarget)
+		assert.ErrorIs(task.WithRescheduleOnErrorAs(statusErr, time.Second, 1), ErrInvalidRescheduleTarget)
+		assert.ErrorIs(task.WithRescheduleOnErrorAs(new(int), time.Second, 1), ErrInvalidRescheduleTarget)
+		assert.ErrorIs(task.WithRescheduleOnErrorAs(statusError{}, time.Second, 1), ErrInvalidRescheduleTarget)
+		assert.NoError(task.WithRescheduleOnErrorAs(new(interface{ Timeout() bool }), time.Second, 1))
+		assert.Len(task.RescheduleRules(), 1)
+	})
+
+	t.Run("Verify rules are identified by their error value", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var b bytes.Buffer
+		task := &Task{Interval: time.Minute, TaskFunc: func() error { return nil }, ErrFunc: func(error) {}}
+
+		errSentinel := errors.New("unavailable")
+		logger.With(logger.NewSimpleLogger(log.New(&b, "", 0), logger.LevelInfo), func() {
+			assert.NoError(task.WithRescheduleOnError(errSentinel, time.Second, 1))
+			assert.NoError(task.WithRescheduleOnError(errSentinel, time.Minute, 2))
+
+			// A distinct error with the same message is a rule of its own, and is reported
+			assert.NoError(task.WithRescheduleOnError(fmt.Errorf("unavailable"), time.Second, 1))
+		})
+
+		rules := task.RescheduleRules()
+		if assert.Len(rules, 2) {
+			for _, rule := range rules {
+				assert.Equal("unavailable", rule.Err.Error())
+			}
+		}
+		assert.Contains(rules, RescheduleRule{Err: errSentinel, Interval: time.Minute, Remaining: 2})
+		assert.Equal(1, strings.Count(b.String(), "already has a reschedule rule for a distinct error with the same message"))
+	})
+
+	t.Run("Verify the number of rules is capped", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		task := &Task{Interval: time.Minute, TaskFunc: func() error { return nil }, ErrFunc: func(error) {}}
+
+		sentinels := make([]error, MaxRescheduleRules)
+		for i := range sentinels {
+			sentinels[i] = fmt.Errorf("error %d", i)
+			assert.NoError(task.WithRescheduleOnError(sentinels[i], time.Second, 1))
+		}
+
+		assert.ErrorIs(task.WithRescheduleOnError(errors.New("one too many"), time.Second, 1), ErrTooManyRescheduleRules)
+		assert.Len(task.RescheduleRules(), MaxRescheduleRules)
+
+		// Replacing a rule is still allowed
+		assert.NoError(task.WithRescheduleOnError(sentinels[0], time.Minute, 3))
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+		assert.NoError(scheduler.AddWithID("capped", task))
+
+		editor, err := scheduler.LookupForUpdate("capped")
+		assert.NoError(err)
+		assert.ErrorIs(editor.AddRescheduleRule(errors.New("one too many"), time.Second, 1), ErrTooManyRescheduleRules)
+	})
 }
 
 func TestTaskLimit(t *testing.T) {
@@ -969,9 +1466,9 @@ func TestTaskLimit(t *testing.T) {
 }
 
 func TestSchedulerLogger(t *testing.T) {
-	var b bytes.Buffer
+	b := &lockedWriter{mu: &sync.Mutex{}, w: &bytes.Buffer{}}
 
-	simpleLogger := logger.NewSimpleLogger(log.New(&b, "", log.LstdFlags), logger.LevelDebug)
+	simpleLogger := logger.NewSimpleLogger(log.New(b, "", log.LstdFlags), logger.LevelDebug)
 
 	scheduler := NewStdScheduler(StdSchedulerOptions{Logger: simpleLogger})
 
@@ -1008,8 +1505,8 @@ func TestSchedulerLogger(t *testing.T) {
 		}
 
 		assert.Contains(b.String(), fmt.Sprintf("task (id: %s) has been scheduled at %s",
-			id, startAfter.Format(time.RFC3339)))
-		assert.Contains(b.String(), fmt.Sprintf("task (id: %s) has been successfully executed", id))
+			id, startAfter.Format(logger.TimeFormatMicro)))
+		assert.Regexp(fmt.Sprintf(`task \(id: %s, run: \w+\) has been successfully executed`, id), b.String())
 	})
 
 }
@@ -1077,3 +1574,1705 @@ func TestSchedulerExtras(t *testing.T) {
 		}
 	})
 }
+
+func TestSubmit(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+	defer scheduler.Stop()
+
+	t.Run("Verify submitted function runs", func(t *testing.T) {
+		doneCh := make(chan struct{})
+
+		err := scheduler.Submit(func() error {
+			doneCh <- struct{}{}
+			return nil
+		}, func(e error) {
+			t.Errorf("ErrFunc should not be called")
+		})
+		if err != nil {
+			t.Errorf("Unexpected errors when submitting a valid function - %s", err)
+		}
+
+		select {
+		case <-doneCh:
+		case <-time.After(time.Second):
+			t.Errorf("Submitted function did not execute within 1 second")
+		}
+
+		if len(scheduler.Tasks()) != 0 {
+			t.Errorf("Submitted function should not be added to the task list")
+		}
+	})
+
+	t.Run("Verify ErrFunc gets called on errors", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errCh := make(chan error)
+
+		err := scheduler.Submit(func() error {
+			return errors.New("some error")
+		}, func(e error) {
+			errCh <- e
+		})
+		assert.NoError(err)
+
+		select {
+		case e := <-errCh:
+			assert.EqualError(e, "some error")
+		case <-time.After(time.Second):
+			t.Errorf("Error function was not called when an error occurred")
+		}
+	})
+
+	t.Run("Verify invalid submits are rejected", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		assert.ErrorIs(scheduler.Submit(nil, func(error) {}), ErrTaskExecFunctionsNotSet)
+		assert.ErrorIs(scheduler.Submit(func() error { return nil }, nil), ErrTaskErrFunctionsNotSet)
+	})
+}
+
+func TestRunID(t *testing.T) {
+	assert := assertions.New(t)
+
+	b := &lockedWriter{mu: &sync.Mutex{}, w: &bytes.Buffer{}}
+
+	var (
+		mu     sync.Mutex
+		runIDs []string
+		failed string
+	)
+	logger.With(logger.NewSimpleLogger(log.New(b, "", 0), logger.LevelDebug), func() {
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("correlated", &Task{
+			Interval:             time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				runIDs = append(runIDs, taskCtx.RunID())
+				if len(runIDs) == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			},
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, _ error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				failed = taskCtx.RunID()
+			},
+		}))
+
+		// The error function is called asynchronously
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return !scheduler.Has("correlated") && failed != ""
+		}, time.Second, time.Millisecond)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !assert.Len(runIDs, 2) {
+		return
+	}
+
+	// Every execution, retries included, gets its own run ID
+	assert.NotEmpty(runIDs[0])
+	assert.NotEqual(runIDs[0], runIDs[1])
+	assert.Equal(runIDs[0], failed)
+	assert.Empty(TaskContext{}.RunID())
+
+	logs := b.String()
+	assert.Contains(logs, fmt.Sprintf("task (id: correlated, run: %s, retries left: 1) failed: failed", runIDs[0]))
+	assert.Contains(logs, fmt.Sprintf("task (id: correlated, run: %s) has been successfully executed", runIDs[1]))
+}
+
+func TestRunSequence(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify run sequence increments on each interval", func(t *testing.T) {
+		seqCh := make(chan uint64)
+
+		id, err := scheduler.Add(&Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				seqCh <- taskCtx.RunSequence()
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		if err != nil {
+			t.Errorf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+
+		for i := uint64(1); i <= 3; i++ {
+			select {
+			case seq := <-seqCh:
+				if seq != i {
+					t.Errorf("Unexpected run sequence %d, expected %d", seq, i)
+				}
+			case <-time.After(time.Second):
+				t.Errorf("StdScheduler failed to execute the scheduled task %d run within 1 second", i)
+			}
+		}
+
+		scheduler.Del(id)
+	})
+
+	t.Run("Verify retries do not increment run sequence", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		seqCh := make(chan uint64, 3)
+		errCh := make(chan uint64, 3)
+
+		id, err := scheduler.Add(&Task{
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				seqCh <- taskCtx.RunSequence()
+				return errors.New("some error")
+			},
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, e error) {
+				errCh <- taskCtx.RunSequence()
+			},
+		})
+		assert.NoError(err)
+		defer scheduler.Del(id)
+
+		for i := 0; i < 3; i++ {
+			select {
+			case seq := <-seqCh:
+				assert.EqualValues(1, seq)
+			case <-time.After(time.Second):
+				t.Errorf("StdScheduler failed to execute the scheduled task attempt %d within 1 second", i)
+			}
+
+			select {
+			case seq := <-errCh:
+				assert.EqualValues(1, seq)
+			case <-time.After(time.Second):
+				t.Errorf("Error function was not called for attempt %d", i)
+			}
+		}
+	})
+}
+
+func TestAttempt(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify retries increment the attempt", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		attemptCh := make(chan int, 3)
+		errCh := make(chan int, 3)
+
+		id, err := scheduler.Add(&Task{
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				attemptCh <- taskCtx.Attempt()
+				return errors.New("some error")
+			},
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, e error) {
+				errCh <- taskCtx.Attempt()
+			},
+		})
+		assert.NoError(err)
+		defer scheduler.Del(id)
+
+		for i := 1; i <= 3; i++ {
+			select {
+			case attempt := <-attemptCh:
+				assert.Equal(i, attempt)
+			case <-time.After(time.Second):
+				t.Errorf("StdScheduler failed to execute the scheduled task attempt %d within 1 second", i)
+			}
+
+			select {
+			case attempt := <-errCh:
+				assert.Equal(i, attempt)
+			case <-time.After(time.Second):
+				t.Errorf("Error function was not called for attempt %d", i)
+			}
+		}
+	})
+
+	t.Run("Verify the attempt starts over with the next cycle", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		type run struct {
+			seq     uint64
+			attempt int
+		}
+		runCh := make(chan run, 10)
+
+		id, err := scheduler.Add(&Task{
+			Interval:             20 * time.Millisecond,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				runCh <- run{seq: taskCtx.RunSequence(), attempt: taskCtx.Attempt()}
+
+				// Every cycle fails once, then succeeds on its retry
+				if taskCtx.Attempt() == 1 {
+					return errors.New("some error")
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.Del(id)
+
+		for _, want := range []run{{1, 1}, {1, 2}, {2, 1}, {2, 2}} {
+			select {
+			case got := <-runCh:
+				assert.Equal(want, got)
+			case <-time.After(time.Second):
+				t.Errorf("StdScheduler failed to execute run %v within 1 second", want)
+			}
+		}
+	})
+
+	t.Run("Verify inline retries and reschedules increment the attempt", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errRetry := errors.New("retry")
+		attemptCh := make(chan int, 4)
+
+		task := &Task{
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: time.Millisecond,
+			InlineRetries:        true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				attemptCh <- taskCtx.Attempt()
+				if taskCtx.Attempt() == 1 {
+					return errRetry
+				}
+				return errors.New("some error")
+			},
+			ErrFunc: func(e error) {},
+		}
+		assert.NoError(task.WithRescheduleOnError(errRetry, time.Millisecond, 1))
+
+		id, err := scheduler.Add(task)
+		assert.NoError(err)
+		defer scheduler.Del(id)
+
+		for i := 1; i <= 4; i++ {
+			select {
+			case attempt := <-attemptCh:
+				assert.Equal(i, attempt)
+			case <-time.After(time.Second):
+				t.Errorf("StdScheduler failed to execute the scheduled task attempt %d within 1 second", i)
+			}
+		}
+	})
+}
+
+func TestExcludedDates(t *testing.T) {
+	t.Run("Verify excluded fire times are skipped", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var mu sync.Mutex
+		fired := 0
+
+		// Exclude the first two fire times, as if they landed on consecutive holidays
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			ExcludedDates: func(time.Time) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				fired++
+				return fired <= 2
+			},
+		})
+		defer scheduler.Stop()
+
+		seqCh := make(chan uint64)
+
+		_, err := scheduler.Add(&Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				seqCh <- taskCtx.RunSequence()
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case seq := <-seqCh:
+			assert.EqualValues(1, seq)
+		case <-time.After(time.Second):
+			t.Errorf("StdScheduler failed to execute the scheduled task within 1 second")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.GreaterOrEqual(fired, 3)
+	})
+
+	t.Run("Verify task exclusions override scheduler exclusions", func(t *testing.T) {
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			ExcludedDates: func(time.Time) bool { return true },
+		})
+		defer scheduler.Stop()
+
+		doneCh := make(chan struct{})
+
+		_, err := scheduler.Add(&Task{
+			Interval:      10 * time.Millisecond,
+			ExcludedDates: func(time.Time) bool { return false },
+			TaskFunc: func() error {
+				doneCh <- struct{}{}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		if err != nil {
+			t.Errorf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+
+		select {
+		case <-doneCh:
+		case <-time.After(time.Second):
+			t.Errorf("StdScheduler failed to execute the scheduled task within 1 second")
+		}
+	})
+}
+
+func TestMaxConsecutiveSkips(t *testing.T) {
+	t.Run("Verify task is removed after the skip limit", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu      sync.Mutex
+			skipped int
+		)
+		removedCh := make(chan string, 1)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{
+			OnScheduleChange: func(id string, next time.Time, reason string) {
+				if next.IsZero() {
+					removedCh <- reason
+				}
+			},
+		})
+		defer scheduler.Stop()
+
+		err := scheduler.AddWithID("skipping", &Task{
+			Interval:            5 * time.Millisecond,
+			MaxConsecutiveSkips: 3,
+			ExcludedDates: func(time.Time) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				skipped++
+				return true
+			},
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case reason := <-removedCh:
+			assert.Equal(removalReasonSkipLimit, reason)
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to remove the skipping task within 1 second")
+		}
+
+		// Give a wrongly re-armed timer the chance to fire
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(3, skipped)
+		assert.False(scheduler.Has("skipping"))
+	})
+
+	t.Run("Verify executions reset the skip count", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu    sync.Mutex
+			fired int
+			once  sync.Once
+		)
+		doneCh := make(chan struct{})
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		// Every other firing is skipped, the task must never reach 2 consecutive skips
+		err := scheduler.AddWithID("alternating", &Task{
+			Interval:            5 * time.Millisecond,
+			MaxConsecutiveSkips: 2,
+			ExcludedDates: func(time.Time) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				fired++
+				return fired%2 == 1
+			},
+			TaskFunc: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				if fired >= 8 {
+					once.Do(func() { close(doneCh) })
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case <-doneCh:
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to execute the alternating task within 1 second")
+		}
+
+		assert.True(scheduler.Has("alternating"))
+	})
+}
+
+func TestCompleteBy(t *testing.T) {
+	t.Run("Verify pending retries are cancelled at the deadline", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		var runs atomic.Int32
+		errCh := make(chan error, 10)
+
+		err := scheduler.AddWithID("deadline", &Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       100,
+			RetryOnErrorInterval: 20 * time.Millisecond,
+			CompleteBy:           time.Now().Add(100 * time.Millisecond),
+			TaskFunc: func() error {
+				runs.Add(1)
+				return errors.New("some error")
+			},
+			ErrFunc: func(e error) {
+				errCh <- e
+			},
+		})
+		assert.NoError(err)
+
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case e := <-errCh:
+				if !errors.Is(e, ErrDeadlineExceeded) {
+					continue
+				}
+			case <-timeout:
+				t.Fatalf("StdScheduler failed to deliver the deadline error within 1 second")
+			}
+
+			break
+		}
+
+		assert.False(scheduler.Has("deadline"))
+
+		// Retries consumed the budget, and none are left pending
+		n := runs.Load()
+		assert.GreaterOrEqual(n, int32(3))
+		assert.LessOrEqual(n, int32(6))
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(n, runs.Load())
+	})
+
+	t.Run("Verify in flight executions are cancelled with the deadline cause", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		causeCh := make(chan error, 1)
+
+		err := scheduler.AddWithID("in-flight", &Task{
+			Interval:   10 * time.Millisecond,
+			RunOnce:    true,
+			CompleteBy: time.Now().Add(50 * time.Millisecond),
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				<-taskCtx.Context.Done()
+				causeCh <- context.Cause(taskCtx.Context)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case cause := <-causeCh:
+			assert.ErrorIs(cause, ErrDeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to cancel the in flight execution within 1 second")
+		}
+	})
+
+	t.Run("Verify completed tasks do not report the deadline", func(t *testing.T) {
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		doneCh := make(chan struct{})
+		errCh := make(chan error, 1)
+
+		err := scheduler.AddWithID("completed", &Task{
+			Interval:   10 * time.Millisecond,
+			RunOnce:    true,
+			CompleteBy: time.Now().Add(50 * time.Millisecond),
+			TaskFunc: func() error {
+				close(doneCh)
+				return nil
+			},
+			ErrFunc: func(e error) {
+				errCh <- e
+			},
+		})
+		assertions.NoError(t, err)
+
+		<-doneCh
+
+		select {
+		case e := <-errCh:
+			t.Errorf("Unexpected error delivered after completion - %s", e)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func TestBypassWorkerLimit(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 2})
+
+	// Saturate the pool with slow tasks
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("bulk-%d", i)
+		err := scheduler.AddWithID(id, &Task{
+			Interval: 5 * time.Millisecond,
+			TaskFunc: func() error {
+				time.Sleep(200 * time.Millisecond)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		t.Cleanup(func() { scheduler.Del(id) })
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	startCh := make(chan time.Time, 10)
+	added := time.Now()
+
+	err := scheduler.AddWithID("heartbeat", &Task{
+		Interval:          20 * time.Millisecond,
+		BypassWorkerLimit: true,
+		TaskFunc: func() error {
+			select {
+			case startCh <- time.Now():
+			default:
+			}
+			return nil
+		},
+		ErrFunc: func(e error) {},
+	})
+	assert.NoError(err)
+	t.Cleanup(func() { scheduler.Del("heartbeat") })
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case started := <-startCh:
+			assert.Less(started.Sub(added), time.Duration(i)*20*time.Millisecond+15*time.Millisecond)
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to start the heartbeat within 1 second")
+		}
+	}
+}
+
+func TestRetryBeforeTimerArmed(t *testing.T) {
+	t.Run("Verify retrying a task without a timer arms one", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		retriedCh := make(chan struct{})
+		task := &Task{
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: time.Millisecond,
+			TaskFunc: func() error {
+				close(retriedCh)
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}
+		task.id = "no-timer"
+		task.state = TaskStateRunning
+		task.retriesRemaining = task.RetriesOnError
+		task.ctx, task.cancel = context.WithCancel(context.Background())
+		defer task.cancel()
+
+		// The error path runs before the dispatch path assigned the timer
+		assert.NotPanics(func() {
+			scheduler.onTaskError(task, task.TaskContext, errors.New("some error"))
+		})
+
+		select {
+		case <-retriedCh:
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to retry the task within 1 second")
+		}
+	})
+
+	t.Run("Verify immediate RunOnce tasks retry", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		for i := 0; i < 200; i++ {
+			wg.Add(1)
+
+			var attempts atomic.Int32
+			_, err := scheduler.Add(&Task{
+				RunOnce:              true,
+				RetriesOnError:       1,
+				RetryOnErrorInterval: time.Nanosecond,
+				TaskFunc: func() error {
+					if attempts.Add(1) == 1 {
+						return errors.New("some error")
+					}
+					wg.Done()
+					return nil
+				},
+				ErrFunc: func(e error) {},
+			})
+			assertions.NoError(t, err)
+		}
+
+		doneCh := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(doneCh)
+		}()
+
+		select {
+		case <-doneCh:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("StdScheduler failed to retry every task within 5 seconds")
+		}
+	})
+}
+
+func TestHasBatch(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	for _, id := range []string{"a", "b"} {
+		err := scheduler.AddWithID(id, &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+	}
+
+	assert.Nil(scheduler.HasAll([]string{"a", "b"}))
+	assert.Equal([]string{"d", "c"}, scheduler.HasAll([]string{"d", "a", "c"}))
+	assert.Nil(scheduler.HasAll(nil))
+
+	assert.True(scheduler.HasAny([]string{"c", "b"}))
+	assert.False(scheduler.HasAny([]string{"c", "d"}))
+	assert.False(scheduler.HasAny(nil))
+
+	assert.Equal(map[string]bool{"a": true, "c": false}, scheduler.Exists("a", "c"))
+	assert.Empty(scheduler.Exists())
+}
+
+func TestFuncWithID(t *testing.T) {
+	t.Run("Verify ID variants satisfy validation", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.ErrorIs(scheduler.AddWithID("no-func", &Task{
+			Interval:      time.Hour,
+			ErrFuncWithID: func(string, error) {},
+		}), ErrTaskExecFunctionsNotSet)
+
+		assert.ErrorIs(scheduler.AddWithID("no-err-func", &Task{
+			Interval:   time.Hour,
+			FuncWithID: func(string) error { return nil },
+		}), ErrTaskErrFunctionsNotSet)
+
+		assert.NoError(scheduler.AddWithID("ids", &Task{
+			Interval:      time.Hour,
+			FuncWithID:    func(string) error { return nil },
+			ErrFuncWithID: func(string, error) {},
+		}))
+	})
+
+	t.Run("Verify the ID is passed to shared functions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		ranCh := make(chan string, 2)
+		failedCh := make(chan string, 2)
+
+		template := &Task{
+			Interval: 10 * time.Millisecond,
+			RunOnce:  true,
+			FuncWithID: func(id string) error {
+				ranCh <- id
+				return errors.New("some error")
+			},
+			ErrFuncWithID: func(id string, err error) {
+				failedCh <- id
+			},
+		}
+
+		assert.NoError(scheduler.AddWithID("first", template.Clone()))
+		assert.NoError(scheduler.AddWithID("second", template.Clone()))
+
+		for _, ch := range []chan string{ranCh, failedCh} {
+			var ids []string
+			for len(ids) < 2 {
+				select {
+				case id := <-ch:
+					ids = append(ids, id)
+				case <-time.After(time.Second):
+					t.Fatalf("StdScheduler failed to execute the scheduled tasks within 1 second")
+				}
+			}
+			assert.ElementsMatch([]string{"first", "second"}, ids)
+		}
+	})
+
+	t.Run("Verify precedence between variants", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		calledCh := make(chan string, 10)
+		errSome := errors.New("some error")
+
+		// The context variants win over the ID variants, which win over the plain ones
+		assert.NoError(scheduler.AddWithID("context", &Task{
+			Interval:               10 * time.Millisecond,
+			RunOnce:                true,
+			TaskFunc:               func() error { calledCh <- "TaskFunc"; return errSome },
+			FuncWithID:             func(string) error { calledCh <- "FuncWithID"; return errSome },
+			FuncWithTaskContext:    func(TaskContext) error { calledCh <- "FuncWithTaskContext"; return errSome },
+			ErrFunc:                func(error) { calledCh <- "ErrFunc" },
+			ErrFuncWithID:          func(string, error) { calledCh <- "ErrFuncWithID" },
+			ErrFuncWithTaskContext: func(TaskContext, error) { calledCh <- "ErrFuncWithTaskContext" },
+		}))
+
+		assert.Equal("FuncWithTaskContext", <-calledCh)
+		assert.Equal("ErrFuncWithTaskContext", <-calledCh)
+
+		assert.NoError(scheduler.AddWithID("id", &Task{
+			Interval:      10 * time.Millisecond,
+			RunOnce:       true,
+			TaskFunc:      func() error { calledCh <- "TaskFunc"; return errSome },
+			FuncWithID:    func(string) error { calledCh <- "FuncWithID"; return errSome },
+			ErrFunc:       func(error) { calledCh <- "ErrFunc" },
+			ErrFuncWithID: func(string, error) { calledCh <- "ErrFuncWithID" },
+		}))
+
+		assert.Equal("FuncWithID", <-calledCh)
+		assert.Equal("ErrFuncWithID", <-calledCh)
+	})
+}
+
+func TestWorkerLimitAuto(t *testing.T) {
+	defer func(f func() int) { gomaxprocs = f }(gomaxprocs)
+	gomaxprocs = func() int { return 4 }
+
+	tt := []struct {
+		name     string
+		opts     StdSchedulerOptions
+		expected int
+	}{
+		{name: "Unlimited", opts: StdSchedulerOptions{}, expected: 0},
+		{name: "Fixed", opts: StdSchedulerOptions{WorkerLimit: 3}, expected: 3},
+		{name: "Auto", opts: StdSchedulerOptions{WorkerLimit: WorkerLimitAuto}, expected: 4},
+		{
+			name:     "Auto with factor",
+			opts:     StdSchedulerOptions{WorkerLimit: WorkerLimitAuto, WorkerLimitFactor: 2.5},
+			expected: 10,
+		},
+		{
+			name:     "Auto with tiny factor",
+			opts:     StdSchedulerOptions{WorkerLimit: WorkerLimitAuto, WorkerLimitFactor: 0.1},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduler := NewStdScheduler(tc.opts)
+			defer scheduler.Stop()
+
+			assertions.Equal(t, tc.expected, scheduler.WorkerLimit())
+		})
+	}
+}
+
+func TestAddWait(t *testing.T) {
+	t.Run("Verify producers wait for capacity", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{TaskLimit: 1})
+		defer scheduler.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var completed atomic.Int32
+		for i := 0; i < 200; i++ {
+			_, err := scheduler.AddWait(ctx, &Task{
+				Interval: time.Microsecond,
+				RunOnce:  true,
+				TaskFunc: func() error {
+					completed.Add(1)
+					return nil
+				},
+				ErrFunc: func(e error) {},
+			})
+			if !assert.NoError(err) {
+				return
+			}
+		}
+
+		assert.Eventually(func() bool { return completed.Load() == 200 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify waiting honors the context", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{TaskLimit: 1})
+		defer scheduler.Stop()
+
+		task := &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		}
+		assert.NoError(scheduler.AddWithID("blocking", task))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(scheduler.AddWithIDWait(ctx, "waiting", task), context.DeadlineExceeded)
+
+		// Other errors are returned right away
+		assert.ErrorIs(scheduler.AddWithIDWait(context.Background(), "invalid", &Task{}), ErrTaskExecFunctionsNotSet)
+	})
+}
+
+func TestExecTaskAllocations(t *testing.T) {
+	var b bytes.Buffer
+	logger.With(logger.NewSimpleLogger(log.New(&b, "", log.LstdFlags), logger.LevelInfo), func() {
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		done := make(chan struct{}, 1)
+		err := scheduler.AddWithID("allocs", &Task{
+			Interval: time.Hour,
+			TaskFunc: func() error {
+				done <- struct{}{}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+
+		scheduler.RLock()
+		task := scheduler.tasks["allocs"]
+		scheduler.RUnlock()
+
+		// The timer is armed shortly after the task is added
+		for task.State() != TaskStateScheduled {
+			runtime.Gosched()
+		}
+
+		// Fire the task like its timer does, dispatch and re-arm included, and wait for the execution to return
+		allocs := testing.AllocsPerRun(100, func() {
+			scheduler.execTask(task)
+			<-done
+			for task.inFlight.Load() > 0 {
+				runtime.Gosched()
+			}
+		})
+		if allocs > 2 {
+			t.Errorf("Successful fire allocated %v times, expected at most 2", allocs)
+		}
+	})
+}
+
+func TestTaskReuse(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify one template yields independent schedules", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		type run struct {
+			id  string
+			err error
+		}
+		runCh := make(chan run, 10)
+
+		template := &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				select {
+				case runCh <- run{id: taskCtx.ID(), err: taskCtx.Context.Err()}:
+				default:
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}
+
+		assert.NoError(scheduler.AddWithID("template-a", template))
+		assert.NoError(scheduler.AddWithID("template-b", template))
+
+		a, err := scheduler.Lookup("template-a")
+		assert.NoError(err)
+		b, err := scheduler.Lookup("template-b")
+		assert.NoError(err)
+		assert.True(a.TaskContext.Context != b.TaskContext.Context)
+
+		scheduler.Del("template-a")
+		assert.ErrorIs(a.TaskContext.Context.Err(), context.Canceled)
+		assert.NoError(b.TaskContext.Context.Err())
+
+		// Drain runs started before the deletion
+		time.Sleep(20 * time.Millisecond)
+		for len(runCh) > 0 {
+			<-runCh
+		}
+
+		select {
+		case r := <-runCh:
+			assert.Equal("template-b", r.id)
+			assert.NoError(r.err)
+		case <-time.After(time.Second):
+			t.Errorf("Second schedule of the template did not execute within 1 second")
+		}
+
+		scheduler.Del("template-b")
+	})
+
+	t.Run("Verify a looked up task can be re-added", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		id, err := scheduler.Add(&Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		clone, err := scheduler.Lookup(id)
+		assert.NoError(err)
+
+		copyID, err := scheduler.Add(clone)
+		assert.NoError(err)
+
+		scheduler.Del(id)
+
+		copied, err := scheduler.Lookup(copyID)
+		assert.NoError(err)
+		assert.NoError(copied.TaskContext.Context.Err())
+
+		scheduler.Del(copyID)
+	})
+
+	t.Run("Verify a task cloned for reuse keeps running after the original is deleted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		runCh := make(chan string, 10)
+		id, err := scheduler.Add(&Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				select {
+				case runCh <- taskCtx.ID():
+				default:
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		original, err := scheduler.Lookup(id)
+		assert.NoError(err)
+
+		clone := original.CloneForReuse()
+		assert.Empty(clone.id)
+		assert.Nil(clone.TaskContext.Context)
+		assert.Nil(clone.TaskContext.Cancel)
+		assert.Nil(clone.timer)
+		assert.False(clone.registered)
+
+		cloneID, err := scheduler.Add(clone)
+		assert.NoError(err)
+		defer scheduler.Del(cloneID)
+
+		scheduler.Del(id)
+
+		// Drain runs started before the deletion
+		time.Sleep(20 * time.Millisecond)
+		for len(runCh) > 0 {
+			<-runCh
+		}
+
+		select {
+		case ranID := <-runCh:
+			assert.Equal(cloneID, ranID)
+		case <-time.After(time.Second):
+			t.Errorf("Task cloned for reuse did not execute within 1 second")
+		}
+
+		copied, err := scheduler.Lookup(cloneID)
+		assert.NoError(err)
+		assert.NoError(copied.TaskContext.Context.Err())
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 1})
+
+	t.Run("Verify task functions are never called", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		id, err := scheduler.Add(&Task{
+			Interval:       10 * time.Millisecond,
+			DryRun:         true,
+			DryRunDuration: 50 * time.Millisecond,
+			TaskFunc: func() error {
+				t.Errorf("TaskFunc should not be called")
+				return nil
+			},
+			ErrFunc: func(e error) {
+				t.Errorf("ErrFunc should not be called")
+			},
+		})
+		assert.NoError(err)
+
+		// Once the first dry run started, a second function has to wait for the single worker it holds
+		time.Sleep(20 * time.Millisecond)
+		start := time.Now()
+		doneCh := make(chan time.Time)
+
+		assert.NoError(scheduler.Submit(func() error {
+			doneCh <- time.Now()
+			return nil
+		}, func(e error) {}))
+
+		select {
+		case <-doneCh:
+		case <-time.After(time.Second):
+			t.Errorf("Submitted function did not execute within 1 second")
+		}
+
+		scheduler.Del(id)
+
+		assert.GreaterOrEqual(time.Since(start), 30*time.Millisecond)
+	})
+}
+
+func TestBindCancellation(t *testing.T) {
+	waitForGoroutines := func(t *testing.T, n int) {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > n {
+			if time.Now().After(deadline) {
+				t.Errorf("Goroutines leaked, expected at most %d, got %d", n, runtime.NumGoroutine())
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	t.Run("Verify task is deleted when the signal fires", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		id, err := scheduler.Add(&Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		done := make(chan struct{})
+		assert.NoError(scheduler.BindCancellation(id, done))
+		close(done)
+
+		assert.Eventually(func() bool { return !scheduler.Has(id) }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify binding is released when the task is deleted first", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		id, err := scheduler.Add(&Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		before := runtime.NumGoroutine()
+
+		done := make(chan struct{})
+		assert.NoError(scheduler.BindCancellation(id, done))
+		scheduler.Del(id)
+
+		waitForGoroutines(t, before)
+
+		// A new task with the same ID must not be deleted by the stale binding
+		assert.NoError(scheduler.AddWithID(id, &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		}))
+		close(done)
+		time.Sleep(10 * time.Millisecond)
+		assert.True(scheduler.Has(id))
+	})
+
+	t.Run("Verify binding is released on Stop", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+
+		id, err := scheduler.Add(&Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+
+		before := runtime.NumGoroutine()
+
+		assert.NoError(scheduler.BindCancellation(id, make(chan struct{})))
+		scheduler.Stop()
+
+		waitForGoroutines(t, before)
+	})
+
+	t.Run("Verify binding an unknown task fails", func(t *testing.T) {
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assertions.ErrorIs(t, scheduler.BindCancellation("unknown", make(chan struct{})), ErrTaskNotFound)
+	})
+}
+
+func TestMinGap(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	assertSpacing := func(t *testing.T, task *Task, startCh chan time.Time, gap time.Duration) {
+		id, err := scheduler.Add(task)
+		if err != nil {
+			t.Fatalf("Unexpected errors when scheduling a valid task - %s", err)
+		}
+		defer scheduler.Del(id)
+
+		var last time.Time
+		for i := 0; i < 4; i++ {
+			select {
+			case start := <-startCh:
+				if !last.IsZero() && start.Sub(last) < gap {
+					t.Errorf("Executions started %s apart, expected at least %s", start.Sub(last), gap)
+				}
+				last = start
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to execute the scheduled task %d run within 1 second", i)
+			}
+		}
+	}
+
+	t.Run("Verify intervals respect the minimum gap", func(t *testing.T) {
+		startCh := make(chan time.Time, 100)
+
+		assertSpacing(t, &Task{
+			Interval: 5 * time.Millisecond,
+			MinGap:   50 * time.Millisecond,
+			TaskFunc: func() error {
+				startCh <- time.Now()
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		}, startCh, 50*time.Millisecond)
+	})
+
+	t.Run("Verify retries respect the minimum gap", func(t *testing.T) {
+		startCh := make(chan time.Time, 100)
+
+		assertSpacing(t, &Task{
+			RunOnce:              true,
+			RetriesOnError:       5,
+			RetryOnErrorInterval: time.Millisecond,
+			MinGap:               50 * time.Millisecond,
+			TaskFunc: func() error {
+				startCh <- time.Now()
+				return errors.New("some error")
+			},
+			ErrFunc: func(e error) {},
+		}, startCh, 50*time.Millisecond)
+	})
+}
+
+func TestOnScheduleChange(t *testing.T) {
+	type change struct {
+		next   time.Time
+		reason string
+	}
+
+	var mu sync.Mutex
+	changes := map[string][]change{}
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{
+		OnScheduleChange: func(id string, next time.Time, reason string) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes[id] = append(changes[id], change{next: next, reason: reason})
+
+			if reason == "panic" {
+				panic("schedule change callback panic")
+			}
+		},
+	})
+	defer scheduler.Stop()
+
+	t.Run("Verify changes for a task that runs, fails, retries and is deleted", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		doneCh := make(chan struct{})
+
+		start := time.Now()
+		err := scheduler.AddWithID("changes", &Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: 20 * time.Millisecond,
+			TaskFunc: func() error {
+				return errors.New("some error")
+			},
+			ErrFunc: func(e error) {
+				doneCh <- struct{}{}
+			},
+		})
+		assert.NoError(err)
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-doneCh:
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to execute the scheduled task attempt %d within 1 second", i)
+			}
+		}
+
+		assert.Eventually(func() bool { return !scheduler.Has("changes") }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		var reasons []string
+		for _, c := range changes["changes"] {
+			reasons = append(reasons, c.reason)
+		}
+		assert.Equal([]string{"scheduled", "retry", "deleted"}, reasons)
+
+		if len(reasons) == 3 {
+			assert.WithinDuration(start.Add(10*time.Millisecond), changes["changes"][0].next, 5*time.Millisecond)
+			assert.True(changes["changes"][1].next.After(changes["changes"][0].next))
+			assert.True(changes["changes"][2].next.IsZero())
+		}
+	})
+
+	t.Run("Verify callback panics are recovered", func(t *testing.T) {
+		scheduler.notifyScheduleChange("panicking", time.Time{}, "panic")
+	})
+}
+
+func TestMinFirstDelay(t *testing.T) {
+	t.Run("Verify no execution starts before Add returns", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{MinFirstDelay: 10 * time.Millisecond})
+		defer scheduler.Stop()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			var added atomic.Bool
+
+			wg.Add(1)
+			_, err := scheduler.Add(&Task{
+				RunOnce: true,
+				TaskFunc: func() error {
+					defer wg.Done()
+
+					assert.True(added.Load(), "Task executed before Add returned")
+					return nil
+				},
+				ErrFunc: func(e error) {},
+			})
+			added.Store(true)
+			assert.NoError(err)
+		}
+		wg.Wait()
+	})
+
+	t.Run("Verify the first execution is delayed", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{MinFirstDelay: 50 * time.Millisecond})
+		defer scheduler.Stop()
+
+		ranCh := make(chan time.Time, 1)
+		added := time.Now()
+		_, err := scheduler.Add(&Task{
+			RunOnce:  true,
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				ranCh <- time.Now()
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		select {
+		case ran := <-ranCh:
+			assert.GreaterOrEqual(ran.Sub(added), 50*time.Millisecond)
+		case <-time.After(time.Second):
+			t.Errorf("Task did not execute within 1 second")
+		}
+	})
+}
+
+func TestUptime(t *testing.T) {
+	assert := assertions.New(t)
+
+	before := time.Now()
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	assert.False(scheduler.StartedAt().Before(before))
+	time.Sleep(10 * time.Millisecond)
+	assert.GreaterOrEqual(scheduler.Uptime(), 10*time.Millisecond)
+
+	task := &Task{
+		Interval: time.Minute,
+		TaskFunc: func() error { return nil },
+		ErrFunc:  func(e error) {},
+	}
+	assert.True(task.AddedAt().IsZero())
+
+	added := time.Now()
+	assert.NoError(scheduler.AddWithID("added", task))
+
+	scheduled, err := scheduler.Lookup("added")
+	assert.NoError(err)
+	assert.False(scheduled.AddedAt().Before(added))
+
+	// A copy added later has its own add time
+	assert.True(scheduled.CloneForReuse().AddedAt().IsZero())
+}
+
+// countingContext counts the contexts derived from it that have not been released yet. It is never done.
+type countingContext struct {
+	context.Context
+
+	done    chan struct{}
+	derived atomic.Int64
+}
+
+// Done returns a channel of its own, so that derived contexts register through AfterFunc.
+func (c *countingContext) Done() <-chan struct{} {
+	return c.done
+}
+
+// AfterFunc is called by context.WithCancel to register derived contexts with their parent.
+func (c *countingContext) AfterFunc(f func()) func() bool {
+	c.derived.Add(1)
+
+	var once sync.Once
+	return func() bool {
+		once.Do(func() { c.derived.Add(-1) })
+		return true
+	}
+}
+
+func TestFailedAddReleasesContexts(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{TaskLimit: 2})
+	defer scheduler.Stop()
+
+	parent := &countingContext{Context: context.Background(), done: make(chan struct{})}
+	newTask := func() *Task {
+		return &Task{
+			Interval:    time.Minute,
+			TaskContext: TaskContext{Context: parent},
+			TaskFunc:    func() error { return nil },
+			ErrFunc:     func(e error) {},
+		}
+	}
+
+	assert.NoError(scheduler.AddWithID("first", newTask()))
+	assert.Equal(int64(1), parent.derived.Load())
+
+	for i := 0; i < 1000; i++ {
+		assert.ErrorIs(scheduler.AddWithID("first", newTask()), ErrIDInUse)
+	}
+	assert.Equal(int64(1), parent.derived.Load())
+
+	assert.NoError(scheduler.AddWithID("second", newTask()))
+	for i := 0; i < 1000; i++ {
+		assert.ErrorIs(scheduler.AddWithID(fmt.Sprintf("over-limit-%d", i), newTask()), ErrTaskLimitExceeded)
+	}
+	assert.Equal(int64(2), parent.derived.Load())
+
+	scheduler.Del("first")
+	scheduler.Del("second")
+	assert.Zero(parent.derived.Load())
+}
+
+func TestRunOnceFirstExecution(t *testing.T) {
+	tt := []struct {
+		name       string
+		startAfter time.Duration
+		interval   time.Duration
+		expected   time.Duration
+		warning    bool
+	}{
+		{name: "Neither StartAfter nor Interval", expected: 0},
+		{name: "Interval only", interval: 100 * time.Millisecond, expected: 100 * time.Millisecond},
+		{name: "StartAfter only", startAfter: 100 * time.Millisecond, expected: 100 * time.Millisecond},
+		{
+			name:       "StartAfter and Interval",
+			startAfter: 100 * time.Millisecond,
+			interval:   200 * time.Millisecond,
+			expected:   100 * time.Millisecond,
+			warning:    true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assertions.New(t)
+
+			b := &lockedWriter{mu: &sync.Mutex{}, w: &bytes.Buffer{}}
+			logger.With(logger.NewSimpleLogger(log.New(b, "", 0), logger.LevelInfo), func() {
+				scheduler := NewStdScheduler(StdSchedulerOptions{})
+				defer scheduler.Stop()
+
+				ranCh := make(chan time.Time, 1)
+				added := time.Now()
+
+				task := &Task{
+					RunOnce:  true,
+					Interval: tc.interval,
+					TaskFunc: func() error {
+						ranCh <- time.Now()
+						return nil
+					},
+					ErrFunc: func(e error) {},
+				}
+				if tc.startAfter > 0 {
+					task.StartAfter = added.Add(tc.startAfter)
+				}
+				_, err := scheduler.Add(task)
+				assert.NoError(err)
+
+				select {
+				case ran := <-ranCh:
+					assert.GreaterOrEqual(ran.Sub(added), tc.expected)
+					assert.Less(ran.Sub(added), tc.expected+80*time.Millisecond)
+				case <-time.After(time.Second):
+					t.Errorf("Task did not execute within 1 second")
+				}
+			})
+
+			if tc.warning {
+				assert.Contains(b.String(), "its Interval of 200ms is ignored")
+			} else {
+				assert.NotContains(b.String(), "is ignored")
+			}
+		})
+	}
+}
+
+func TestTaskID(t *testing.T) {
+	assert := assertions.New(t)
+
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	task := &Task{
+		Interval: time.Minute,
+		TaskFunc: func() error { return nil },
+		ErrFunc:  func(error) {},
+	}
+	assert.Empty(task.ID())
+
+	id, err := scheduler.Add(task)
+	assert.NoError(err)
+	assert.NoError(scheduler.AddWithID("custom", task.CloneForReuse()))
+
+	for _, id := range []string{id, "custom"} {
+		assert.Equal(id, scheduler.Tasks()[id].ID())
+
+		looked, err := scheduler.Lookup(id)
+		if assert.NoError(err) {
+			assert.Equal(id, looked.ID())
+			assert.Equal(id, looked.Clone().ID())
+			assert.Empty(looked.CloneForReuse().ID())
+		}
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	t.Run("Verify the next run of a recurring task", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("slow", &Task{
+			Interval: 30 * time.Second,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		next, err := scheduler.NextRun("slow")
+		assert.NoError(err)
+		assert.WithinDuration(time.Now().Add(30*time.Second), next, time.Second)
+
+		looked, err := scheduler.Lookup("slow")
+		assert.NoError(err)
+		assert.Equal(next, looked.NextRun())
+
+		// Held fires have no pending execution
+		scheduler.PauseAll()
+		_, err = scheduler.NextRun("slow")
+		assert.ErrorIs(err, ErrNoNextRun)
+		scheduler.ResumeAll()
+	})
+
+	t.Run("Verify the next run moves forward after an execution", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		assert.NoError(scheduler.AddWithID("fast", &Task{
+			Interval: 50 * time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		first, err := scheduler.NextRun("fast")
+		assert.NoError(err)
+
+		assert.Eventually(func() bool {
+			next, err := scheduler.NextRun("fast")
+			return err == nil && next.After(first)
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify fired RunOnce tasks have no next run", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		assert.NoError(scheduler.AddWithID("once", &Task{
+			Interval: time.Millisecond,
+			RunOnce:  true,
+			TaskFunc: func() error {
+				close(started)
+				<-release
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+		<-started
+
+		next, err := scheduler.NextRun("once")
+		assert.ErrorIs(err, ErrNoNextRun)
+		assert.True(next.IsZero())
+
+		_, err = scheduler.NextRun("missing")
+		assert.ErrorIs(err, ErrTaskNotFound)
+	})
+}
+
+// statusError is a typed error carrying an HTTP status code.
+type statusError struct {
+	code int
+	err  error
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// timeoutError is a typed error of a timed out call.
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string {
+	return "timeout"
+}
+
+func (e *timeoutError) Timeout() bool {
+	return true
+}
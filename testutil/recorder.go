@@ -0,0 +1,236 @@
+/*
+Package testutil provides helpers to write fast, deterministic tests for code that schedules tasks.
+
+A TaskRecorder wraps a task function and records every invocation, while an ErrRecorder captures the errors
+delivered to the error function. Tests wait for the expected number of runs instead of sleeping.
+
+	recorder := testutil.NewTaskRecorder(func() error {
+		return doWork()
+	})
+	errs := &testutil.ErrRecorder{}
+
+	id, err := scheduler.Add(&tasks.Task{
+		Interval: 10 * time.Millisecond,
+		TaskFunc: recorder.TaskFunc,
+		ErrFunc:  errs.ErrFunc,
+	})
+	if err != nil {
+		// Do stuff
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := recorder.WaitForRuns(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+*/
+package testutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaelmaar/tasks"
+)
+
+// Invocation is a single recorded call of a task function.
+type Invocation struct {
+	// Time is when the function was called.
+	Time time.Time
+
+	// Attempt is the 1-based number of the invocation across the lifetime of the recorder.
+	Attempt int
+
+	// RunSequence is the run sequence of the task, only set for FuncWithTaskContext invocations.
+	RunSequence uint64
+
+	// Err is the error returned by the wrapped function.
+	Err error
+}
+
+// TaskRecorder wraps a task function and records each of its invocations.
+type TaskRecorder struct {
+	mu sync.Mutex
+
+	f           func(tasks.TaskContext) error
+	invocations []Invocation
+
+	// changed is closed and replaced every time an invocation is recorded.
+	changed chan struct{}
+}
+
+// NewTaskRecorder returns a recorder wrapping f. Use TaskRecorder.TaskFunc or TaskRecorder.FuncWithTaskContext as
+// the task function. A nil f always succeeds.
+func NewTaskRecorder(f func() error) *TaskRecorder {
+	return NewTaskRecorderWithTaskContext(func(tasks.TaskContext) error {
+		if f == nil {
+			return nil
+		}
+
+		return f()
+	})
+}
+
+// NewTaskRecorderWithTaskContext returns a recorder wrapping a function that receives the TaskContext. A nil f
+// always succeeds.
+func NewTaskRecorderWithTaskContext(f func(tasks.TaskContext) error) *TaskRecorder {
+	if f == nil {
+		f = func(tasks.TaskContext) error { return nil }
+	}
+
+	return &TaskRecorder{
+		f:       f,
+		changed: make(chan struct{}),
+	}
+}
+
+// TaskFunc calls the wrapped function and records the invocation. It matches Task.TaskFunc.
+func (r *TaskRecorder) TaskFunc() error {
+	return r.call(tasks.TaskContext{}, false)
+}
+
+// FuncWithTaskContext calls the wrapped function and records the invocation. It matches Task.FuncWithTaskContext.
+func (r *TaskRecorder) FuncWithTaskContext(taskCtx tasks.TaskContext) error {
+	return r.call(taskCtx, true)
+}
+
+// call runs the wrapped function and records its outcome.
+func (r *TaskRecorder) call(taskCtx tasks.TaskContext, withContext bool) error {
+	now := time.Now()
+	err := r.f(taskCtx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invocation := Invocation{
+		Time:    now,
+		Attempt: len(r.invocations) + 1,
+		Err:     err,
+	}
+	if withContext {
+		invocation.RunSequence = taskCtx.RunSequence()
+	}
+
+	r.invocations = append(r.invocations, invocation)
+
+	close(r.changed)
+	r.changed = make(chan struct{})
+
+	return err
+}
+
+// Invocations returns a copy of the recorded invocations, oldest first.
+func (r *TaskRecorder) Invocations() []Invocation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Invocation(nil), r.invocations...)
+}
+
+// Runs returns the number of recorded invocations.
+func (r *TaskRecorder) Runs() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.invocations)
+}
+
+// WaitForRuns blocks until at least n invocations are recorded, or returns the context error.
+func (r *TaskRecorder) WaitForRuns(ctx context.Context, n int) error {
+	for {
+		r.mu.Lock()
+		runs, changed := len(r.invocations), r.changed
+		r.mu.Unlock()
+
+		if runs >= n {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// AssertRanBetween reports a test error unless the number of recorded invocations is between n and m inclusive.
+func (r *TaskRecorder) AssertRanBetween(t testing.TB, n, m int) bool {
+	t.Helper()
+
+	runs := r.Runs()
+	if runs < n || runs > m {
+		t.Errorf("task ran %d times, expected between %d and %d", runs, n, m)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertRanExactly reports a test error unless exactly n invocations are recorded.
+func (r *TaskRecorder) AssertRanExactly(t testing.TB, n int) bool {
+	t.Helper()
+
+	return r.AssertRanBetween(t, n, n)
+}
+
+// ErrRecorder captures the errors delivered to a task error function. The zero value is ready to use.
+type ErrRecorder struct {
+	mu sync.Mutex
+
+	errs []error
+
+	// changed is closed and replaced every time an error is recorded.
+	changed chan struct{}
+}
+
+// ErrFunc records the error. It matches Task.ErrFunc.
+func (r *ErrRecorder) ErrFunc(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, err)
+
+	if r.changed != nil {
+		close(r.changed)
+	}
+	r.changed = make(chan struct{})
+}
+
+// ErrFuncWithTaskContext records the error. It matches Task.ErrFuncWithTaskContext.
+func (r *ErrRecorder) ErrFuncWithTaskContext(_ tasks.TaskContext, err error) {
+	r.ErrFunc(err)
+}
+
+// Errors returns a copy of the recorded errors, oldest first.
+func (r *ErrRecorder) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]error(nil), r.errs...)
+}
+
+// WaitForErrors blocks until at least n errors are recorded, or returns the context error.
+func (r *ErrRecorder) WaitForErrors(ctx context.Context, n int) error {
+	for {
+		r.mu.Lock()
+		if r.changed == nil {
+			r.changed = make(chan struct{})
+		}
+		count, changed := len(r.errs), r.changed
+		r.mu.Unlock()
+
+		if count >= n {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
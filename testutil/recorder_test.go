@@ -0,0 +1,71 @@
+package testutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks"
+	"github.com/shaelmaar/tasks/testutil"
+)
+
+func TestTaskRecorder(t *testing.T) {
+	t.Run("Verify invocations are recorded", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		someErr := errors.New("some error")
+		calls := 0
+		recorder := testutil.NewTaskRecorder(func() error {
+			calls++
+			if calls == 2 {
+				return someErr
+			}
+			return nil
+		})
+
+		assert.NoError(recorder.TaskFunc())
+		assert.ErrorIs(recorder.TaskFunc(), someErr)
+
+		invocations := recorder.Invocations()
+		if assert.Len(invocations, 2) {
+			assert.Equal(1, invocations[0].Attempt)
+			assert.NoError(invocations[0].Err)
+			assert.Equal(2, invocations[1].Attempt)
+			assert.ErrorIs(invocations[1].Err, someErr)
+			assert.False(invocations[1].Time.Before(invocations[0].Time))
+		}
+
+		recorder.AssertRanExactly(t, 2)
+		recorder.AssertRanBetween(t, 1, 3)
+
+		mock := &testing.T{}
+		assert.False(recorder.AssertRanBetween(mock, 3, 4))
+	})
+
+	t.Run("Verify WaitForRuns honors the context", func(t *testing.T) {
+		recorder := testutil.NewTaskRecorder(nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assertions.ErrorIs(t, recorder.WaitForRuns(ctx, 1), context.DeadlineExceeded)
+	})
+
+	t.Run("Verify errors are recorded", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		errs := &testutil.ErrRecorder{}
+
+		go errs.ErrFunc(errors.New("first"))
+		go errs.ErrFuncWithTaskContext(tasks.TaskContext{}, errors.New("second"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.NoError(errs.WaitForErrors(ctx, 2))
+		assert.Len(errs.Errors(), 2)
+	})
+}
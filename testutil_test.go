@@ -0,0 +1,79 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks"
+	"github.com/shaelmaar/tasks/testutil"
+)
+
+func TestWithRecorder(t *testing.T) {
+	t.Run("Verify RunOnce task retries are recorded", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := tasks.NewStdScheduler(tasks.StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		someErr := errors.New("some error")
+		recorder := testutil.NewTaskRecorderWithTaskContext(func(tasks.TaskContext) error {
+			return someErr
+		})
+		errs := &testutil.ErrRecorder{}
+
+		_, err := scheduler.Add(&tasks.Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       2,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			FuncWithTaskContext:  recorder.FuncWithTaskContext,
+			ErrFunc:              errs.ErrFunc,
+		})
+		assert.NoError(err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		assert.NoError(recorder.WaitForRuns(ctx, 3))
+		assert.NoError(errs.WaitForErrors(ctx, 1))
+
+		// Leave room for unexpected extra executions
+		time.Sleep(50 * time.Millisecond)
+		recorder.AssertRanExactly(t, 3)
+
+		for _, invocation := range recorder.Invocations() {
+			assert.ErrorIs(invocation.Err, someErr)
+			assert.Equal(uint64(1), invocation.RunSequence)
+		}
+	})
+
+	t.Run("Verify recurring task runs", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := tasks.NewStdScheduler(tasks.StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		recorder := testutil.NewTaskRecorder(nil)
+		errs := &testutil.ErrRecorder{}
+
+		id, err := scheduler.Add(&tasks.Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: recorder.TaskFunc,
+			ErrFunc:  errs.ErrFunc,
+		})
+		assert.NoError(err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		assert.NoError(recorder.WaitForRuns(ctx, 5))
+		scheduler.Del(id)
+
+		recorder.AssertRanBetween(t, 5, 6)
+		assert.Empty(errs.Errors())
+	})
+}
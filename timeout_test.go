@@ -0,0 +1,113 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTaskTimeout(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify every execution gets a fresh deadline", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var (
+			mu        sync.Mutex
+			deadlines []time.Time
+			errs      []error
+			errCtxErr error
+		)
+		assert.NoError(scheduler.AddWithID("hanging", &Task{
+			Interval: 30 * time.Millisecond,
+			Timeout:  10 * time.Millisecond,
+			FuncWithTaskContext: func(ctx TaskContext) error {
+				deadline, ok := ctx.Context.Deadline()
+				if !ok {
+					return errors.New("no deadline")
+				}
+
+				mu.Lock()
+				deadlines = append(deadlines, deadline)
+				mu.Unlock()
+
+				<-ctx.Context.Done()
+				return ctx.Context.Err()
+			},
+			ErrFuncWithTaskContext: func(ctx TaskContext, e error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, e)
+				errCtxErr = ctx.Context.Err()
+			},
+		}))
+		t.Cleanup(func() { scheduler.Del("hanging") })
+
+		assert.Eventually(func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(errs) >= 2
+		}, time.Second, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.ErrorIs(errs[0], context.DeadlineExceeded)
+		assert.ErrorIs(errs[1], context.DeadlineExceeded)
+		assert.NoError(errCtxErr)
+		assert.GreaterOrEqual(deadlines[1].Sub(deadlines[0]), 20*time.Millisecond)
+	})
+
+	t.Run("Verify a timed out RunOnce task is retried", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		var runs atomic.Int32
+		assert.NoError(scheduler.AddWithID("retried", &Task{
+			Interval:             5 * time.Millisecond,
+			RunOnce:              true,
+			Timeout:              10 * time.Millisecond,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: 5 * time.Millisecond,
+			FuncWithTaskContext: func(ctx TaskContext) error {
+				if runs.Add(1) == 1 {
+					<-ctx.Context.Done()
+					return ctx.Context.Err()
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("retried") }, time.Second, time.Millisecond)
+		assert.Equal(int32(2), runs.Load())
+	})
+
+	t.Run("Verify executions are not bounded without a timeout", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		hasDeadline := make(chan bool, 1)
+		assert.NoError(scheduler.AddWithID("unbounded", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(ctx TaskContext) error {
+				_, ok := ctx.Context.Deadline()
+				hasDeadline <- ok
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		select {
+		case ok := <-hasDeadline:
+			assert.False(ok)
+		case <-time.After(time.Second):
+			assert.Fail("task did not run")
+		}
+	})
+}
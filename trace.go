@@ -0,0 +1,176 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// decisionTraceSize is the number of decisions kept per task with Task.Debug enabled.
+const decisionTraceSize = 64
+
+// defaultMissedFireThreshold is used when StdSchedulerOptions.MissedFireThreshold is not set.
+const defaultMissedFireThreshold = time.Second
+
+// Decision is a kind of scheduling decision recorded for tasks with Task.Debug enabled.
+type Decision int
+
+// Scheduling decisions recorded in the decision trace.
+const (
+	DecisionTimerArmed Decision = iota
+	DecisionFireReceived
+	DecisionSkipped
+	DecisionExecutionStarted
+	DecisionExecutionFinished
+	DecisionRetryArmed
+	DecisionDeleted
+)
+
+// String returns the human readable name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case DecisionTimerArmed:
+		return "timer armed"
+	case DecisionFireReceived:
+		return "fire received"
+	case DecisionSkipped:
+		return "skipped"
+	case DecisionExecutionStarted:
+		return "execution started"
+	case DecisionExecutionFinished:
+		return "execution finished"
+	case DecisionRetryArmed:
+		return "retry armed"
+	case DecisionDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("decision(%d)", int(d))
+	}
+}
+
+// DecisionRecord is a single entry of a task decision trace.
+type DecisionRecord struct {
+	// Time is when the decision was taken.
+	Time time.Time `json:"time"`
+
+	// Decision is the kind of decision taken.
+	Decision Decision `json:"decision"`
+
+	// Delay is the timer delay for DecisionTimerArmed and DecisionRetryArmed records, in nanoseconds in JSON.
+	Delay time.Duration `json:"delay,omitempty"`
+
+	// Reason describes why the decision was taken, e.g. the skip reason or what armed the timer.
+	Reason string `json:"reason,omitempty"`
+}
+
+// String formats the record as a single log line.
+func (r DecisionRecord) String() string {
+	var b strings.Builder
+	b.WriteString(r.Time.Format(time.RFC3339Nano))
+	b.WriteString(" ")
+	b.WriteString(r.Decision.String())
+	if r.Delay > 0 {
+		b.WriteString(" delay=")
+		b.WriteString(r.Delay.String())
+	}
+	if r.Reason != "" {
+		b.WriteString(" reason=")
+		b.WriteString(r.Reason)
+	}
+
+	return b.String()
+}
+
+// decisionTrace is a bounded ring of the latest scheduling decisions of a task. A nil trace records nothing, which
+// keeps tasks without Task.Debug free of any tracing cost.
+type decisionTrace struct {
+	sync.Mutex
+
+	records [decisionTraceSize]DecisionRecord
+	next    int
+	full    bool
+}
+
+// record appends a decision to the trace, overwriting the oldest one when the trace is full.
+func (d *decisionTrace) record(decision Decision, delay time.Duration, reason string) {
+	if d == nil {
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.records[d.next] = DecisionRecord{
+		Time:     time.Now(),
+		Decision: decision,
+		Delay:    delay,
+		Reason:   reason,
+	}
+
+	d.next = (d.next + 1) % decisionTraceSize
+	if d.next == 0 {
+		d.full = true
+	}
+}
+
+// snapshot returns the recorded decisions, oldest first.
+func (d *decisionTrace) snapshot() []DecisionRecord {
+	if d == nil {
+		return nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if !d.full {
+		return append([]DecisionRecord(nil), d.records[:d.next]...)
+	}
+
+	records := make([]DecisionRecord, 0, decisionTraceSize)
+	records = append(records, d.records[d.next:]...)
+
+	return append(records, d.records[:d.next]...)
+}
+
+// DebugTrace will return the scheduling decisions recorded for the specified task, oldest first. Decisions are only
+// recorded for tasks with Task.Debug enabled, for any other task or an unknown ID the result is empty.
+func (s *StdScheduler) DebugTrace(id string) []DecisionRecord {
+	s.RLock()
+	t, ok := s.tasks[id]
+	s.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return t.trace.snapshot()
+}
+
+// reportMissedFire dumps the decision trace of a task at Warn level when it fired later than the threshold after
+// its expected fire time.
+func (s *StdScheduler) reportMissedFire(t *Task, expected, now time.Time) {
+	if t.trace == nil || expected.IsZero() {
+		return
+	}
+
+	threshold := s.opts.MissedFireThreshold
+	if threshold <= 0 {
+		threshold = defaultMissedFireThreshold
+	}
+
+	late := now.Sub(expected)
+	if late <= threshold {
+		return
+	}
+
+	var b strings.Builder
+	for _, r := range t.trace.snapshot() {
+		b.WriteString("\n\t")
+		b.WriteString(r.String())
+	}
+
+	logger.Warnf("task (id: %s) fired %s after its expected fire time %s, decision trace:%s",
+		t.id, late, expected.Format(logger.TimeFormatMicro), b.String())
+}
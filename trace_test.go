@@ -0,0 +1,114 @@
+package tasks
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+func TestDebugTrace(t *testing.T) {
+	scheduler := NewStdScheduler(StdSchedulerOptions{})
+	defer scheduler.Stop()
+
+	t.Run("Verify decisions are recorded for debug tasks", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		doneCh := make(chan struct{})
+
+		err := scheduler.AddWithID("traced", &Task{
+			Interval: 10 * time.Millisecond,
+			Debug:    true,
+			TaskFunc: func() error {
+				select {
+				case doneCh <- struct{}{}:
+				default:
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.Del("traced")
+
+		select {
+		case <-doneCh:
+		case <-time.After(time.Second):
+			t.Fatalf("StdScheduler failed to execute the scheduled task within 1 second")
+		}
+
+		// Wait for the execution to be recorded as finished
+		time.Sleep(5 * time.Millisecond)
+
+		var decisions []Decision
+		for _, r := range scheduler.DebugTrace("traced") {
+			decisions = append(decisions, r.Decision)
+		}
+
+		if assert.GreaterOrEqual(len(decisions), 6) {
+			assert.Equal([]Decision{
+				DecisionTimerArmed,
+				DecisionTimerArmed,
+				DecisionFireReceived,
+			}, decisions[:3])
+			assert.Contains(decisions[3:], DecisionExecutionStarted)
+			assert.Contains(decisions[3:], DecisionExecutionFinished)
+		}
+	})
+
+	t.Run("Verify nothing is recorded without Debug", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		err := scheduler.AddWithID("untraced", &Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(e error) {},
+		})
+		assert.NoError(err)
+		defer scheduler.Del("untraced")
+
+		time.Sleep(30 * time.Millisecond)
+		assert.Empty(scheduler.DebugTrace("untraced"))
+		assert.Empty(scheduler.DebugTrace("unknown"))
+	})
+
+	t.Run("Verify trace keeps the latest decisions", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		trace := &decisionTrace{}
+		for i := 0; i < decisionTraceSize+10; i++ {
+			trace.record(DecisionTimerArmed, time.Duration(i), "")
+		}
+
+		records := trace.snapshot()
+		assert.Len(records, decisionTraceSize)
+		assert.Equal(time.Duration(10), records[0].Delay)
+		assert.Equal(time.Duration(decisionTraceSize+9), records[decisionTraceSize-1].Delay)
+	})
+}
+
+func TestReportMissedFire(t *testing.T) {
+	assert := assertions.New(t)
+
+	var b bytes.Buffer
+	logger.With(logger.NewSimpleLogger(log.New(&b, "", log.LstdFlags), logger.LevelInfo), func() {
+		scheduler := NewStdScheduler(StdSchedulerOptions{MissedFireThreshold: 100 * time.Millisecond})
+		defer scheduler.Stop()
+
+		task := &Task{id: "late", trace: &decisionTrace{}}
+		task.trace.record(DecisionTimerArmed, time.Second, "interval")
+
+		now := time.Now()
+
+		scheduler.reportMissedFire(task, now.Add(-50*time.Millisecond), now)
+		assert.Empty(b.String())
+
+		scheduler.reportMissedFire(task, now.Add(-time.Second), now)
+		assert.Contains(b.String(), "task (id: late) fired 1s after its expected fire time")
+		assert.Contains(b.String(), "timer armed delay=1s reason=interval")
+	})
+}
@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// removalReasonTransferred is reported when a task is moved to another scheduler with Transfer.
+const removalReasonTransferred = "transferred"
+
+// transferPollInterval is how often Transfer checks whether the execution in flight returned.
+const transferPollInterval = 5 * time.Millisecond
+
+// transfers serializes the transfers, so that transfers between two schedulers in opposite directions can lock both
+// schedulers without deadlocking. No other operation locks two schedulers.
+var transfers sync.Mutex
+
+// TransferOptions configures TransferWithOptions.
+type TransferOptions struct {
+	// CarryInFlight transfers the task right away even when an execution is in flight. The execution keeps running on
+	// the source scheduler until it returns, but its outcome is not applied to the transferred task: it does not
+	// retry, and a checkpoint it sets is dropped. By default the transfer waits for a moment when no execution is in
+	// flight.
+	CarryInFlight bool
+}
+
+// Transfer will move the specified task to the destination scheduler, e.g. from a low priority scheduler to one
+// with more workers, keeping its ID, its configuration, its run count and latest run times, its checkpoint, its
+// remaining retries and reschedules, and its SLO and decision trace. The task is removed from the source and added to
+// the destination at once, and is then scheduled on the destination as if it had just been added. The removal is
+// reported to the OnScheduleChange of the source with the reason "transferred", and tasks waiting for it on the source
+// with AfterAll get ErrDependencyDeleted. If an execution is in flight, Transfer waits for it to return, see
+// TransferWithOptions to carry it over instead.
+//
+// It returns ErrTaskNotFound if the task does not exist, and the error AddWithID would return on the destination,
+// such as ErrTaskLimitExceeded, ErrIDInUse or ErrUnknownLane; the task then keeps running on the source untouched.
+//
+//	// Move the report to the scheduler with more workers
+//	if err := lowPriority.Transfer("report", highPriority); err != nil {
+//		// Do stuff
+//	}
+func (s *StdScheduler) Transfer(id string, dst *StdScheduler) error {
+	return s.TransferWithOptions(id, dst, TransferOptions{})
+}
+
+// TransferWithOptions will move the specified task to the destination scheduler as configured by opts. See
+// Transfer.
+func (s *StdScheduler) TransferWithOptions(id string, dst *StdScheduler, opts TransferOptions) error {
+	if dst == s {
+		if !s.Has(id) {
+			return ErrTaskNotFound
+		}
+
+		return nil
+	}
+
+	transfers.Lock()
+	defer transfers.Unlock()
+
+	for {
+		moved, carried, err := s.transfer(id, dst, opts)
+		if err != nil {
+			if errors.Is(err, ErrIDInUse) {
+				dst.recordIDCollision(id, IDCollisionDuplicate)
+			}
+
+			return err
+		}
+
+		if moved != nil {
+			s.transferred(id, carried)
+			dst.scheduleTask(moved)
+
+			logger.Infof("task (id: %s) has been transferred to another scheduler", id)
+
+			return nil
+		}
+
+		// An execution is in flight, check again once it had a chance to return
+		time.Sleep(transferPollInterval)
+	}
+}
+
+// transfer moves the task from the task list of the scheduler to the one of dst if no execution is in flight or
+// opts carries it over, returning the task added to dst and whether an execution was carried over. It returns a nil
+// task when it has to wait for the execution in flight.
+func (s *StdScheduler) transfer(id string, dst *StdScheduler, opts TransferOptions) (*Task, bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, false, ErrTaskNotFound
+	}
+
+	dst.Lock()
+	defer dst.Unlock()
+
+	if err := dst.admit(id); err != nil {
+		return nil, false, err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	carried := t.inFlight.Load() > 0
+	if carried && !opts.CarryInFlight {
+		return nil, false, nil
+	}
+
+	// The snapshot is prepared like a task added to dst, then gets back what the task accumulated so far
+	snapshot := t.clone()
+	snapshot.registered = false
+	moved, err := dst.prepareTask(snapshot)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if t.trace != nil {
+		moved.trace = t.trace
+	}
+	if t.slo != nil {
+		moved.slo = t.slo
+	}
+	moved.damping = t.damping
+	moved.retriesRemaining, moved.retryAttempts = t.retriesRemaining, t.retryAttempts
+	moved.failedAttempts = t.failedAttempts
+	if t.definition != nil && moved.definition != nil {
+		moved.definition = t.definition
+	}
+
+	dst.register(id, moved)
+	moved.addedAt = t.addedAt
+
+	// The task is stopped on the source like a deleted one, but a carried execution keeps its task context
+	delete(s.tasks, id)
+	s.freeCapacity()
+
+	_ = t.transition(eventRemove)
+	t.cancel()
+	t.disarm()
+	if t.deadlineTimer != nil {
+		t.deadlineTimer.Stop()
+	}
+	if t.boostTimer != nil {
+		t.boostTimer.Stop()
+	}
+	if t.snoozeTimer != nil {
+		t.snoozeTimer.Stop()
+	}
+	t.trace.record(DecisionDeleted, 0, removalReasonTransferred)
+
+	if carried {
+		t.carried = true
+	} else {
+		t.releaseContexts()
+	}
+
+	return moved, carried, nil
+}
+
+// transferred reports the removal of a transferred task from the scheduler, once every lock is released.
+func (s *StdScheduler) transferred(id string, carried bool) {
+	if carried {
+		logger.Warnf("task (id: %s) is transferred with an execution in flight, its outcome is dropped", id)
+	}
+
+	s.notifyScheduleChange(id, time.Time{}, removalReasonTransferred)
+
+	// Tasks waiting for it with AfterAll can not follow it to the destination
+	s.dependencyDone(id, dependencyDeleted)
+}
+
+// releaseContexts cancels the task contexts created by the scheduler for a transferred task. A context supplied by
+// the user is shared with the transferred task and left alone. The task lock must be held.
+func (t *Task) releaseContexts() {
+	if !t.ownsTaskContext {
+		return
+	}
+
+	if t.cancelDeadline != nil {
+		t.cancelDeadline(nil)
+	}
+	t.TaskContext.Cancel()
+}
+
+// endFlight counts an execution out when it returns, releasing the task context of a transferred task once its
+// carried executions are done.
+func (t *Task) endFlight() {
+	if t.inFlight.Add(-1) > 0 {
+		return
+	}
+
+	t.Lock()
+	if t.carried {
+		t.releaseContexts()
+	}
+	t.Unlock()
+}
@@ -0,0 +1,194 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTransfer(t *testing.T) {
+	t.Run("Verify a task is moved with its state", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		src := NewStdScheduler(StdSchedulerOptions{})
+		defer src.Stop()
+		dst := NewStdScheduler(StdSchedulerOptions{WorkerLimit: 4})
+		defer dst.Stop()
+
+		var (
+			runs     int64
+			failures int64
+		)
+		errBusy := errors.New("busy")
+		task := &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				if atomic.AddInt64(&runs, 1)%2 == 0 {
+					return errBusy
+				}
+				return taskCtx.SetCheckpoint([]byte("watermark"))
+			},
+			ErrFunc: func(error) { atomic.AddInt64(&failures, 1) },
+		}
+		task.WithRescheduleOnError(errBusy, 10*time.Millisecond, 100)
+		assert.NoError(src.AddWithID("report", task))
+
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 4 }, time.Second, time.Millisecond)
+
+		before, err := src.Lookup("report")
+		assert.NoError(err)
+		assert.NoError(src.Transfer("report", dst))
+		transferredAt := atomic.LoadInt64(&runs)
+
+		assert.False(src.Has("report"))
+		after, err := dst.Lookup("report")
+		assert.NoError(err)
+		assert.Equal(before.AddedAt(), after.AddedAt())
+		assert.GreaterOrEqual(after.RunSequence(), before.RunSequence())
+		assert.Equal("watermark", string(after.checkpoint))
+		assert.Less(after.RescheduleRules()[0].Remaining, 100)
+
+		// The task keeps running on the destination only
+		assert.Eventually(func() bool {
+			return atomic.LoadInt64(&runs) >= transferredAt+4
+		}, time.Second, time.Millisecond)
+		after, err = dst.Lookup("report")
+		assert.NoError(err)
+		assert.Greater(after.RunSequence(), before.RunSequence())
+	})
+
+	t.Run("Verify a rejected transfer leaves the task on the source", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		src := NewStdScheduler(StdSchedulerOptions{})
+		defer src.Stop()
+		full := NewStdScheduler(StdSchedulerOptions{TaskLimit: 1})
+		defer full.Stop()
+
+		var runs int64
+		newTask := func() *Task {
+			return &Task{
+				Interval: 10 * time.Millisecond,
+				TaskFunc: func() error {
+					atomic.AddInt64(&runs, 1)
+					return nil
+				},
+				ErrFunc: func(error) {},
+			}
+		}
+		assert.NoError(src.AddWithID("report", newTask()))
+		assert.NoError(src.AddWithID("other", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.NoError(full.AddWithID("other", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+
+		assert.ErrorIs(src.Transfer("report", full), ErrTaskLimitExceeded)
+		assert.ErrorIs(src.Transfer("missing", full), ErrTaskNotFound)
+
+		collides := NewStdScheduler(StdSchedulerOptions{})
+		defer collides.Stop()
+		assert.NoError(collides.AddWithID("report", &Task{
+			Interval: time.Minute,
+			TaskFunc: func() error { return nil },
+			ErrFunc:  func(error) {},
+		}))
+		assert.ErrorIs(src.Transfer("report", collides), ErrIDInUse)
+		assert.Equal(uint64(1), collides.IDCollisions().Duplicates)
+
+		// The source task still runs
+		assert.True(src.Has("report"))
+		seen := atomic.LoadInt64(&runs)
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) > seen+2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("Verify a transfer waits for the execution in flight", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		src := NewStdScheduler(StdSchedulerOptions{})
+		defer src.Stop()
+		dst := NewStdScheduler(StdSchedulerOptions{})
+		defer dst.Stop()
+
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		var (
+			runs     int64
+			finished int64
+		)
+		assert.NoError(src.AddWithID("slow", &Task{
+			Interval: 10 * time.Millisecond,
+			TaskFunc: func() error {
+				if atomic.AddInt64(&runs, 1) == 1 {
+					started <- struct{}{}
+					<-release
+				}
+				atomic.AddInt64(&finished, 1)
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		<-started
+		done := make(chan error)
+		go func() { done <- src.Transfer("slow", dst) }()
+
+		select {
+		case <-done:
+			t.Fatal("transfer did not wait for the execution in flight")
+		case <-time.After(30 * time.Millisecond):
+		}
+		assert.True(src.Has("slow"))
+
+		close(release)
+		assert.NoError(<-done)
+		assert.GreaterOrEqual(atomic.LoadInt64(&finished), int64(1))
+		assert.True(dst.Has("slow"))
+		assert.False(src.Has("slow"))
+	})
+
+	t.Run("Verify a transfer carries the execution in flight over", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		src := NewStdScheduler(StdSchedulerOptions{})
+		defer src.Stop()
+		dst := NewStdScheduler(StdSchedulerOptions{})
+		defer dst.Stop()
+
+		started := make(chan TaskContext, 1)
+		release := make(chan struct{})
+		var runs int64
+		assert.NoError(src.AddWithID("slow", &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				if atomic.AddInt64(&runs, 1) == 1 {
+					started <- taskCtx
+					<-release
+				}
+				return nil
+			},
+			ErrFunc: func(error) {},
+		}))
+
+		taskCtx := <-started
+		assert.NoError(src.TransferWithOptions("slow", dst, TransferOptions{CarryInFlight: true}))
+		assert.False(src.Has("slow"))
+		assert.True(dst.Has("slow"))
+
+		// The carried execution is not interrupted, the task runs on the destination meanwhile
+		assert.NoError(taskCtx.Context.Err())
+		assert.Eventually(func() bool { return atomic.LoadInt64(&runs) >= 3 }, time.Second, time.Millisecond)
+
+		close(release)
+		assert.Eventually(func() bool { return taskCtx.Context.Err() != nil }, time.Second, time.Millisecond)
+		assert.True(dst.Has("slow"))
+	})
+}
@@ -0,0 +1,40 @@
+package tasks
+
+import "fmt"
+
+// Trigger tells why an execution is happening.
+type Trigger int
+
+// Triggers reported by TaskContext.Trigger.
+const (
+	// TriggerInterval is a regular fire of the task interval.
+	TriggerInterval Trigger = iota
+	// TriggerStartAfter is the first fire of a task with Task.StartAfter set.
+	TriggerStartAfter
+	// TriggerRetry is a retry of a failed task, see Task.RetriesOnError.
+	TriggerRetry
+	// TriggerRescheduleOnError is a reschedule after an error matching Task.WithRescheduleOnError.
+	TriggerRescheduleOnError
+)
+
+// String returns the human readable name of the trigger.
+func (tr Trigger) String() string {
+	switch tr {
+	case TriggerInterval:
+		return "interval"
+	case TriggerStartAfter:
+		return "start after"
+	case TriggerRetry:
+		return "retry"
+	case TriggerRescheduleOnError:
+		return "reschedule on error"
+	default:
+		return fmt.Sprintf("trigger(%d)", int(tr))
+	}
+}
+
+// Trigger will return why the current execution is happening, e.g. TriggerRetry for a retry of a failed execution.
+// ErrFuncWithTaskContext receives the trigger of the failing execution.
+func (ctx TaskContext) Trigger() Trigger {
+	return ctx.trigger
+}
@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestTrigger(t *testing.T) {
+	t.Run("Verify interval and start after triggers", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		triggerCh := make(chan Trigger, 10)
+
+		_, err := scheduler.Add(&Task{
+			Interval:   10 * time.Millisecond,
+			StartAfter: time.Now().Add(10 * time.Millisecond),
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				select {
+				case triggerCh <- taskCtx.Trigger():
+				default:
+				}
+				return nil
+			},
+			ErrFunc: func(e error) {},
+		})
+		assert.NoError(err)
+
+		for _, expected := range []Trigger{TriggerStartAfter, TriggerInterval, TriggerInterval} {
+			select {
+			case trigger := <-triggerCh:
+				assert.Equal(expected, trigger)
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to execute the scheduled task within 1 second")
+			}
+		}
+	})
+
+	t.Run("Verify retry triggers reach the error function", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		triggerCh := make(chan Trigger, 10)
+		errTriggerCh := make(chan Trigger, 10)
+
+		_, err := scheduler.Add(&Task{
+			Interval:             10 * time.Millisecond,
+			RunOnce:              true,
+			RetriesOnError:       1,
+			RetryOnErrorInterval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				triggerCh <- taskCtx.Trigger()
+				return errors.New("some error")
+			},
+			ErrFuncWithTaskContext: func(taskCtx TaskContext, e error) {
+				errTriggerCh <- taskCtx.Trigger()
+			},
+		})
+		assert.NoError(err)
+
+		for _, expected := range []Trigger{TriggerInterval, TriggerRetry} {
+			select {
+			case trigger := <-triggerCh:
+				assert.Equal(expected, trigger)
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to execute the scheduled task within 1 second")
+			}
+
+			select {
+			case trigger := <-errTriggerCh:
+				assert.Equal(expected, trigger)
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to call the error function within 1 second")
+			}
+		}
+	})
+
+	t.Run("Verify reschedule on error triggers", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+		defer scheduler.Stop()
+
+		errSome := errors.New("some error")
+		triggerCh := make(chan Trigger, 10)
+
+		task := &Task{
+			Interval: 10 * time.Millisecond,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				// Let the interval timer be re-armed before the reschedule
+				time.Sleep(2 * time.Millisecond)
+				triggerCh <- taskCtx.Trigger()
+				return errSome
+			},
+			ErrFunc: func(e error) {},
+		}
+		task.WithRescheduleOnError(errSome, 50*time.Millisecond, 1)
+
+		_, err := scheduler.Add(task)
+		assert.NoError(err)
+
+		for _, expected := range []Trigger{TriggerInterval, TriggerRescheduleOnError} {
+			select {
+			case trigger := <-triggerCh:
+				assert.Equal(expected, trigger)
+			case <-time.After(time.Second):
+				t.Fatalf("StdScheduler failed to execute the scheduled task within 1 second")
+			}
+		}
+	})
+}
+
+func TestTriggerString(t *testing.T) {
+	assert := assertions.New(t)
+
+	assert.Equal("retry", TriggerRetry.String())
+	assert.Equal("trigger(42)", Trigger(42).String())
+}
@@ -0,0 +1,117 @@
+package tasks
+
+// version is the version of the package. Released builds may set it with
+// -ldflags "-X github.com/shaelmaar/tasks.version=v1.2.3".
+var version = "v0.0.0-devel"
+
+// Version will return the version of the package linked in the binary.
+func Version() string {
+	return version
+}
+
+// Feature is a capability of the scheduler that depends on the version of the package, see Supports.
+type Feature int
+
+// Features of the scheduler. The zero value is not a feature.
+const (
+	// FeaturePause is StdScheduler.PauseAll and StdScheduler.ResumeAll.
+	FeaturePause Feature = iota + 1
+	// FeatureScheduleEvents is StdSchedulerOptions.OnScheduleChange.
+	FeatureScheduleEvents
+	// FeatureAudit is StdSchedulerOptions.AuditWriter.
+	FeatureAudit
+	// FeatureCron is Task.CronExpr.
+	FeatureCron
+	// FeatureLanes is StdSchedulerOptions.Lanes.
+	FeatureLanes
+	// FeatureDeadLetters is StdScheduler.DeadLetters and StdScheduler.Requeue.
+	FeatureDeadLetters
+	// FeatureAfterAll is StdScheduler.AfterAll.
+	FeatureAfterAll
+	// FeatureReplicas is StdScheduler.AddReplicated.
+	FeatureReplicas
+	// FeatureBoost is StdScheduler.Boost.
+	FeatureBoost
+	// FeatureSnooze is StdScheduler.Snooze.
+	FeatureSnooze
+	// FeatureTransfer is StdScheduler.Transfer.
+	FeatureTransfer
+	// FeatureTaskStatus is StdScheduler.TaskStatus and StdScheduler.NextRun.
+	FeatureTaskStatus
+	// FeatureConcurrencyLimit is Task.MaxConcurrent.
+	FeatureConcurrencyLimit
+	// FeaturePanicRecovery is the recovery of panics in task and error functions, see PanicError.
+	FeaturePanicRecovery
+	// FeatureFixedDelay is Task.Mode.
+	FeatureFixedDelay
+	// FeatureDispatchGroups is Task.Group.
+	FeatureDispatchGroups
+	// FeatureSkipIfRunning is Task.SkipIfRunning.
+	FeatureSkipIfRunning
+	// FeatureRetryBackoff is Task.RetryBackoff.
+	FeatureRetryBackoff
+	// FeatureRecurringRetries is Task.RetriesOnError for recurring tasks.
+	FeatureRecurringRetries
+	// FeatureDelWhere is StdScheduler.DelWhere.
+	FeatureDelWhere
+	// FeatureCancellation is StdScheduler.IsCancelled and StdScheduler.CancellationChan.
+	FeatureCancellation
+	// FeatureRescheduleMatchers is Task.WithRescheduleOnErrorAs and Task.WithRescheduleOnErrorFunc.
+	FeatureRescheduleMatchers
+	// FeatureExhaustedFunc is Task.ExhaustedFunc and Task.ExhaustedFuncWithTaskContext.
+	FeatureExhaustedFunc
+	// FeatureInlineRetries is Task.InlineRetries.
+	FeatureInlineRetries
+	// FeatureAttempt is TaskContext.Attempt.
+	FeatureAttempt
+
+	// featureEnd follows the last feature.
+	featureEnd
+)
+
+// featureNames are the names of the features, by feature.
+var featureNames = map[Feature]string{
+	FeaturePause:              "pause",
+	FeatureScheduleEvents:     "schedule events",
+	FeatureAudit:              "audit",
+	FeatureCron:               "cron",
+	FeatureLanes:              "lanes",
+	FeatureDeadLetters:        "dead letters",
+	FeatureAfterAll:           "after all",
+	FeatureReplicas:           "replicas",
+	FeatureBoost:              "boost",
+	FeatureSnooze:             "snooze",
+	FeatureTransfer:           "transfer",
+	FeatureTaskStatus:         "task status",
+	FeatureConcurrencyLimit:   "concurrency limit",
+	FeaturePanicRecovery:      "panic recovery",
+	FeatureFixedDelay:         "fixed delay",
+	FeatureDispatchGroups:     "dispatch groups",
+	FeatureSkipIfRunning:      "skip if running",
+	FeatureRetryBackoff:       "retry backoff",
+	FeatureRecurringRetries:   "recurring retries",
+	FeatureDelWhere:           "delete where",
+	FeatureCancellation:       "cancellation",
+	FeatureRescheduleMatchers: "reschedule matchers",
+	FeatureExhaustedFunc:      "exhausted function",
+	FeatureInlineRetries:      "inline retries",
+	FeatureAttempt:            "attempt",
+}
+
+// String returns the name of the feature.
+func (f Feature) String() string {
+	if name, ok := featureNames[f]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// Supports will return whether the linked version of the package implements the feature. Libraries built against a
+// newer version can use it to gate optional behaviour instead of failing at runtime. Unknown features are not
+// supported.
+func Supports(feature Feature) bool {
+	_, ok := featureNames[feature]
+
+	return ok
+}
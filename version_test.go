@@ -0,0 +1,34 @@
+package tasks
+
+import (
+	"testing"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	assert := assertions.New(t)
+
+	assert.NotEmpty(Version())
+	assert.Equal(version, Version())
+}
+
+func TestSupports(t *testing.T) {
+	t.Run("Verify every feature is supported", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		for f := FeaturePause; f < featureEnd; f++ {
+			assert.True(Supports(f), "feature %d", f)
+			assert.NotEqual("unknown", f.String(), "feature %d", f)
+		}
+	})
+
+	t.Run("Verify unknown features are not supported", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		assert.False(Supports(0))
+		assert.False(Supports(featureEnd))
+		assert.False(Supports(Feature(-1)))
+		assert.Equal("unknown", featureEnd.String())
+	})
+}
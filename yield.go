@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/shaelmaar/tasks/logger"
+)
+
+// Draining will return a channel closed when the scheduler starts shutting down, before the task context is
+// cancelled. Long executions can watch it to store their progress with SetCheckpoint and return ErrYielded. It is
+// nil, and never closes, for a task context not passed by a scheduler.
+//
+//	FuncWithTaskContext: func(taskCtx tasks.TaskContext) error {
+//		for offset := decode(taskCtx.Checkpoint()); offset < total; offset++ {
+//			select {
+//			case <-taskCtx.Draining():
+//				_ = taskCtx.SetCheckpoint(encode(offset))
+//				return tasks.ErrYielded
+//			default:
+//			}
+//			process(offset)
+//		}
+//		return nil
+//	},
+func (ctx TaskContext) Draining() <-chan struct{} {
+	return ctx.draining
+}
+
+// drain closes the draining channel of the task contexts, once.
+func (s *StdScheduler) drain() {
+	s.drainOnce.Do(func() { close(s.draining) })
+}
+
+// yieldTask handles an execution that returned ErrYielded. It is not a failure: the checkpoint it set is kept, the
+// error functions are not called and no retry is consumed. A RunOnce task resumes after its interval, in the same
+// execution cycle.
+func (s *StdScheduler) yieldTask(t *Task, taskCtx TaskContext) {
+	t.trace.record(DecisionExecutionFinished, 0, "yielded")
+	t.commitCheckpoint(taskCtx.pendingCheckpoint)
+	t.endRun(taskCtx.runTimes.Started, nil)
+	s.audit(t, taskCtx, ErrYielded, false)
+
+	logger.Infof("task (id: %s, run: %s) yielded, it resumes on its next execution", t.id, taskCtx.runID)
+
+	// The last execution allowed by MaxRuns is not resumed
+	if !t.RunOnce {
+		if t.finish(true) == TaskStateRemoved || taskCtx.finalRun {
+			s.complete(t)
+
+			return
+		}
+		s.armAfterRun(t, taskCtx)
+
+		return
+	}
+
+	var (
+		next  time.Time
+		armed bool
+	)
+	t.safeOps(func() {
+		if t.transition(eventRetry) != nil {
+			return
+		}
+
+		t.retryPending = true
+		next, armed = s.resetTimer(t, t.Interval, DecisionTimerArmed, TriggerInterval)
+	})
+	if armed {
+		s.notifyScheduleChange(t.id, next, TriggerInterval.String())
+	}
+
+	if t.State() == TaskStateRemoved {
+		s.complete(t)
+	}
+}
@@ -0,0 +1,120 @@
+package tasks
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestYield(t *testing.T) {
+	t.Run("Verify a long execution yields when the scheduler stops", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		scheduler := NewStdScheduler(StdSchedulerOptions{})
+
+		started := make(chan struct{})
+		yielded := make(chan struct{})
+		var failures atomic.Int32
+
+		assert.NoError(scheduler.AddWithID("batch", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			SLO:      &SLO{SuccessRatio: 0.99, Window: time.Minute},
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				close(started)
+
+				select {
+				case <-taskCtx.Draining():
+					_ = taskCtx.SetCheckpoint([]byte("offset=42"))
+					close(yielded)
+					return ErrYielded
+				case <-time.After(5 * time.Second):
+					return nil
+				}
+			},
+			ErrFunc: func(error) { failures.Add(1) },
+		}))
+
+		<-started
+		scheduler.RLock()
+		task := scheduler.tasks["batch"]
+		scheduler.RUnlock()
+
+		scheduler.Stop()
+
+		select {
+		case <-yielded:
+		case <-time.After(time.Second):
+			assert.Fail("task did not yield")
+		}
+
+		assert.Eventually(func() bool {
+			var checkpoint []byte
+			task.safeOps(func() { checkpoint = task.checkpoint })
+			return string(checkpoint) == "offset=42"
+		}, time.Second, time.Millisecond)
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Zero(failures.Load())
+
+		// Yielding is not counted towards the SLO
+		task.slo.Lock()
+		for _, b := range task.slo.buckets {
+			assert.Zero(b.total)
+		}
+		task.slo.Unlock()
+	})
+
+	t.Run("Verify a yielded RunOnce task resumes from its checkpoint", func(t *testing.T) {
+		assert := assertions.New(t)
+
+		w := &memoryAuditWriter{}
+		scheduler := NewStdScheduler(StdSchedulerOptions{AuditWriter: w})
+
+		var (
+			mu          sync.Mutex
+			checkpoints []string
+			failures    atomic.Int32
+		)
+		assert.NoError(scheduler.AddWithID("resumed", &Task{
+			Interval: 5 * time.Millisecond,
+			RunOnce:  true,
+			FuncWithTaskContext: func(taskCtx TaskContext) error {
+				mu.Lock()
+				checkpoints = append(checkpoints, string(taskCtx.Checkpoint()))
+				first := len(checkpoints) == 1
+				mu.Unlock()
+
+				if first {
+					_ = taskCtx.SetCheckpoint([]byte("half"))
+					return ErrYielded
+				}
+				return nil
+			},
+			ErrFunc: func(error) { failures.Add(1) },
+		}))
+
+		assert.Eventually(func() bool { return !scheduler.Has("resumed") }, time.Second, time.Millisecond)
+		scheduler.Stop()
+
+		mu.Lock()
+		assert.Equal([]string{"", "half"}, checkpoints)
+		mu.Unlock()
+		assert.Zero(failures.Load())
+
+		rr := w.byTask("resumed")
+		if assert.Len(rr, 2) {
+			assert.Equal(AuditOutcomeYielded, rr[0].Outcome)
+			assert.NoError(rr[0].Err)
+			assert.Equal(AuditOutcomeSuccess, rr[1].Outcome)
+			assert.Equal(rr[0].RunSequence, rr[1].RunSequence)
+		}
+	})
+
+	t.Run("Verify task contexts not passed by a scheduler never drain", func(t *testing.T) {
+		assertions.Nil(t, TaskContext{}.Draining())
+	})
+}